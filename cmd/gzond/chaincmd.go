@@ -98,6 +98,9 @@ if one is set.  Otherwise it prints the genesis from the datadir.`,
 			utils.TransactionHistoryFlag,
 			utils.StateSchemeFlag,
 			utils.StateHistoryFlag,
+			utils.StateDiffsFlag,
+			utils.VMTraceFlag,
+			utils.VMTraceJsonConfigFlag,
 		}, utils.DatabasePathFlags),
 		Description: `
 The import command imports blocks from an RLP-encoded form. The form can be one file
@@ -122,6 +125,35 @@ Optional second and third arguments control the first and
 last block to write. In this mode, the file will be appended
 if already existing. If the file ends with .gz, the output will
 be gzipped.`,
+	}
+	importHistoryCommand = &cli.Command{
+		Action:    importHistory,
+		Name:      "import-history",
+		Usage:     "Import the chain history from era files",
+		ArgsUsage: "<dir>",
+		Flags: flags.Merge([]cli.Flag{
+			utils.CacheFlag,
+			utils.TransactionHistoryFlag,
+			utils.StateSchemeFlag,
+		}, utils.DatabasePathFlags),
+		Description: `
+The import-history command imports the blocks and receipts stored in the era files
+of the given directory. The era files are verified before importing and must
+cover the chain from the genesis block on without gaps.`,
+	}
+	exportHistoryCommand = &cli.Command{
+		Action:    exportHistory,
+		Name:      "export-history",
+		Usage:     "Export the chain history into era files",
+		ArgsUsage: "<dir> <blockNumFirst> <blockNumLast>",
+		Flags: flags.Merge([]cli.Flag{
+			utils.CacheFlag,
+			utils.StateSchemeFlag,
+		}, utils.DatabasePathFlags),
+		Description: `
+The export-history command exports the blocks and receipts in the given range
+into era files in the given directory. The blocks are exported in whole epochs of
+8192 blocks, one era file each, the last epoch being cut at the last block.`,
 	}
 	dumpCommand = &cli.Command{
 		Action:    dump,
@@ -341,6 +373,45 @@ func exportChain(ctx *cli.Context) error {
 	return nil
 }
 
+func importHistory(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, db := utils.MakeChain(ctx, stack, false)
+	defer db.Close()
+
+	start := time.Now()
+	if err := utils.ImportHistory(chain, ctx.Args().First()); err != nil {
+		utils.Fatalf("Import error: %v\n", err)
+	}
+	fmt.Printf("Import done in %v\n", time.Since(start))
+	return nil
+}
+
+func exportHistory(ctx *cli.Context) error {
+	if ctx.Args().Len() != 3 {
+		utils.Fatalf("This command requires three arguments.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, _ := utils.MakeChain(ctx, stack, true)
+	first, ferr := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+	last, lerr := strconv.ParseUint(ctx.Args().Get(2), 10, 64)
+	if ferr != nil || lerr != nil {
+		utils.Fatalf("Export error in parsing parameters: block number not an integer\n")
+	}
+	start := time.Now()
+	if err := utils.ExportHistory(chain, ctx.Args().First(), first, last); err != nil {
+		utils.Fatalf("Export error: %v\n", err)
+	}
+	fmt.Printf("Export done in %v\n", time.Since(start))
+	return nil
+}
+
 func parseDumpConfig(ctx *cli.Context, stack *node.Node) (*state.DumpConfig, zonddb.Database, common.Hash, error) {
 	db := utils.MakeChainDatabase(ctx, stack, true)
 	var header *types.Header
@@ -299,6 +299,10 @@ func applyMetricConfig(ctx *cli.Context, cfg *gzondConfig) {
 
 func deprecated(field string) bool {
 	switch field {
+	case "legacypool.Config.Journal":
+		return true
+	case "legacypool.Config.Rejournal":
+		return true
 	default:
 		return false
 	}
@@ -0,0 +1,40 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Tests that the configs written before the local transactions were moved into
+// the database still load.
+func TestLoadConfigDeprecatedJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	config := "[Zond.TxPool]\nJournal = \"transactions.rlp\"\nRejournal = 3600000000000\nPriceLimit = 2\n"
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	var cfg gzondConfig
+	if err := loadConfig(path, &cfg); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Zond.TxPool.PriceLimit != 2 {
+		t.Errorf("price limit mismatch: have %d, want 2", cfg.Zond.TxPool.PriceLimit)
+	}
+}
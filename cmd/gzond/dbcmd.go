@@ -65,6 +65,7 @@ Remove blockchain and state databases`,
 			dbPutCmd,
 			dbGetSlotsCmd,
 			dbDumpFreezerIndex,
+			dbMigrateAncientCmd,
 			dbImportCmd,
 			dbExportCmd,
 			dbMetadataCmd,
@@ -165,6 +166,18 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		}, utils.NetworkFlags, utils.DatabasePathFlags),
 		Description: "This command displays information about the freezer index.",
 	}
+	dbMigrateAncientCmd = &cli.Command{
+		Action: migrateAncient,
+		Name:   "migrate-ancient",
+		Usage:  "Convert the flat-file chain ancient store into a Pebble backed one",
+		Flags: flags.Merge([]cli.Flag{
+			utils.SyncModeFlag,
+		}, utils.NetworkFlags, utils.DatabasePathFlags),
+		Description: `This command converts the chain segments stored in the flat-file ancient store
+into a dedicated Pebble instance, in place. The new store is only swapped in once the
+conversion is complete, an interrupted migration leaves the flat files intact and can
+be restarted. The node must not be running.`,
+	}
 	dbImportCmd = &cli.Command{
 		Action:    importLDBdata,
 		Name:      "import",
@@ -557,6 +570,14 @@ func freezerInspect(ctx *cli.Context) error {
 	return rawdb.InspectFreezerTable(ancient, freezer, table, start, end)
 }
 
+func migrateAncient(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	ancient := stack.ResolveAncient("chaindata", ctx.String(utils.AncientFlag.Name))
+	return rawdb.MigrateChainFreezer(ancient)
+}
+
 func importLDBdata(ctx *cli.Context) error {
 	start := 0
 	switch ctx.NArg() {
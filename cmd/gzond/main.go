@@ -40,6 +40,7 @@ import (
 
 	// Force-load the tracer engines to trigger registration
 	_ "github.com/theQRL/go-zond/zond/tracers/js"
+	_ "github.com/theQRL/go-zond/zond/tracers/live"
 	_ "github.com/theQRL/go-zond/zond/tracers/native"
 	"go.uber.org/automaxprocs/maxprocs"
 
@@ -74,15 +75,24 @@ var (
 		utils.TxPoolGlobalSlotsFlag,
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
+		utils.TxPoolGlobalBytesFlag,
 		utils.TxPoolLifetimeFlag,
+		utils.TxPoolSimulateFlag,
+		utils.BlobPoolDataDirFlag,
+		utils.BlobPoolDataCapFlag,
+		utils.BlobPoolPriceBumpFlag,
 		utils.SyncModeFlag,
 		utils.SyncTargetFlag,
 		utils.ExitWhenSyncedFlag,
 		utils.GCModeFlag,
 		utils.SnapshotFlag,
 		utils.TransactionHistoryFlag,
+		utils.HistoryHorizonFlag,
+		utils.HistoryEraDirFlag,
 		utils.StateSchemeFlag,
 		utils.StateHistoryFlag,
+		utils.StateDiffsFlag,
+		utils.TraceIndexFlag,
 		utils.LightKDFFlag,
 		utils.ZondRequiredBlocksFlag,
 		utils.BloomFilterSizeFlag,
@@ -103,6 +113,8 @@ var (
 		utils.MinerGasPriceFlag,
 		utils.MinerExtraDataFlag,
 		utils.MinerRecommitIntervalFlag,
+		utils.MinerStrategyFlag,
+		utils.MinerSkipRevertingFlag,
 		utils.MinerPendingFeeRecipientFlag,
 		utils.NATFlag,
 		utils.NoDiscoverFlag,
@@ -116,6 +128,8 @@ var (
 		utils.DeveloperGasLimitFlag,
 		utils.DeveloperPeriodFlag,
 		utils.VMEnableDebugFlag,
+		utils.VMTraceFlag,
+		utils.VMTraceJsonConfigFlag,
 		utils.NetworkIdFlag,
 		utils.ZondStatsURLFlag,
 		utils.NoCompactionFlag,
@@ -186,6 +200,8 @@ func init() {
 		initCommand,
 		importCommand,
 		exportCommand,
+		importHistoryCommand,
+		exportHistoryCommand,
 		removedbCommand,
 		dumpCommand,
 		dumpGenesisCommand,
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/theQRL/go-zond/cmd/utils"
@@ -134,6 +135,35 @@ verification. The default checking target is the HEAD state. It's basically iden
 to traverse-state, but the check granularity is smaller. 
 
 It's also usable without snapshot enabled.
+`,
+			},
+			{
+				Name:      "export",
+				Usage:     "Export the state snapshot and the header chain into an archive",
+				ArgsUsage: "<file> [<blockNum>]",
+				Action:    exportSnapshot,
+				Flags:     flags.Merge([]cli.Flag{utils.StateSchemeFlag}, utils.NetworkFlags, utils.DatabasePathFlags),
+				Description: `
+gzond snapshot export <file> [<blockNum>]
+will export the flat state snapshot of the given block, along with the canonical
+headers up to it, into a chunked and checksummed archive. The block needs to be
+recent enough for its state to be present in the snapshot, the default being the
+head block.
+`,
+			},
+			{
+				Name:      "import",
+				Usage:     "Bootstrap a node from a state snapshot archive",
+				ArgsUsage: "<file>",
+				Action:    importSnapshot,
+				Flags:     flags.Merge([]cli.Flag{utils.StateSchemeFlag}, utils.NetworkFlags, utils.DatabasePathFlags),
+				Description: `
+gzond snapshot import <file>
+will import a state snapshot archive created by 'gzond snapshot export' into a
+freshly initialized node. The state trie is rebuilt from the snapshot and verified
+against the state root of the archived block, which then becomes the head of the
+chain, letting the node continue syncing from there. The history below the block
+is not available.
 `,
 			},
 			{
@@ -635,3 +665,59 @@ func checkAccount(ctx *cli.Context) error {
 	log.Info("Checked the snapshot journalled storage", "time", common.PrettyDuration(time.Since(start)))
 	return nil
 }
+
+// exportSnapshot exports the state snapshot of the given or the head block into
+// an archive.
+func exportSnapshot(ctx *cli.Context) error {
+	if ctx.NArg() < 1 || ctx.NArg() > 2 {
+		return errors.New("expected the archive file and an optional block number")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chaindb := utils.MakeChainDatabase(ctx, stack, true)
+	defer chaindb.Close()
+
+	header := rawdb.ReadHeadHeader(chaindb)
+	if ctx.NArg() == 2 {
+		number, err := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+		if err != nil {
+			return err
+		}
+		header = rawdb.ReadHeader(chaindb, rawdb.ReadCanonicalHash(chaindb, number), number)
+	}
+	if header == nil {
+		return errors.New("block not found")
+	}
+	triedb := utils.MakeTrieDatabase(ctx, chaindb, false, true)
+	defer triedb.Close()
+
+	snapConfig := snapshot.Config{
+		CacheSize:  256,
+		Recovery:   false,
+		NoBuild:    true,
+		AsyncBuild: false,
+	}
+	snaptree, err := snapshot.New(snapConfig, chaindb, triedb, rawdb.ReadHeadHeader(chaindb).Root)
+	if err != nil {
+		return err
+	}
+	return utils.ExportSnapshot(chaindb, snaptree, header, ctx.Args().First())
+}
+
+// importSnapshot bootstraps the node from a state snapshot archive.
+func importSnapshot(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("expected the archive file")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	// Open the chain for initializing the genesis block if not yet done
+	chain, chaindb := utils.MakeChain(ctx, stack, false)
+	chain.Stop()
+	defer chaindb.Close()
+
+	_, err := utils.ImportSnapshot(chaindb, ctx.Args().First())
+	return err
+}
@@ -23,8 +23,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"syscall"
@@ -33,12 +35,15 @@ import (
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/state/snapshot"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/internal/debug"
+	"github.com/theQRL/go-zond/internal/era"
 	"github.com/theQRL/go-zond/log"
 	"github.com/theQRL/go-zond/node"
 	"github.com/theQRL/go-zond/rlp"
+	"github.com/theQRL/go-zond/trie"
 	"github.com/theQRL/go-zond/zond/zondconfig"
 	"github.com/theQRL/go-zond/zonddb"
 	"github.com/urfave/cli/v2"
@@ -296,6 +301,344 @@ func ExportAppendChain(blockchain *core.BlockChain, fn string, first uint64, las
 	return nil
 }
 
+// ExportHistory exports the blocks between first and last, inclusive, along
+// with their receipts into era files in the given directory. The blocks are
+// exported in whole epochs, the last one being cut at the last block.
+func ExportHistory(bc *core.BlockChain, dir string, first, last uint64) error {
+	log.Info("Exporting chain history", "dir", dir, "first", first, "last", last)
+
+	if head := bc.CurrentSnapBlock().Number.Uint64(); last > head {
+		return fmt.Errorf("last block %d larger than head block %d", last, head)
+	}
+	if first > last {
+		return fmt.Errorf("first block %d larger than last block %d", first, last)
+	}
+	if cutoff := bc.HistoryPruningCutoff(); first < cutoff {
+		return fmt.Errorf("history below block %d is pruned", cutoff)
+	}
+	var (
+		start   = time.Now()
+		network = era.NetworkName(bc.Config().ChainID)
+	)
+	for epoch := first / era.MaxEraBatchSize; epoch <= last/era.MaxEraBatchSize; epoch++ {
+		path, err := era.Export(bc, dir, network, epoch, last+1)
+		if err != nil {
+			return fmt.Errorf("failed to export epoch %d: %w", epoch, err)
+		}
+		log.Info("Exported era file", "epoch", epoch, "path", path)
+	}
+	log.Info("Exported chain history", "dir", dir, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// ImportHistory imports the blocks and receipts from the era files in the given
+// directory into the chain. The era files are verified before importing, and
+// must start at the genesis block. The bodies and receipts of the blocks already
+// known to the chain are restored, if they were expired.
+func ImportHistory(chain *core.BlockChain, dir string) error {
+	network := era.NetworkName(chain.Config().ChainID)
+	files, err := era.ReadDir(dir, network)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no era files found for network %s in %s", network, dir)
+	}
+	var (
+		start    = time.Now()
+		imported int
+		restored int
+	)
+	for i, file := range files {
+		err := func() error {
+			e, err := era.Open(filepath.Join(dir, file))
+			if err != nil {
+				return err
+			}
+			defer e.Close()
+
+			if e.Start() != uint64(i)*era.MaxEraBatchSize {
+				return fmt.Errorf("era file %s starts at block %d, want %d", file, e.Start(), uint64(i)*era.MaxEraBatchSize)
+			}
+			if err := e.Verify(); err != nil {
+				return fmt.Errorf("failed to verify era file %s: %w", file, err)
+			}
+			var (
+				blocks   = make(types.Blocks, 0, importBatchSize)
+				receipts = make([]types.Receipts, 0, importBatchSize)
+
+				known         = make(types.Blocks, 0, importBatchSize)
+				knownReceipts = make([]types.Receipts, 0, importBatchSize)
+			)
+			restore := func() error {
+				if len(known) == 0 {
+					return nil
+				}
+				n, err := chain.RestoreHistory(known, knownReceipts)
+				if err != nil {
+					return err
+				}
+				restored += n
+				known, knownReceipts = known[:0], knownReceipts[:0]
+				return nil
+			}
+			flush := func() error {
+				if len(blocks) == 0 {
+					return nil
+				}
+				headers := make([]*types.Header, len(blocks))
+				for j, block := range blocks {
+					headers[j] = block.Header()
+				}
+				if _, err := chain.InsertHeaderChain(headers); err != nil {
+					return err
+				}
+				if _, err := chain.InsertReceiptChain(blocks, receipts, math.MaxUint64); err != nil {
+					return err
+				}
+				imported += len(blocks)
+				blocks, receipts = blocks[:0], receipts[:0]
+				return nil
+			}
+			for number := e.Start(); number < e.Start()+e.Count(); number++ {
+				block, blockReceipts, err := e.GetBlockWithReceiptsByNumber(number)
+				if err != nil {
+					return err
+				}
+				if number == 0 {
+					if block.Hash() != chain.Genesis().Hash() {
+						return fmt.Errorf("genesis mismatch: have %x, want %x", block.Hash(), chain.Genesis().Hash())
+					}
+					continue
+				}
+				// The history of known blocks may have been expired, write it
+				// back instead of inserting the blocks anew.
+				if number <= chain.CurrentSnapBlock().Number.Uint64() {
+					known, knownReceipts = append(known, block), append(knownReceipts, blockReceipts)
+					if len(known) == importBatchSize {
+						if err := restore(); err != nil {
+							return err
+						}
+					}
+					continue
+				}
+				blocks, receipts = append(blocks, block), append(receipts, blockReceipts)
+				if len(blocks) == importBatchSize {
+					if err := flush(); err != nil {
+						return err
+					}
+				}
+			}
+			if err := restore(); err != nil {
+				return err
+			}
+			return flush()
+		}()
+		if err != nil {
+			return err
+		}
+		log.Info("Imported era file", "file", file, "blocks", imported, "restored", restored, "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+	return nil
+}
+
+// snapshotBlock is the payload of the block chunk of a snapshot archive.
+type snapshotBlock struct {
+	Body     rlp.RawValue
+	Receipts rlp.RawValue
+}
+
+// ExportSnapshot writes the canonical headers up to the given block, the body
+// and receipts of the block and its state from the snapshot tree into a
+// snapshot archive, for bootstrapping other nodes with ImportSnapshot.
+func ExportSnapshot(db zonddb.Database, snaptree *snapshot.Tree, header *types.Header, fn string) error {
+	log.Info("Exporting snapshot", "file", fn, "number", header.Number, "hash", header.Hash(), "root", header.Root)
+
+	var (
+		number = header.Number.Uint64()
+		hash   = header.Hash()
+	)
+	body, receipts := rawdb.ReadBodyRLP(db, hash, number), rawdb.ReadReceiptsRLP(db, hash, number)
+	if len(body) == 0 || len(receipts) == 0 {
+		return fmt.Errorf("block %d is not available", number)
+	}
+	fh, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	w, err := snapshot.NewArchiveWriter(fh)
+	if err != nil {
+		return err
+	}
+	meta := &snapshot.ArchiveMetadata{
+		Genesis: rawdb.ReadCanonicalHash(db, 0),
+		Number:  number,
+		Hash:    hash,
+		Root:    header.Root,
+	}
+	if err := w.WriteChunk(snapshot.ArchiveMeta, meta); err != nil {
+		return err
+	}
+	// Export the canonical header chain in batches
+	var (
+		headers []rlp.RawValue
+		size    int
+	)
+	for n := uint64(0); n <= number; n++ {
+		blob := rawdb.ReadHeaderRLP(db, rawdb.ReadCanonicalHash(db, n), n)
+		if len(blob) == 0 {
+			return fmt.Errorf("header %d is not available", n)
+		}
+		headers, size = append(headers, blob), size+len(blob)
+		if size >= 1024*1024 || n == number {
+			if err := w.WriteChunk(snapshot.ArchiveHeaders, headers); err != nil {
+				return err
+			}
+			headers, size = headers[:0], 0
+		}
+	}
+	if err := w.WriteChunk(snapshot.ArchiveBlock, &snapshotBlock{Body: body, Receipts: receipts}); err != nil {
+		return err
+	}
+	if err := snapshot.ExportState(w, snaptree, header.Root, db); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	log.Info("Exported snapshot", "file", fn)
+	return nil
+}
+
+// ImportSnapshot imports a snapshot archive into a database only containing
+// the genesis block. The state trie is rebuilt from the archived state and
+// verified against the state root of the archived block, which becomes the
+// head of the chain. The history below it is marked as pruned.
+func ImportSnapshot(db zonddb.Database, fn string) (*types.Header, error) {
+	log.Info("Importing snapshot", "file", fn)
+
+	genesis := rawdb.ReadCanonicalHash(db, 0)
+	if genesis == (common.Hash{}) {
+		return nil, errors.New("database is not initialized with a genesis block")
+	}
+	if head := rawdb.ReadHeadHeader(db); head == nil || head.Number.Uint64() != 0 {
+		return nil, errors.New("database already contains blocks beyond genesis")
+	}
+	if frozen, err := db.Ancients(); err != nil {
+		return nil, fmt.Errorf("ancient store unavailable: %w", err)
+	} else if frozen != 0 {
+		return nil, errors.New("ancient store is not empty")
+	}
+	scheme := rawdb.ReadStateScheme(db)
+	if scheme == "" {
+		return nil, errors.New("state scheme of database unknown")
+	}
+	fh, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+
+	r, err := snapshot.NewArchiveReader(fh)
+	if err != nil {
+		return nil, err
+	}
+	var meta snapshot.ArchiveMetadata
+	if err := r.ReadChunk(snapshot.ArchiveMeta, &meta); err != nil {
+		return nil, err
+	}
+	if meta.Genesis != genesis {
+		return nil, fmt.Errorf("genesis mismatch: have %x, want %x", genesis, meta.Genesis)
+	}
+	// Import the header chain, freezing all the headers below the head
+	var parent *types.Header
+	for parent == nil || parent.Number.Uint64() < meta.Number {
+		var chunk []*types.Header
+		if err := r.ReadChunk(snapshot.ArchiveHeaders, &chunk); err != nil {
+			return nil, err
+		}
+		batch := db.NewBatch()
+		for i, header := range chunk {
+			switch {
+			case parent == nil && header.Hash() != genesis:
+				return nil, fmt.Errorf("genesis header mismatch: have %x, want %x", header.Hash(), genesis)
+			case parent != nil && (header.Number.Uint64() != parent.Number.Uint64()+1 || header.ParentHash != parent.Hash()):
+				return nil, fmt.Errorf("header %d not linked to its parent", header.Number)
+			case header.Number.Uint64() > meta.Number:
+				return nil, fmt.Errorf("header %d beyond the snapshot block %d", header.Number, meta.Number)
+			}
+			rawdb.WriteHeaderNumber(batch, header.Hash(), header.Number.Uint64())
+			parent = chunk[i]
+		}
+		if parent.Number.Uint64() == meta.Number {
+			chunk = chunk[:len(chunk)-1]
+		}
+		if _, err := rawdb.WriteAncientHeaderChain(db, chunk); err != nil {
+			return nil, err
+		}
+		if err := batch.Write(); err != nil {
+			return nil, err
+		}
+	}
+	head := parent
+	if head.Hash() != meta.Hash || head.Root != meta.Root {
+		return nil, fmt.Errorf("snapshot block mismatch: have %x, want %x", head.Hash(), meta.Hash)
+	}
+	// Verify the body and receipts of the head block
+	var (
+		chunk    snapshotBlock
+		body     types.Body
+		receipts []*types.ReceiptForStorage
+	)
+	if err := r.ReadChunk(snapshot.ArchiveBlock, &chunk); err != nil {
+		return nil, err
+	}
+	if err := rlp.DecodeBytes(chunk.Body, &body); err != nil {
+		return nil, err
+	}
+	if err := rlp.DecodeBytes(chunk.Receipts, &receipts); err != nil {
+		return nil, err
+	}
+	block := types.NewBlockWithHeader(head).WithBody(body)
+	if hash := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)); hash != head.TxHash {
+		return nil, fmt.Errorf("transaction root mismatch: have %x, want %x", hash, head.TxHash)
+	}
+	if len(receipts) != len(body.Transactions) {
+		return nil, fmt.Errorf("receipt count mismatch: have %d, want %d", len(receipts), len(body.Transactions))
+	}
+	derived := make(types.Receipts, len(receipts))
+	for i, receipt := range receipts {
+		receipt.Type = body.Transactions[i].Type()
+		derived[i] = (*types.Receipt)(receipt)
+	}
+	if hash := types.DeriveSha(derived, trie.NewStackTrie(nil)); hash != head.ReceiptHash {
+		return nil, fmt.Errorf("receipt root mismatch: have %x, want %x", hash, head.ReceiptHash)
+	}
+	// Rebuild the state and mark the head block as the chain head
+	if err := snapshot.ImportState(r, db, scheme, head.Root); err != nil {
+		return nil, err
+	}
+	batch := db.NewBatch()
+	rawdb.WriteHeader(batch, head)
+	rawdb.WriteBodyRLP(batch, head.Hash(), head.Number.Uint64(), chunk.Body)
+	rawdb.WriteReceipts(batch, head.Hash(), head.Number.Uint64(), derived)
+	rawdb.WriteCanonicalHash(batch, head.Hash(), head.Number.Uint64())
+	rawdb.WriteHeadHeaderHash(batch, head.Hash())
+	rawdb.WriteHeadFastBlockHash(batch, head.Hash())
+	rawdb.WriteHeadBlockHash(batch, head.Hash())
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	// The history below the head block is not available
+	if _, err := db.TruncateTail(head.Number.Uint64()); err != nil {
+		return nil, err
+	}
+	log.Info("Imported snapshot", "file", fn, "number", head.Number, "hash", head.Hash(), "root", head.Root)
+	return head, nil
+}
+
 // ImportPreimages imports a batch of exported hash preimages into the database.
 // It's a part of the deprecated functionality, should be removed in the future.
 func ImportPreimages(db zonddb.Database, fn string) error {
@@ -20,6 +20,7 @@ package utils
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -41,6 +42,7 @@ import (
 	"github.com/theQRL/go-zond/common/fdlimit"
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/txpool/blobpool"
 	"github.com/theQRL/go-zond/core/txpool/legacypool"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto"
@@ -101,6 +103,12 @@ var (
 		Value:    node.DefaultConfig.DBEngine,
 		Category: flags.ZondCategory,
 	}
+	AncientEngineFlag = &cli.StringFlag{
+		Name:     "db.ancient.engine",
+		Usage:    "Backing implementation of the chain ancient store ('file' or 'pebble')",
+		Value:    node.DefaultConfig.AncientEngine,
+		Category: flags.ZondCategory,
+	}
 	AncientFlag = &flags.DirectoryFlag{
 		Name:     "datadir.ancient",
 		Usage:    "Root directory for ancient data (default = inside chaindata)",
@@ -243,7 +251,7 @@ var (
 	}
 	GCModeFlag = &cli.StringFlag{
 		Name:     "gcmode",
-		Usage:    `Blockchain garbage collection mode, only relevant in state.scheme=hash ("full", "archive")`,
+		Usage:    `Blockchain garbage collection mode ("full", "archive"), archive indexes state histories in state.scheme=path`,
 		Value:    "full",
 		Category: flags.StateCategory,
 	}
@@ -258,12 +266,33 @@ var (
 		Value:    zondconfig.Defaults.StateHistory,
 		Category: flags.StateCategory,
 	}
+	StateDiffsFlag = &cli.BoolFlag{
+		Name:     "history.statediffs",
+		Usage:    "Persist the account and storage changes of each block, retained as long as the state history",
+		Category: flags.StateCategory,
+	}
+	TraceIndexFlag = &cli.BoolFlag{
+		Name:     "history.traces",
+		Usage:    "Index the call traces of the new blocks by address, serving the trace namespace without re-executing them",
+		Category: flags.StateCategory,
+	}
 	TransactionHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.transactions",
 		Usage:    "Number of recent blocks to maintain transactions index for (default = about one year, 0 = entire chain)",
 		Value:    zondconfig.Defaults.TransactionHistory,
 		Category: flags.StateCategory,
 	}
+	HistoryHorizonFlag = &cli.Uint64Flag{
+		Name:     "history.horizon",
+		Usage:    "Number of recent blocks to retain bodies and receipts for, older ones are exported into era files and pruned (0 = entire chain)",
+		Value:    zondconfig.Defaults.HistoryHorizon,
+		Category: flags.StateCategory,
+	}
+	HistoryEraDirFlag = &cli.StringFlag{
+		Name:     "history.era",
+		Usage:    "Directory to export the expired chain history into (default = inside the datadir)",
+		Category: flags.StateCategory,
+	}
 	// Transaction pool settings
 	TxPoolLocalsFlag = &cli.StringFlag{
 		Name:     "txpool.locals",
@@ -275,18 +304,6 @@ var (
 		Usage:    "Disables price exemptions for locally submitted transactions",
 		Category: flags.TxPoolCategory,
 	}
-	TxPoolJournalFlag = &cli.StringFlag{
-		Name:     "txpool.journal",
-		Usage:    "Disk journal for local transaction to survive node restarts",
-		Value:    zondconfig.Defaults.TxPool.Journal,
-		Category: flags.TxPoolCategory,
-	}
-	TxPoolRejournalFlag = &cli.DurationFlag{
-		Name:     "txpool.rejournal",
-		Usage:    "Time interval to regenerate the local transaction journal",
-		Value:    zondconfig.Defaults.TxPool.Rejournal,
-		Category: flags.TxPoolCategory,
-	}
 	TxPoolPriceLimitFlag = &cli.Uint64Flag{
 		Name:     "txpool.pricelimit",
 		Usage:    "Minimum gas price tip to enforce for acceptance into the pool",
@@ -323,12 +340,42 @@ var (
 		Value:    zondconfig.Defaults.TxPool.GlobalQueue,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolGlobalBytesFlag = &cli.Uint64Flag{
+		Name:     "txpool.globalbytes",
+		Usage:    "Maximum total size in bytes of the transactions for all accounts",
+		Value:    zondconfig.Defaults.TxPool.GlobalBytes,
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolLifetimeFlag = &cli.DurationFlag{
 		Name:     "txpool.lifetime",
 		Usage:    "Maximum amount of time non-executable transaction are queued",
 		Value:    zondconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolSimulateFlag = &cli.BoolFlag{
+		Name:     "txpool.simulate",
+		Usage:    "Execute the pending transactions against the pending state to predict their outcome",
+		Category: flags.TxPoolCategory,
+	}
+	// Blob transaction pool settings
+	BlobPoolDataDirFlag = &cli.StringFlag{
+		Name:     "blobpool.datadir",
+		Usage:    "Data directory to store blob transactions in",
+		Value:    zondconfig.Defaults.BlobPool.Datadir,
+		Category: flags.BlobPoolCategory,
+	}
+	BlobPoolDataCapFlag = &cli.Uint64Flag{
+		Name:     "blobpool.datacap",
+		Usage:    "Disk space to allocate for pending blob transactions (soft limit)",
+		Value:    zondconfig.Defaults.BlobPool.Datacap,
+		Category: flags.BlobPoolCategory,
+	}
+	BlobPoolPriceBumpFlag = &cli.Uint64Flag{
+		Name:     "blobpool.pricebump",
+		Usage:    "Price bump percentage to replace an already existing blob transaction",
+		Value:    zondconfig.Defaults.BlobPool.PriceBump,
+		Category: flags.BlobPoolCategory,
+	}
 	// Performance tuning settings
 	CacheFlag = &cli.IntFlag{
 		Name:     "cache",
@@ -406,6 +453,17 @@ var (
 		Value:    zondconfig.Defaults.Miner.Recommit,
 		Category: flags.MinerCategory,
 	}
+	MinerStrategyFlag = &cli.StringFlag{
+		Name:     "miner.strategy",
+		Usage:    "Transaction ordering strategy used to build blocks (price, bundle)",
+		Value:    zondconfig.Defaults.Miner.Strategy,
+		Category: flags.MinerCategory,
+	}
+	MinerSkipRevertingFlag = &cli.BoolFlag{
+		Name:     "miner.skipreverting",
+		Usage:    "Skip the transactions predicted to revert by the pool simulation (requires --txpool.simulate)",
+		Category: flags.MinerCategory,
+	}
 	MinerPendingFeeRecipientFlag = &cli.StringFlag{
 		Name:     "miner.pending.feeRecipient",
 		Usage:    "Z prefixed public address for the pending block producer (not used for actual block production)",
@@ -443,6 +501,17 @@ var (
 		Usage:    "Record information useful for VM and contract debugging",
 		Category: flags.VMCategory,
 	}
+	VMTraceFlag = &cli.StringFlag{
+		Name:     "vmtrace",
+		Usage:    "Name of the live tracer tracing the whole block processing (e.g. 'jsonl')",
+		Category: flags.VMCategory,
+	}
+	VMTraceJsonConfigFlag = &cli.StringFlag{
+		Name:     "vmtrace.jsonconfig",
+		Usage:    "Tracer configuration of the live tracer (JSON)",
+		Value:    "{}",
+		Category: flags.VMCategory,
+	}
 
 	// API options.
 	RPCGlobalGasCapFlag = &cli.Uint64Flag{
@@ -867,7 +936,7 @@ var (
 
 func init() {
 	if rawdb.PebbleEnabled {
-		DatabasePathFlags = append(DatabasePathFlags, DBEngineFlag)
+		DatabasePathFlags = append(DatabasePathFlags, DBEngineFlag, AncientEngineFlag)
 	}
 }
 
@@ -1278,6 +1347,16 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 		log.Info(fmt.Sprintf("Using %s as db engine", dbEngine))
 		cfg.DBEngine = dbEngine
 	}
+	if ctx.IsSet(AncientEngineFlag.Name) {
+		engine := ctx.String(AncientEngineFlag.Name)
+		if engine != rawdb.AncientEngineFile && engine != rawdb.AncientEnginePebble {
+			Fatalf("Invalid choice for db.ancient.engine '%s', allowed '%s' or '%s'", engine, rawdb.AncientEngineFile, rawdb.AncientEnginePebble)
+		}
+		if engine == rawdb.AncientEnginePebble && !rawdb.PebbleEnabled {
+			Fatalf("db.ancient.engine '%s' not supported on this platform", engine)
+		}
+		cfg.AncientEngine = engine
+	}
 }
 
 func setSmartCard(ctx *cli.Context, cfg *node.Config) {
@@ -1343,11 +1422,8 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolNoLocalsFlag.Name) {
 		cfg.NoLocals = ctx.Bool(TxPoolNoLocalsFlag.Name)
 	}
-	if ctx.IsSet(TxPoolJournalFlag.Name) {
-		cfg.Journal = ctx.String(TxPoolJournalFlag.Name)
-	}
-	if ctx.IsSet(TxPoolRejournalFlag.Name) {
-		cfg.Rejournal = ctx.Duration(TxPoolRejournalFlag.Name)
+	if ctx.IsSet(TxPoolJournalFlag.Name) || ctx.IsSet(TxPoolRejournalFlag.Name) {
+		log.Warn("The txpool journal flags are deprecated and will be removed in the future, local transactions are stored in the database")
 	}
 	if ctx.IsSet(TxPoolPriceLimitFlag.Name) {
 		cfg.PriceLimit = ctx.Uint64(TxPoolPriceLimitFlag.Name)
@@ -1367,9 +1443,27 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolGlobalQueueFlag.Name) {
 		cfg.GlobalQueue = ctx.Uint64(TxPoolGlobalQueueFlag.Name)
 	}
+	if ctx.IsSet(TxPoolGlobalBytesFlag.Name) {
+		cfg.GlobalBytes = ctx.Uint64(TxPoolGlobalBytesFlag.Name)
+	}
 	if ctx.IsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.Duration(TxPoolLifetimeFlag.Name)
 	}
+	if ctx.IsSet(TxPoolSimulateFlag.Name) {
+		cfg.Simulate = ctx.Bool(TxPoolSimulateFlag.Name)
+	}
+}
+
+func setBlobPool(ctx *cli.Context, cfg *blobpool.Config) {
+	if ctx.IsSet(BlobPoolDataDirFlag.Name) {
+		cfg.Datadir = ctx.String(BlobPoolDataDirFlag.Name)
+	}
+	if ctx.IsSet(BlobPoolDataCapFlag.Name) {
+		cfg.Datacap = ctx.Uint64(BlobPoolDataCapFlag.Name)
+	}
+	if ctx.IsSet(BlobPoolPriceBumpFlag.Name) {
+		cfg.PriceBump = ctx.Uint64(BlobPoolPriceBumpFlag.Name)
+	}
 }
 
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
@@ -1385,6 +1479,15 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(MinerRecommitIntervalFlag.Name) {
 		cfg.Recommit = ctx.Duration(MinerRecommitIntervalFlag.Name)
 	}
+	if ctx.IsSet(MinerStrategyFlag.Name) {
+		cfg.Strategy = ctx.String(MinerStrategyFlag.Name)
+		if !miner.HasStrategy(cfg.Strategy) {
+			Fatalf("Unknown miner strategy: %s", cfg.Strategy)
+		}
+	}
+	if ctx.IsSet(MinerSkipRevertingFlag.Name) {
+		cfg.SkipReverting = ctx.Bool(MinerSkipRevertingFlag.Name)
+	}
 }
 
 func setRequiredBlocks(ctx *cli.Context, cfg *zondconfig.Config) {
@@ -1461,6 +1564,7 @@ func SetZondConfig(ctx *cli.Context, stack *node.Node, cfg *zondconfig.Config) {
 	setEtherbase(ctx, cfg)
 	setGPO(ctx, &cfg.GPO)
 	setTxPool(ctx, &cfg.TxPool)
+	setBlobPool(ctx, &cfg.BlobPool)
 	setMiner(ctx, &cfg.Miner)
 	setRequiredBlocks(ctx, cfg)
 
@@ -1516,6 +1620,12 @@ func SetZondConfig(ctx *cli.Context, stack *node.Node, cfg *zondconfig.Config) {
 	if ctx.IsSet(StateHistoryFlag.Name) {
 		cfg.StateHistory = ctx.Uint64(StateHistoryFlag.Name)
 	}
+	if ctx.IsSet(StateDiffsFlag.Name) {
+		cfg.StateDiffs = ctx.Bool(StateDiffsFlag.Name)
+	}
+	if ctx.IsSet(TraceIndexFlag.Name) {
+		cfg.TraceIndex = ctx.Bool(TraceIndexFlag.Name)
+	}
 	// Parse state scheme, abort the process if it's not compatible.
 	chaindb := tryMakeReadOnlyDatabase(ctx, stack)
 	scheme, err := ParseStateScheme(ctx, chaindb)
@@ -1525,9 +1635,18 @@ func SetZondConfig(ctx *cli.Context, stack *node.Node, cfg *zondconfig.Config) {
 	}
 	cfg.StateScheme = scheme
 
+	if cfg.NoPruning && cfg.StateScheme == rawdb.PathScheme {
+		log.Info("Enabling state history indexing since archive mode is used", "history", cfg.StateHistory)
+	}
 	if ctx.IsSet(TransactionHistoryFlag.Name) {
 		cfg.TransactionHistory = ctx.Uint64(TransactionHistoryFlag.Name)
 	}
+	if ctx.IsSet(HistoryHorizonFlag.Name) {
+		cfg.HistoryHorizon = ctx.Uint64(HistoryHorizonFlag.Name)
+	}
+	if ctx.IsSet(HistoryEraDirFlag.Name) {
+		cfg.HistoryEraDir = ctx.String(HistoryEraDirFlag.Name)
+	}
 	if ctx.String(GCModeFlag.Name) == "archive" && cfg.TransactionHistory != 0 {
 		cfg.TransactionHistory = 0
 		log.Warn("Disabled transaction unindexing for archive node")
@@ -1560,6 +1679,10 @@ func SetZondConfig(ctx *cli.Context, stack *node.Node, cfg *zondconfig.Config) {
 		// TODO(fjl): force-enable this in --dev mode
 		cfg.EnablePreimageRecording = ctx.Bool(VMEnableDebugFlag.Name)
 	}
+	if ctx.IsSet(VMTraceFlag.Name) {
+		cfg.VMTrace = ctx.String(VMTraceFlag.Name)
+		cfg.VMTraceJsonConfig = ctx.String(VMTraceJsonConfigFlag.Name)
+	}
 
 	if ctx.IsSet(RPCGlobalGasCapFlag.Name) {
 		cfg.RPCGasCap = ctx.Uint64(RPCGlobalGasCapFlag.Name)
@@ -1692,6 +1815,9 @@ func RegisterZondService(stack *node.Node, cfg *zondconfig.Config) (zondapi.Back
 		Fatalf("Failed to register the Zond service: %v", err)
 	}
 	stack.RegisterAPIs(tracers.APIs(backend.APIBackend))
+	if cfg.TraceIndex {
+		stack.RegisterLifecycle(tracers.NewIndexer(backend.APIBackend))
+	}
 	return backend.APIBackend, backend
 }
 
@@ -1917,6 +2043,7 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 		Preimages:           ctx.Bool(CachePreimagesFlag.Name),
 		StateScheme:         scheme,
 		StateHistory:        ctx.Uint64(StateHistoryFlag.Name),
+		StateDiffs:          ctx.Bool(StateDiffsFlag.Name),
 	}
 	if cache.TrieDirtyDisabled && !cache.Preimages {
 		cache.Preimages = true
@@ -1937,6 +2064,13 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 		cache.TrieDirtyLimit = ctx.Int(CacheFlag.Name) * ctx.Int(CacheGCFlag.Name) / 100
 	}
 	vmcfg := vm.Config{EnablePreimageRecording: ctx.Bool(VMEnableDebugFlag.Name)}
+	if ctx.IsSet(VMTraceFlag.Name) {
+		tracer, err := tracers.LiveDirectory.New(ctx.String(VMTraceFlag.Name), json.RawMessage(ctx.String(VMTraceJsonConfigFlag.Name)))
+		if err != nil {
+			Fatalf("Failed to create live tracer: %v", err)
+		}
+		vmcfg.Tracer = tracer
+	}
 
 	// Disable transaction indexing/unindexing by default.
 	chain, err := core.NewBlockChain(chainDb, cache, gspec, engine, vmcfg, nil)
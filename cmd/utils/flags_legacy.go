@@ -19,6 +19,7 @@ package utils
 import (
 	"fmt"
 
+	"github.com/theQRL/go-zond/internal/flags"
 	"github.com/urfave/cli/v2"
 )
 
@@ -30,7 +31,25 @@ var ShowDeprecated = &cli.Command{
 	Description: "Show flags that have been deprecated and will soon be removed",
 }
 
-var DeprecatedFlags = []cli.Flag{}
+var DeprecatedFlags = []cli.Flag{
+	TxPoolJournalFlag,
+	TxPoolRejournalFlag,
+}
+
+var (
+	// Deprecated October 2026, local transactions are stored in the database
+	TxPoolJournalFlag = &cli.StringFlag{
+		Name:     "txpool.journal",
+		Usage:    "Disk journal for local transaction to survive node restarts (deprecated)",
+		Category: flags.DeprecatedCategory,
+	}
+	// Deprecated October 2026, local transactions are stored in the database
+	TxPoolRejournalFlag = &cli.DurationFlag{
+		Name:     "txpool.rejournal",
+		Usage:    "Time interval to regenerate the local transaction journal (deprecated)",
+		Category: flags.DeprecatedCategory,
+	}
+)
 
 // showDeprecated displays deprecated flags that will be soon removed from the codebase.
 func showDeprecated(*cli.Context) error {
@@ -0,0 +1,133 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/internal/era"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/trie"
+	"github.com/theQRL/go-zond/zonddb/memorydb"
+)
+
+func TestHistoryExportImport(t *testing.T) {
+	var (
+		key, _  = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr    = common.Address(key.GetAddress())
+		genesis = &core.Genesis{
+			Config:  params.AllBeaconProtocolChanges,
+			Alloc:   core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(genesis.Config)
+		count  = era.MaxEraBatchSize + 100
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, beacon.NewFaker(), count, func(i int, g *core.BlockGen) {
+		if i%500 != 0 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     g.TxNonce(addr),
+			To:        &common.Address{0x01},
+			Value:     big.NewInt(1),
+			Gas:       params.TxGas,
+			GasFeeCap: g.BaseFee(),
+		}), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		g.AddTx(tx)
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	// Export the history into two era files and import it into a fresh chain
+	dir := t.TempDir()
+	if err := ExportHistory(chain, dir, 0, uint64(count)); err != nil {
+		t.Fatalf("failed to export history: %v", err)
+	}
+	if files, err := era.ReadDir(dir, era.NetworkName(genesis.Config.ChainID)); err != nil || len(files) != 2 {
+		t.Fatalf("era files mismatch: have %v, err %v", files, err)
+	}
+	db, err := rawdb.NewDatabaseWithFreezer(memorydb.New(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	imported, err := core.NewBlockChain(db, nil, genesis, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer imported.Stop()
+
+	if err := ImportHistory(imported, dir); err != nil {
+		t.Fatalf("failed to import history: %v", err)
+	}
+	if head := imported.CurrentSnapBlock().Number.Uint64(); head != uint64(count) {
+		t.Fatalf("snap head mismatch: have %d, want %d", head, count)
+	}
+	for _, want := range blocks {
+		block := imported.GetBlockByNumber(want.NumberU64())
+		if block == nil || block.Hash() != want.Hash() {
+			t.Fatalf("block %d mismatch", want.NumberU64())
+		}
+		if have, want := len(imported.GetReceiptsByHash(block.Hash())), len(chain.GetReceiptsByHash(block.Hash())); have != want {
+			t.Fatalf("block %d receipt count mismatch: have %d, want %d", block.NumberU64(), have, want)
+		}
+	}
+	// Expire the first epoch and re-import the history to restore it
+	if _, err := db.TruncateTail(era.MaxEraBatchSize); err != nil {
+		t.Fatalf("failed to expire history: %v", err)
+	}
+	expired := blocks[0]
+	if body := rawdb.ReadBody(db, expired.Hash(), expired.NumberU64()); body != nil {
+		t.Fatalf("block %d body not expired", expired.NumberU64())
+	}
+	if err := ImportHistory(imported, dir); err != nil {
+		t.Fatalf("failed to re-import history: %v", err)
+	}
+	for _, want := range blocks {
+		number, hash := want.NumberU64(), want.Hash()
+		body := rawdb.ReadBody(db, hash, number)
+		if body == nil {
+			t.Fatalf("block %d body missing", number)
+		}
+		if types.DeriveSha(types.Transactions(body.Transactions), trie.NewStackTrie(nil)) != want.TxHash() {
+			t.Fatalf("block %d body mismatch", number)
+		}
+		have := rawdb.ReadReceipts(db, hash, number, want.Time(), genesis.Config)
+		if types.DeriveSha(have, trie.NewStackTrie(nil)) != want.ReceiptHash() {
+			t.Fatalf("block %d receipts mismatch", number)
+		}
+	}
+}
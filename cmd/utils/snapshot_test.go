@@ -0,0 +1,135 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/zonddb/memorydb"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	testSnapshotExportImport(t, rawdb.HashScheme)
+	testSnapshotExportImport(t, rawdb.PathScheme)
+}
+
+func testSnapshotExportImport(t *testing.T, scheme string) {
+	var (
+		key, _   = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr     = common.Address(key.GetAddress())
+		contract = common.Address{0xcc}
+		genesis  = &core.Genesis{
+			Config: params.AllBeaconProtocolChanges,
+			Alloc: core.GenesisAlloc{
+				addr:     {Balance: big.NewInt(params.Ether)},
+				contract: {Balance: common.Big1, Code: []byte{0x60, 0x01, 0x60, 0x00, 0x55}, Storage: map[common.Hash]common.Hash{{0x01}: {0x02}}},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(genesis.Config)
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, beacon.NewFaker(), 20, func(i int, g *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     g.TxNonce(addr),
+			To:        &contract,
+			Value:     big.NewInt(1),
+			Gas:       100000,
+			GasFeeCap: g.BaseFee(),
+		}), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		g.AddTx(tx)
+	})
+	srcdb := rawdb.NewMemoryDatabase()
+	chain, err := core.NewBlockChain(srcdb, core.DefaultCacheConfigWithScheme(scheme), genesis, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks[:10]); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	head := chain.CurrentBlock()
+	file := filepath.Join(t.TempDir(), "snapshot")
+	if err := ExportSnapshot(srcdb, chain.Snapshots(), head, file); err != nil {
+		t.Fatalf("failed to export snapshot: %v", err)
+	}
+	// Import the snapshot into a node only initialized with the genesis
+	db, err := rawdb.NewDatabaseWithFreezer(memorydb.New(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	fresh, err := core.NewBlockChain(db, core.DefaultCacheConfigWithScheme(scheme), genesis, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	fresh.Stop()
+
+	if _, err := ImportSnapshot(db, file); err != nil {
+		t.Fatalf("failed to import snapshot: %v", err)
+	}
+	imported, err := core.NewBlockChain(db, core.DefaultCacheConfigWithScheme(scheme), genesis, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer imported.Stop()
+
+	if have := imported.CurrentBlock().Hash(); have != head.Hash() {
+		t.Fatalf("head mismatch: have %x, want %x", have, head.Hash())
+	}
+	if cutoff := imported.HistoryPruningCutoff(); cutoff != head.Number.Uint64() {
+		t.Fatalf("history cutoff mismatch: have %d, want %d", cutoff, head.Number)
+	}
+	if header := imported.GetHeaderByNumber(1); header == nil || header.Hash() != blocks[0].Hash() {
+		t.Fatalf("header 1 mismatch")
+	}
+	statedb, err := imported.State()
+	if err != nil {
+		t.Fatalf("failed to open state: %v", err)
+	}
+	want, _ := chain.State()
+	for _, account := range []common.Address{addr, contract} {
+		if statedb.GetBalance(account).Cmp(want.GetBalance(account)) != 0 {
+			t.Fatalf("balance mismatch of %x", account)
+		}
+	}
+	if have := statedb.GetState(contract, common.Hash{0x01}); have != (common.Hash{0x02}) {
+		t.Fatalf("storage mismatch: have %x", have)
+	}
+	// The node continues from the imported state
+	if _, err := imported.InsertChain(blocks[10:]); err != nil {
+		t.Fatalf("failed to continue the chain: %v", err)
+	}
+	// Importing into a database beyond the genesis is rejected
+	if _, err := ImportSnapshot(db, file); err == nil {
+		t.Fatalf("snapshot imported into a non-empty database")
+	}
+}
@@ -58,18 +58,19 @@ type ExecutionResult struct {
 
 //go:generate go run github.com/fjl/gencodec -type stEnv -field-override stEnvMarshaling -out gen_stenv.go
 type stEnv struct {
-	Coinbase        common.Address                      `json:"currentCoinbase"   gencodec:"required"`
-	Random          *big.Int                            `json:"currentRandom"`
-	ParentBaseFee   *big.Int                            `json:"parentBaseFee,omitempty"`
-	ParentGasUsed   uint64                              `json:"parentGasUsed,omitempty"`
-	ParentGasLimit  uint64                              `json:"parentGasLimit,omitempty"`
-	GasLimit        uint64                              `json:"currentGasLimit"   gencodec:"required"`
-	Number          uint64                              `json:"currentNumber"     gencodec:"required"`
-	Timestamp       uint64                              `json:"currentTimestamp"  gencodec:"required"`
-	ParentTimestamp uint64                              `json:"parentTimestamp,omitempty"`
-	BlockHashes     map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
-	Withdrawals     []*types.Withdrawal                 `json:"withdrawals,omitempty"`
-	BaseFee         *big.Int                            `json:"currentBaseFee,omitempty"`
+	Coinbase              common.Address                      `json:"currentCoinbase"   gencodec:"required"`
+	Random                *big.Int                            `json:"currentRandom"`
+	ParentBaseFee         *big.Int                            `json:"parentBaseFee,omitempty"`
+	ParentGasUsed         uint64                              `json:"parentGasUsed,omitempty"`
+	ParentGasLimit        uint64                              `json:"parentGasLimit,omitempty"`
+	GasLimit              uint64                              `json:"currentGasLimit"   gencodec:"required"`
+	Number                uint64                              `json:"currentNumber"     gencodec:"required"`
+	Timestamp             uint64                              `json:"currentTimestamp"  gencodec:"required"`
+	ParentTimestamp       uint64                              `json:"parentTimestamp,omitempty"`
+	BlockHashes           map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
+	Withdrawals           []*types.Withdrawal                 `json:"withdrawals,omitempty"`
+	BaseFee               *big.Int                            `json:"currentBaseFee,omitempty"`
+	ParentBeaconBlockRoot *common.Hash                        `json:"parentBeaconBlockRoot"`
 }
 
 type stEnvMarshaling struct {
@@ -110,7 +111,7 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 	}
 	var (
 		statedb     = MakePreState(rawdb.NewMemoryDatabase(), pre.Pre)
-		signer      = types.MakeSigner(chainConfig)
+		signer      = types.MakeSigner(chainConfig, new(big.Int).SetUint64(pre.Env.Number), pre.Env.Timestamp)
 		gaspool     = new(core.GasPool)
 		blockHash   = common.Hash{0x13, 0x37}
 		rejectedTxs []*rejectedTx
@@ -138,6 +139,26 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 		rnd := common.BigToHash(pre.Env.Random)
 		vmContext.Random = &rnd
 	}
+	// Update the system contracts before applying any transactions
+	if pre.Env.Number > 0 {
+		var (
+			parent = &types.Header{Number: new(big.Int).SetUint64(pre.Env.Number - 1), Time: pre.Env.ParentTimestamp}
+			header = &types.Header{Number: vmContext.BlockNumber, Time: pre.Env.Timestamp}
+		)
+		core.InstallSystemContracts(chainConfig, parent, header, statedb)
+	}
+	if beaconRoot := pre.Env.ParentBeaconBlockRoot; beaconRoot != nil {
+		zvm := vm.NewZVM(vmContext, vm.TxContext{}, statedb, chainConfig, vmConfig)
+		core.ProcessBeaconBlockRoot(*beaconRoot, zvm, statedb)
+	}
+	if pre.Env.Number > 0 && chainConfig.IsPrague(new(big.Int).SetUint64(pre.Env.Number), pre.Env.Timestamp) {
+		var (
+			prevNumber = pre.Env.Number - 1
+			prevHash   = pre.Env.BlockHashes[math.HexOrDecimal64(prevNumber)]
+			zvm        = vm.NewZVM(vmContext, vm.TxContext{}, statedb, chainConfig, vmConfig)
+		)
+		core.ProcessParentBlockHash(prevHash, zvm, statedb)
+	}
 
 	for i, tx := range txs {
 		msg, err := core.TransactionToMessage(tx, signer, pre.Env.BaseFee)
@@ -29,6 +29,7 @@ func (s stEnv) MarshalJSON() ([]byte, error) {
 		BlockHashes           map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
 		Withdrawals           []*types.Withdrawal                 `json:"withdrawals,omitempty"`
 		BaseFee               *math.HexOrDecimal256               `json:"currentBaseFee,omitempty"`
+		ParentBeaconBlockRoot *common.Hash                        `json:"parentBeaconBlockRoot"`
 	}
 	var enc stEnv
 	enc.Coinbase = s.Coinbase
@@ -43,6 +44,7 @@ func (s stEnv) MarshalJSON() ([]byte, error) {
 	enc.BlockHashes = s.BlockHashes
 	enc.Withdrawals = s.Withdrawals
 	enc.BaseFee = (*math.HexOrDecimal256)(s.BaseFee)
+	enc.ParentBeaconBlockRoot = s.ParentBeaconBlockRoot
 	return json.Marshal(&enc)
 }
 
@@ -61,6 +63,7 @@ func (s *stEnv) UnmarshalJSON(input []byte) error {
 		BlockHashes           map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
 		Withdrawals           []*types.Withdrawal                 `json:"withdrawals,omitempty"`
 		BaseFee               *math.HexOrDecimal256               `json:"currentBaseFee,omitempty"`
+		ParentBeaconBlockRoot *common.Hash                        `json:"parentBeaconBlockRoot"`
 	}
 	var dec stEnv
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -106,5 +109,8 @@ func (s *stEnv) UnmarshalJSON(input []byte) error {
 	if dec.BaseFee != nil {
 		s.BaseFee = (*big.Int)(dec.BaseFee)
 	}
+	if dec.ParentBeaconBlockRoot != nil {
+		s.ParentBeaconBlockRoot = dec.ParentBeaconBlockRoot
+	}
 	return nil
 }
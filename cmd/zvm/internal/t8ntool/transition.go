@@ -308,7 +308,7 @@ func loadTransactions(txStr string, inputData *input, chainConfig *params.ChainC
 		txsWithKeys = inputData.Txs
 	}
 	// We may have to sign the transactions.
-	signer := types.MakeSigner(chainConfig)
+	signer := types.MakeSigner(chainConfig, big.NewInt(int64(inputData.Env.Number)), inputData.Env.Timestamp)
 	return signUnsignedTransactions(txsWithKeys, signer)
 }
 
@@ -40,6 +40,15 @@ var (
 		Name:  "cpuprofile",
 		Usage: "creates a CPU profile at the given path",
 	}
+	ProfileFlag = &cli.StringFlag{
+		Name:  "profile",
+		Usage: "creates a gas profile of the execution at the given path",
+	}
+	ProfileFormatFlag = &cli.StringFlag{
+		Name:  "profile.format",
+		Usage: "format of the gas profile (pprof or flamegraph)",
+		Value: "pprof",
+	}
 	StatDumpFlag = &cli.BoolFlag{
 		Name:  "statdump",
 		Usage: "displays stack and heap memory information",
@@ -196,6 +205,8 @@ func init() {
 		InputFileFlag,
 		MemProfileFlag,
 		CPUProfileFlag,
+		ProfileFlag,
+		ProfileFormatFlag,
 		StatDumpFlag,
 		GenesisFlag,
 		MachineFlag,
@@ -0,0 +1,193 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/google/pprof/profile"
+	"github.com/theQRL/go-zond/common"
+)
+
+const (
+	profileFormatPprof      = "pprof"
+	profileFormatFlamegraph = "flamegraph"
+)
+
+// gasProfileNode is the aggregated call tree produced by the gas profile tracer.
+type gasProfileNode struct {
+	Address  common.Address `json:"address"`
+	Function string         `json:"function"`
+	Calls    uint64         `json:"calls"`
+	Gas      uint64         `json:"gas"`
+	SelfGas  uint64         `json:"selfGas"`
+	Opcodes  map[string]struct {
+		Gas   uint64 `json:"gas"`
+		Count uint64 `json:"count"`
+	} `json:"opcodes"`
+	PCs map[uint64]struct {
+		Op    string `json:"op"`
+		Gas   uint64 `json:"gas"`
+		Count uint64 `json:"count"`
+	} `json:"pcs"`
+	Children []*gasProfileNode `json:"children"`
+}
+
+// frame returns the name of the node in the exported profiles.
+func (n *gasProfileNode) frame() string {
+	return fmt.Sprintf("%v:%s", n.Address, n.Function)
+}
+
+// unattributed returns the self gas of the node not spent on any opcode, such
+// as the execution of the precompiles.
+func (n *gasProfileNode) unattributed() uint64 {
+	gas := n.SelfGas
+	for _, opcode := range n.Opcodes {
+		gas -= min(gas, opcode.Gas)
+	}
+	return gas
+}
+
+// writeGasProfile converts the result of the gas profile tracer into the given
+// format and writes it to the file at path.
+func writeGasProfile(path string, format string, result json.RawMessage) error {
+	var root gasProfileNode
+	if err := json.Unmarshal(result, &root); err != nil {
+		return fmt.Errorf("could not decode gas profile: %v", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create gas profile: %v", err)
+	}
+	defer f.Close()
+
+	switch format {
+	case profileFormatPprof:
+		err = toPprof(&root).Write(f)
+	case profileFormatFlamegraph:
+		err = writeFolded(f, &root)
+	default:
+		err = fmt.Errorf("unknown gas profile format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("could not write gas profile: %v", err)
+	}
+	return nil
+}
+
+// toPprof converts the call tree into a pprof profile. Every sample is an
+// executed program counter, with the opcode as the leaf frame and the calls
+// leading to it as the parent frames. The line of the innermost call frame
+// is the program counter of the opcode.
+func toPprof(root *gasProfileNode) *profile.Profile {
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "gas", Unit: "count"},
+			{Type: "steps", Unit: "count"},
+		},
+		PeriodType: &profile.ValueType{Type: "gas", Unit: "count"},
+		Period:     1,
+	}
+	var (
+		functions = make(map[string]*profile.Function)
+		locations = make(map[string]*profile.Location)
+	)
+	location := func(name string, line int64) *profile.Location {
+		key := fmt.Sprintf("%s#%d", name, line)
+		if loc, ok := locations[key]; ok {
+			return loc
+		}
+		fn, ok := functions[name]
+		if !ok {
+			fn = &profile.Function{ID: uint64(len(p.Function) + 1), Name: name, SystemName: name}
+			functions[name] = fn
+			p.Function = append(p.Function, fn)
+		}
+		loc := &profile.Location{ID: uint64(len(p.Location) + 1), Line: []profile.Line{{Function: fn, Line: line}}}
+		locations[key] = loc
+		p.Location = append(p.Location, loc)
+		return loc
+	}
+	var walk func(node *gasProfileNode, parents []*profile.Location)
+	walk = func(node *gasProfileNode, parents []*profile.Location) {
+		pcs := make([]uint64, 0, len(node.PCs))
+		for pc := range node.PCs {
+			pcs = append(pcs, pc)
+		}
+		sort.Slice(pcs, func(i, j int) bool { return pcs[i] < pcs[j] })
+
+		for _, pc := range pcs {
+			step := node.PCs[pc]
+			stack := []*profile.Location{location(step.Op, 0), location(node.frame(), int64(pc))}
+			p.Sample = append(p.Sample, &profile.Sample{
+				Location: append(stack, parents...),
+				Value:    []int64{int64(step.Gas), int64(step.Count)},
+			})
+		}
+		if gas := node.unattributed(); gas > 0 {
+			p.Sample = append(p.Sample, &profile.Sample{
+				Location: append([]*profile.Location{location(node.frame(), 0)}, parents...),
+				Value:    []int64{int64(gas), 0},
+			})
+		}
+		// The callers of the children are attributed to the start of the frame,
+		// the program counter of the call site is not tracked.
+		parents = append([]*profile.Location{location(node.frame(), 0)}, parents...)
+		for _, child := range node.Children {
+			walk(child, parents)
+		}
+	}
+	walk(root, nil)
+	return p
+}
+
+// writeFolded writes the call tree in the folded stack format consumed by the
+// flamegraph tools, with one line per opcode executed in each call frame.
+// The gas not spent on opcodes is charged to the frame itself.
+func writeFolded(w io.Writer, root *gasProfileNode) error {
+	out := bufio.NewWriter(w)
+
+	var walk func(node *gasProfileNode, stack []string)
+	walk = func(node *gasProfileNode, stack []string) {
+		stack = append(stack, node.frame())
+
+		ops := make([]string, 0, len(node.Opcodes))
+		for op := range node.Opcodes {
+			ops = append(ops, op)
+		}
+		sort.Strings(ops)
+		for _, op := range ops {
+			if gas := node.Opcodes[op].Gas; gas > 0 {
+				fmt.Fprintf(out, "%s;%s %d\n", strings.Join(stack, ";"), op, gas)
+			}
+		}
+		if gas := node.unattributed(); gas > 0 {
+			fmt.Fprintf(out, "%s %d\n", strings.Join(stack, ";"), gas)
+		}
+		for _, child := range node.Children {
+			walk(child, stack)
+		}
+	}
+	walk(root, nil)
+	return out.Flush()
+}
@@ -42,7 +42,9 @@ import (
 	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/trie"
 	"github.com/theQRL/go-zond/trie/triedb/hashdb"
+	"github.com/theQRL/go-zond/zond/tracers"
 	"github.com/theQRL/go-zond/zond/tracers/logger"
+	_ "github.com/theQRL/go-zond/zond/tracers/native"
 	"github.com/urfave/cli/v2"
 )
 
@@ -123,6 +125,7 @@ func runCmd(ctx *cli.Context) error {
 	var (
 		err           error
 		tracer        vm.ZVMLogger
+		profiler      tracers.Tracer
 		debugLogger   *logger.StructLogger
 		statedb       *state.StateDB
 		chainConfig   *params.ChainConfig
@@ -131,12 +134,24 @@ func runCmd(ctx *cli.Context) error {
 		genesisConfig *core.Genesis
 		preimages     = ctx.Bool(DumpFlag.Name)
 	)
+	if ctx.String(ProfileFlag.Name) != "" {
+		if ctx.Bool(MachineFlag.Name) || ctx.Bool(DebugFlag.Name) {
+			return fmt.Errorf("--%s cannot be combined with --%s or --%s", ProfileFlag.Name, MachineFlag.Name, DebugFlag.Name)
+		}
+		if format := ctx.String(ProfileFormatFlag.Name); format != profileFormatPprof && format != profileFormatFlamegraph {
+			return fmt.Errorf("unknown gas profile format %q", format)
+		}
+		if profiler, err = tracers.DefaultDirectory.New("gasProfileTracer", new(tracers.Context), nil); err != nil {
+			return err
+		}
+		tracer = profiler
+	}
 	if ctx.Bool(MachineFlag.Name) {
 		tracer = logger.NewJSONLogger(logconfig, os.Stdout)
 	} else if ctx.Bool(DebugFlag.Name) {
 		debugLogger = logger.NewStructLogger(logconfig)
 		tracer = debugLogger
-	} else {
+	} else if profiler == nil {
 		debugLogger = logger.NewStructLogger(logconfig)
 	}
 	if ctx.String(GenesisFlag.Name) != "" {
@@ -313,6 +328,16 @@ func runCmd(ctx *cli.Context) error {
 		f.Close()
 	}
 
+	if profiler != nil {
+		result, err := profiler.GetResult()
+		if err != nil {
+			return err
+		}
+		if err := writeGasProfile(ctx.String(ProfileFlag.Name), ctx.String(ProfileFormatFlag.Name), result); err != nil {
+			return err
+		}
+	}
+
 	if ctx.Bool(DebugFlag.Name) {
 		if debugLogger != nil {
 			fmt.Fprintln(os.Stderr, "#### TRACE ####")
@@ -329,7 +354,7 @@ allocations:     %d
 allocated bytes: %d
 `, initialGas-leftOverGas, stats.time, stats.allocs, stats.bytesAllocated)
 	}
-	if tracer == nil {
+	if tracer == nil || profiler != nil {
 		fmt.Printf("%#x\n", output)
 		if err != nil {
 			fmt.Printf(" error: %v\n", err)
@@ -54,6 +54,15 @@ func TestCompiler(t *testing.T) {
 `,
 			output: "6300000006565b",
 		},
+		{
+			input: `
+	PUSH 0x20
+	PUSH 0x00
+	PUSH 0x40
+	MCOPY
+`,
+			output: "6020600060405e",
+		},
 	}
 	for _, test := range tests {
 		ch := Lex([]byte(test.input), false)
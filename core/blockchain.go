@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"runtime"
 	"strings"
 	"sync"
@@ -32,6 +33,7 @@ import (
 	"github.com/theQRL/go-zond/common/mclock"
 	"github.com/theQRL/go-zond/common/prque"
 	"github.com/theQRL/go-zond/consensus"
+	"github.com/theQRL/go-zond/consensus/misc/eip1559"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
 	"github.com/theQRL/go-zond/core/state/snapshot"
@@ -131,11 +133,12 @@ type CacheConfig struct {
 	TrieCleanLimit      int           // Memory allowance (MB) to use for caching trie nodes in memory
 	TrieCleanNoPrefetch bool          // Whether to disable heuristic state prefetching for followup blocks
 	TrieDirtyLimit      int           // Memory limit (MB) at which to start flushing dirty trie nodes to disk
-	TrieDirtyDisabled   bool          // Whether to disable trie write caching and GC altogether (archive node)
+	TrieDirtyDisabled   bool          // Whether to disable trie write caching and GC altogether (archive node), or index state histories in path scheme
 	TrieTimeLimit       time.Duration // Time limit after which to flush the current in-memory trie to disk
 	SnapshotLimit       int           // Memory allowance (MB) to use for caching snapshot entries in memory
 	Preimages           bool          // Whether to store preimage of trie key to the disk
 	StateHistory        uint64        // Number of blocks from head whose state histories are reserved.
+	StateDiffs          bool          // Whether to persist the state diff of each block, reserved for StateHistory blocks
 	StateScheme         string        // Scheme used to store zond states and merkle tree nodes on top
 
 	SnapshotNoBuild bool // Whether the background generation is allowed
@@ -152,9 +155,10 @@ func (c *CacheConfig) triedbConfig() *trie.Config {
 	}
 	if c.StateScheme == rawdb.PathScheme {
 		config.PathDB = &pathdb.Config{
-			StateHistory:   c.StateHistory,
-			CleanCacheSize: c.TrieCleanLimit * 1024 * 1024,
-			DirtyCacheSize: c.TrieDirtyLimit * 1024 * 1024,
+			StateHistory:        c.StateHistory,
+			EnableStateIndexing: c.TrieDirtyDisabled,
+			CleanCacheSize:      c.TrieCleanLimit * 1024 * 1024,
+			DirtyCacheSize:      c.TrieDirtyLimit * 1024 * 1024,
 		}
 	}
 	return config
@@ -205,6 +209,7 @@ type BlockChain struct {
 	flushInterval atomic.Int64                     // Time interval (processing time) after which to flush a state
 	triedb        *trie.Database                   // The database handler for maintaining trie nodes.
 	stateCache    state.Database                   // State database to reuse between imports (contains state cache)
+	historyCache  state.Database                   // State database for serving historical states (path scheme only)
 
 	// txLookupLimit is the maximum number of blocks from head whose tx indices
 	// are reserved:
@@ -220,6 +225,7 @@ type BlockChain struct {
 	chainHeadFeed event.Feed
 	logsFeed      event.Feed
 	blockProcFeed event.Feed
+	stateDiffFeed event.Feed
 	scope         event.SubscriptionScope
 	genesisBlock  *types.Block
 
@@ -247,6 +253,7 @@ type BlockChain struct {
 	prefetcher Prefetcher
 	processor  Processor // Block transaction processor interface
 	vmConfig   vm.Config
+	logger     BlockchainLogger // Tracer of the block processing, nil if not live tracing
 }
 
 // NewBlockChain returns a fully initialised block chain using information
@@ -290,7 +297,13 @@ func NewBlockChain(db zonddb.Database, cacheConfig *CacheConfig, genesis *Genesi
 		vmConfig:      vmConfig,
 	}
 	bc.flushInterval.Store(int64(cacheConfig.TrieTimeLimit))
+	if logger, ok := vmConfig.Tracer.(BlockchainLogger); ok {
+		bc.logger = logger
+	}
 	bc.stateCache = state.NewDatabaseWithNodeDB(bc.db, bc.triedb)
+	if bc.triedb.Scheme() == rawdb.PathScheme {
+		bc.historyCache = state.NewHistoricDatabase(bc.db, bc.triedb)
+	}
 	bc.validator = NewBlockValidator(chainConfig, bc, engine)
 	bc.prefetcher = newStatePrefetcher(chainConfig, bc, engine)
 	bc.processor = NewStateProcessor(chainConfig, bc, engine)
@@ -1291,6 +1304,36 @@ func (bc *BlockChain) InsertReceiptChain(blockChain types.Blocks, receiptChain [
 	return 0, nil
 }
 
+// RestoreHistory writes back the bodies and receipts of already known canonical
+// blocks, which were expired from the ancient store, into the key-value store.
+// The blocks whose history is still present are skipped. It returns the number
+// of blocks restored.
+func (bc *BlockChain) RestoreHistory(blockChain types.Blocks, receiptChain []types.Receipts) (int, error) {
+	if len(blockChain) != len(receiptChain) {
+		return 0, fmt.Errorf("block and receipt count mismatch: %d != %d", len(blockChain), len(receiptChain))
+	}
+	var (
+		batch    = bc.db.NewBatch()
+		restored int
+	)
+	for i, block := range blockChain {
+		number, hash := block.NumberU64(), block.Hash()
+		if canon := rawdb.ReadCanonicalHash(bc.db, number); canon != hash {
+			return 0, fmt.Errorf("non canonical block %d: have %x, want %x", number, hash, canon)
+		}
+		if len(rawdb.ReadBodyRLP(bc.db, hash, number)) > 0 && len(rawdb.ReadReceiptsRLP(bc.db, hash, number)) > 0 {
+			continue
+		}
+		rawdb.WriteBody(batch, hash, number, block.Body())
+		rawdb.WriteReceipts(batch, hash, number, receiptChain[i])
+		restored++
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	return restored, nil
+}
+
 // writeKnownBlock updates the head block flag with a known block
 // and introduces chain reorg if necessary.
 func (bc *BlockChain) writeKnownBlock(block *types.Block) error {
@@ -1319,10 +1362,16 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 		log.Crit("Failed to write block into disk", "err", err)
 	}
 	// Commit all cached state changes into underlying memory database.
+	if bc.cacheConfig.StateDiffs {
+		state.TrackStateDiff()
+	}
 	root, err := state.Commit(block.NumberU64(), true)
 	if err != nil {
 		return err
 	}
+	if bc.cacheConfig.StateDiffs {
+		bc.writeStateDiff(block, state.StateDiff())
+	}
 	// If node is running in path mode, skip explicit gc operation
 	// which is unnecessary in this mode.
 	if bc.triedb.Scheme() == rawdb.PathScheme {
@@ -1383,6 +1432,16 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	return nil
 }
 
+// writeStateDiff stores the state diff of the given block and removes the ones
+// falling out of the state history retention.
+func (bc *BlockChain) writeStateDiff(block *types.Block, diff *types.StateDiff) {
+	rawdb.WriteStateDiff(bc.db, block.Hash(), block.NumberU64(), diff)
+
+	if limit := bc.cacheConfig.StateHistory; limit != 0 && block.NumberU64() > limit {
+		rawdb.DeleteStateDiffs(bc.db, block.NumberU64()-limit)
+	}
+}
+
 // WriteBlockAndSetHead writes the given block and all associated state to the database,
 // and applies the block as the new chain head.
 func (bc *BlockChain) WriteBlockAndSetHead(block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB, emitHeadEvent bool) (status WriteStatus, err error) {
@@ -1416,6 +1475,9 @@ func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types
 	if len(logs) > 0 {
 		bc.logsFeed.Send(logs)
 	}
+	if bc.cacheConfig.StateDiffs {
+		bc.stateDiffFeed.Send(StateDiffEvent{Header: block.Header(), Diff: state.StateDiff()})
+	}
 	// In theory, we should fire a ChainHeadEvent when we inject
 	// a canonical block, but sometimes we can insert a batch of
 	// canonical blocks. Avoid firing too many ChainHeadEvents,
@@ -1477,8 +1539,8 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 		return 0, nil
 	}
 
-	// Start a parallel signature recovery (signer will fluke on fork transition, minimal perf loss)
-	SenderCacher.RecoverFromBlocks(types.MakeSigner(bc.chainConfig), chain)
+	// Start a parallel signature verification, which also caches the senders
+	verifications := SenderVerifier.VerifyFromBlocks(bc.chainConfig, chain)
 
 	var (
 		stats     = insertStats{startTime: mclock.Now()}
@@ -1614,8 +1676,13 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 			continue
 		}
 
-		// Retrieve the parent block and it's state to execute on top
+		// Ensure all transactions in the block are properly signed
 		start := time.Now()
+		if err := verifications[it.index].Wait(); err != nil {
+			bc.reportBlock(block, nil, err)
+			return it.index, err
+		}
+		// Retrieve the parent block and it's state to execute on top
 		parent := it.previous()
 		if parent == nil {
 			parent = bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
@@ -1636,8 +1703,12 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 			if followup, err := it.peek(); followup != nil && err == nil {
 				throwaway, _ := state.New(parent.Root, bc.stateCache, bc.snaps)
 
+				// The prefetching executions are not traced
+				config := bc.vmConfig
+				config.Tracer = nil
+
 				go func(start time.Time, followup *types.Block, throwaway *state.StateDB) {
-					bc.prefetcher.Prefetch(followup, throwaway, bc.vmConfig, &followupInterrupt)
+					bc.prefetcher.Prefetch(followup, throwaway, config, &followupInterrupt)
 
 					blockPrefetchExecuteTimer.Update(time.Since(start))
 					if followupInterrupt.Load() {
@@ -1648,9 +1719,16 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 		}
 
 		// Process block using the parent state as reference point
+		if bc.logger != nil {
+			statedb.SetLogger(bc.logger)
+			bc.logger.OnBlockStart(block)
+		}
 		pstart := time.Now()
 		receipts, logs, usedGas, err := bc.processor.Process(block, statedb, bc.vmConfig)
 		if err != nil {
+			if bc.logger != nil {
+				bc.logger.OnBlockEnd(err)
+			}
 			bc.reportBlock(block, receipts, err)
 			followupInterrupt.Store(true)
 			return it.index, err
@@ -1658,7 +1736,11 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 		ptime := time.Since(pstart)
 
 		vstart := time.Now()
-		if err := bc.validator.ValidateState(block, statedb, receipts, usedGas); err != nil {
+		err = bc.validator.ValidateState(block, statedb, receipts, usedGas)
+		if bc.logger != nil {
+			bc.logger.OnBlockEnd(err)
+		}
+		if err != nil {
 			bc.reportBlock(block, receipts, err)
 			followupInterrupt.Store(true)
 			return it.index, err
@@ -1805,8 +1887,13 @@ func (bc *BlockChain) recoverAncestors(block *types.Block) (common.Hash, error)
 // collectLogs collects the logs that were generated or removed during
 // the processing of a block. These logs are later announced as deleted or reborn.
 func (bc *BlockChain) collectLogs(b *types.Block, removed bool) []*types.Log {
+	var blobGasPrice *big.Int
+	excessBlobGas := b.ExcessBlobGas()
+	if excessBlobGas != nil {
+		blobGasPrice = eip1559.CalcBlobFee(*excessBlobGas)
+	}
 	receipts := rawdb.ReadRawReceipts(bc.db, b.Hash(), b.NumberU64())
-	if err := receipts.DeriveFields(bc.chainConfig, b.Hash(), b.NumberU64(), b.Time(), b.BaseFee(), b.Transactions()); err != nil {
+	if err := receipts.DeriveFields(bc.chainConfig, b.Hash(), b.NumberU64(), b.Time(), b.BaseFee(), blobGasPrice, b.Transactions()); err != nil {
 		log.Error("Failed to derive block receipts fields", "hash", b.Hash(), "number", b.NumberU64(), "err", err)
 	}
 	var logs []*types.Log
@@ -2100,6 +2187,9 @@ func (bc *BlockChain) indexBlocks(tail *uint64, head uint64, done chan struct{})
 		return
 	}
 
+	// The bodies below the history cutoff are pruned, they can't be indexed.
+	cutoff := bc.HistoryPruningCutoff()
+
 	// The tail flag is not existent, it means the node is just initialized
 	// and all blocks(may from ancient store) are not indexed yet.
 	if tail == nil {
@@ -2107,12 +2197,12 @@ func (bc *BlockChain) indexBlocks(tail *uint64, head uint64, done chan struct{})
 		if bc.txLookupLimit != 0 && head >= bc.txLookupLimit {
 			from = head - bc.txLookupLimit + 1
 		}
-		rawdb.IndexTransactions(bc.db, from, head+1, bc.quit)
+		rawdb.IndexTransactions(bc.db, max(from, cutoff), head+1, bc.quit)
 		return
 	}
 	// The tail flag is existent, but the whole chain is required to be indexed.
 	if bc.txLookupLimit == 0 || head < bc.txLookupLimit {
-		if *tail > 0 {
+		if *tail > cutoff {
 			// It can happen when chain is rewound to a historical point which
 			// is even lower than the indexes tail, recap the indexing target
 			// to new head to avoid reading non-existent block bodies.
@@ -2120,14 +2210,14 @@ func (bc *BlockChain) indexBlocks(tail *uint64, head uint64, done chan struct{})
 			if end > head+1 {
 				end = head + 1
 			}
-			rawdb.IndexTransactions(bc.db, 0, end, bc.quit)
+			rawdb.IndexTransactions(bc.db, cutoff, end, bc.quit)
 		}
 		return
 	}
 	// Update the transaction index to the new chain state
-	if head-bc.txLookupLimit+1 < *tail {
+	if from := max(head-bc.txLookupLimit+1, cutoff); from < *tail {
 		// Reindex a part of missing indices and rewind index tail to HEAD-limit
-		rawdb.IndexTransactions(bc.db, head-bc.txLookupLimit+1, *tail, bc.quit)
+		rawdb.IndexTransactions(bc.db, from, *tail, bc.quit)
 	} else {
 		// Unindex a part of stale indices and forward index tail to HEAD-limit
 		rawdb.UnindexTransactions(bc.db, *tail, head-bc.txLookupLimit+1, bc.quit)
@@ -0,0 +1,48 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+)
+
+// BlockchainLogger is used to collect traces of the whole block processing,
+// as opposed to the traces of a single transaction re-executed on demand. If
+// the tracer of the virtual machine configuration of the chain implements it,
+// it's notified of every block imported, along with the transactions and the
+// state changes within.
+type BlockchainLogger interface {
+	vm.ZVMLogger
+	state.StateLogger
+
+	// OnBlockStart is called before the block is executed.
+	OnBlockStart(block *types.Block)
+
+	// OnBlockEnd is called after the block is executed and validated, with the
+	// error if either failed.
+	OnBlockEnd(err error)
+
+	// OnTxStart is called before the transaction is executed.
+	OnTxStart(tx *types.Transaction, from common.Address)
+
+	// OnTxEnd is called after the transaction is executed, with the error if
+	// it couldn't be applied.
+	OnTxEnd(receipt *types.Receipt, err error)
+}
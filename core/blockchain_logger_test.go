@@ -0,0 +1,138 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
+)
+
+// recordingLogger is a blockchain logger recording the block, transaction and
+// call frame events along with the balance changes.
+type recordingLogger struct {
+	events   []string
+	balances map[common.Address]*big.Int
+}
+
+func (l *recordingLogger) OnBlockStart(block *types.Block) { l.events = append(l.events, "blockStart") }
+func (l *recordingLogger) OnBlockEnd(err error)            { l.events = append(l.events, "blockEnd") }
+func (l *recordingLogger) OnTxStart(tx *types.Transaction, from common.Address) {
+	l.events = append(l.events, "txStart")
+}
+func (l *recordingLogger) OnTxEnd(receipt *types.Receipt, err error) {
+	l.events = append(l.events, "txEnd")
+}
+func (l *recordingLogger) CaptureTxStart(gasLimit uint64) {}
+func (l *recordingLogger) CaptureTxEnd(restGas uint64)    {}
+func (l *recordingLogger) CaptureStart(env *vm.ZVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	l.events = append(l.events, "start")
+}
+func (l *recordingLogger) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	l.events = append(l.events, "end")
+}
+func (l *recordingLogger) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+}
+func (l *recordingLogger) CaptureExit(output []byte, gasUsed uint64, err error) {}
+func (l *recordingLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+func (l *recordingLogger) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+func (l *recordingLogger) OnBalanceChange(addr common.Address, prev, new *big.Int) {
+	l.balances[addr] = new
+}
+func (l *recordingLogger) OnNonceChange(addr common.Address, prev, new uint64) {}
+func (l *recordingLogger) OnCodeChange(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+}
+func (l *recordingLogger) OnStorageChange(addr common.Address, slot common.Hash, prev, new common.Hash) {
+}
+func (l *recordingLogger) OnLog(log *types.Log) {}
+
+// Tests that the blockchain logger configured as the tracer of the chain is
+// notified of the processing of the imported blocks.
+func TestBlockchainLogger(t *testing.T) {
+	var (
+		key, _  = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = common.Address(key.GetAddress())
+		theAddr = common.Address{1}
+		gspec   = &Genesis{
+			Config:  params.AllBeaconProtocolChanges,
+			Alloc:   GenesisAlloc{address: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, beacon.NewFaker(), 2, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     block.TxNonce(address),
+			To:        &theAddr,
+			Value:     big.NewInt(1),
+			Gas:       params.TxGas,
+			GasFeeCap: block.BaseFee(),
+		}), signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block.AddTx(tx)
+	})
+	logger := &recordingLogger{balances: make(map[common.Address]*big.Int)}
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, beacon.NewFaker(), vm.Config{Tracer: logger}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	// Drop the call frames of the system calls, made outside the transactions
+	var (
+		have []string
+		inTx bool
+	)
+	for _, event := range logger.events {
+		switch event {
+		case "txStart":
+			inTx = true
+		case "txEnd":
+			inTx = false
+		case "start", "end":
+			if !inTx {
+				continue
+			}
+		}
+		have = append(have, event)
+	}
+	var want []string
+	for range blocks {
+		want = append(want, "blockStart", "txStart", "start", "end", "txEnd", "blockEnd")
+	}
+	if !slices.Equal(have, want) {
+		t.Fatalf("event mismatch: have %v, want %v", have, want)
+	}
+	if balance := logger.balances[theAddr]; balance == nil || balance.Int64() != 2 {
+		t.Fatalf("recipient balance mismatch: have %v, want 2", balance)
+	}
+}
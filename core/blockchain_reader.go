@@ -17,6 +17,8 @@
 package core
 
 import (
+	"errors"
+
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/consensus"
 	"github.com/theQRL/go-zond/core/rawdb"
@@ -227,6 +229,12 @@ func (bc *BlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
 	return receipts
 }
 
+// GetStateDiff retrieves the diff of the accounts and storage slots mutated by
+// the block with the given hash and number, nil if it's not stored.
+func (bc *BlockChain) GetStateDiff(hash common.Hash, number uint64) *types.StateDiff {
+	return rawdb.ReadStateDiff(bc.db, hash, number)
+}
+
 // GetCanonicalHash returns the canonical hash for a given block number
 func (bc *BlockChain) GetCanonicalHash(number uint64) common.Hash {
 	return bc.hc.GetCanonicalHash(number)
@@ -294,6 +302,16 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
 	return state.New(root, bc.stateCache, bc.snaps)
 }
 
+// HistoricState returns a historical state based on a particular point in time,
+// rebuilt from the indexed state histories. It's only supported in path scheme
+// and the returned state can't be committed.
+func (bc *BlockChain) HistoricState(root common.Hash) (*state.StateDB, error) {
+	if bc.historyCache == nil {
+		return nil, errors.New("historical state is only supported in path scheme")
+	}
+	return state.New(root, bc.historyCache, nil)
+}
+
 // Config retrieves the chain's fork configuration.
 func (bc *BlockChain) Config() *params.ChainConfig { return bc.chainConfig }
 
@@ -347,6 +365,17 @@ func (bc *BlockChain) TxLookupLimit() uint64 {
 	return bc.txLookupLimit
 }
 
+// HistoryPruningCutoff returns the number of the first block whose body and
+// receipts are retained, the ones of the blocks below it being expired from
+// the ancient store.
+func (bc *BlockChain) HistoryPruningCutoff() uint64 {
+	tail, err := bc.db.Tail()
+	if err != nil {
+		return 0
+	}
+	return tail
+}
+
 // TrieDB retrieves the low level trie database used for data storage.
 func (bc *BlockChain) TrieDB() *trie.Database {
 	return bc.triedb
@@ -382,3 +411,8 @@ func (bc *BlockChain) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscript
 func (bc *BlockChain) SubscribeBlockProcessingEvent(ch chan<- bool) event.Subscription {
 	return bc.scope.Track(bc.blockProcFeed.Subscribe(ch))
 }
+
+// SubscribeStateDiffEvent registers a subscription of StateDiffEvent.
+func (bc *BlockChain) SubscribeStateDiffEvent(ch chan<- StateDiffEvent) event.Subscription {
+	return bc.scope.Track(bc.stateDiffFeed.Subscribe(ch))
+}
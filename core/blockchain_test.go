@@ -3209,3 +3209,95 @@ func TestEIP3651(t *testing.T) {
 		t.Fatalf("sender balance incorrect: expected %d, got %d", expected, actual)
 	}
 }
+
+// Tests that the state diffs of the imported blocks are persisted and announced
+// if enabled, and pruned along with the state histories.
+func TestStateDiffs(t *testing.T) {
+	testStateDiffs(t, rawdb.HashScheme)
+	testStateDiffs(t, rawdb.PathScheme)
+}
+
+func testStateDiffs(t *testing.T, scheme string) {
+	var (
+		key, _  = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = common.Address(key.GetAddress())
+		theAddr = common.Address{1}
+		gspec   = &Genesis{
+			Config:  params.AllBeaconProtocolChanges,
+			Alloc:   GenesisAlloc{address: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, beacon.NewFaker(), 10, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     block.TxNonce(address),
+			To:        &theAddr,
+			Value:     big.NewInt(1),
+			Gas:       params.TxGas,
+			GasFeeCap: block.BaseFee(),
+		}), signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block.AddTx(tx)
+	})
+	config := DefaultCacheConfigWithScheme(scheme)
+	config.StateDiffs = true
+	config.StateHistory = 4
+
+	blockchain, _ := NewBlockChain(rawdb.NewMemoryDatabase(), config, gspec, beacon.NewFaker(), vm.Config{}, nil)
+	defer blockchain.Stop()
+
+	events := make(chan StateDiffEvent, len(blocks))
+	sub := blockchain.SubscribeStateDiffEvent(events)
+	defer sub.Unsubscribe()
+
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	for i, block := range blocks {
+		diff := blockchain.GetStateDiff(block.Hash(), block.NumberU64())
+		if block.NumberU64() < 6 {
+			if diff != nil {
+				t.Fatalf("block %d: state diff not pruned", block.NumberU64())
+			}
+			continue
+		}
+		if diff == nil {
+			t.Fatalf("block %d: state diff missing", block.NumberU64())
+		}
+		var (
+			sender    *types.AccountDiff
+			recipient *types.AccountDiff
+		)
+		for _, account := range diff.Accounts {
+			switch account.Address {
+			case address:
+				sender = account
+			case theAddr:
+				recipient = account
+			}
+		}
+		if sender == nil || recipient == nil {
+			t.Fatalf("block %d: transfer accounts missing from state diff", block.NumberU64())
+		}
+		post, err := types.FullAccount(recipient.Post)
+		if err != nil {
+			t.Fatalf("block %d: failed to decode account: %v", block.NumberU64(), err)
+		}
+		if post.Balance.Uint64() != uint64(i+1) {
+			t.Fatalf("block %d: recipient balance mismatch: have %d, want %d", block.NumberU64(), post.Balance, i+1)
+		}
+	}
+	for _, block := range blocks {
+		select {
+		case ev := <-events:
+			if ev.Header.Hash() != block.Hash() || ev.Diff == nil {
+				t.Fatalf("state diff event mismatch: have %d, want %d", ev.Header.Number, block.NumberU64())
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("block %d: state diff event missing", block.NumberU64())
+		}
+	}
+}
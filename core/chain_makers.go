@@ -86,6 +86,9 @@ func (b *BlockGen) addTx(bc *BlockChain, vmConfig vm.Config, tx *types.Transacti
 	}
 	b.txs = append(b.txs, tx)
 	b.receipts = append(b.receipts, receipt)
+	if b.header.BlobGasUsed != nil {
+		*b.header.BlobGasUsed += receipt.BlobGasUsed
+	}
 }
 
 // AddTx adds a transaction to the generated block. If no coinbase has
@@ -235,6 +238,18 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 		b := &BlockGen{i: i, chain: blocks, parent: parent, statedb: statedb, config: config, engine: engine}
 		b.header = makeHeader(chainreader, parent, statedb)
 
+		// Update the system contracts before any user modifications
+		var (
+			blockContext = NewZVMBlockContext(b.header, nil, &b.header.Coinbase)
+			vmenv        = vm.NewZVM(blockContext, vm.TxContext{}, statedb, config, vm.Config{})
+		)
+		InstallSystemContracts(config, parent.Header(), b.header, statedb)
+		if beaconRoot := b.header.ParentBeaconRoot; beaconRoot != nil {
+			ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+		}
+		if config.IsPrague(b.header.Number, b.header.Time) {
+			ProcessParentBlockHash(b.header.ParentHash, vmenv, statedb)
+		}
 		// Execute any user modifications to the block
 		if gen != nil {
 			gen(i, b)
@@ -306,7 +321,20 @@ func makeHeader(chain consensus.ChainReader, parent *types.Block, state *state.S
 		Time:       time,
 		BaseFee:    eip1559.CalcBaseFee(chain.Config(), parent.Header()),
 	}
-
+	if chain.Config().IsCancun(header.Number, header.Time) {
+		var (
+			parentExcessBlobGas uint64
+			parentBlobGasUsed   uint64
+		)
+		if parent.ExcessBlobGas() != nil {
+			parentExcessBlobGas = *parent.ExcessBlobGas()
+			parentBlobGasUsed = *parent.BlobGasUsed()
+		}
+		excessBlobGas := eip1559.CalcExcessBlobGas(parentExcessBlobGas, parentBlobGasUsed)
+		header.ExcessBlobGas = &excessBlobGas
+		header.BlobGasUsed = new(uint64)
+		header.ParentBeaconRoot = new(common.Hash)
+	}
 	return header
 }
 
@@ -97,4 +97,22 @@ var (
 
 	// ErrSenderNoEOA is returned if the sender of a transaction is a contract.
 	ErrSenderNoEOA = errors.New("sender not an eoa")
+
+	// ErrPublicKeyNotRegistered is returned if a registered key transaction is
+	// sent from an account without a public key in the registry.
+	ErrPublicKeyNotRegistered = errors.New("public key not registered")
+
+	// ErrInvalidSignature is returned if the signature of a registered key
+	// transaction does not verify against the registered public key.
+	ErrInvalidSignature = errors.New("invalid transaction signature")
+
+	// ErrBlobFeeCapTooLow is returned if the transaction fee cap is less than the
+	// blob gas fee of the block.
+	ErrBlobFeeCapTooLow = errors.New("max fee per blob gas less than block blob gas fee")
+
+	// ErrMissingBlobHashes is returned if a blob transaction has no blob hashes.
+	ErrMissingBlobHashes = errors.New("blob transaction missing blob hashes")
+
+	// ErrBlobTxCreate is returned if a blob transaction has no explicit to field.
+	ErrBlobTxCreate = errors.New("blob transaction of type create")
 )
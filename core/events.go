@@ -41,3 +41,10 @@ type ChainSideEvent struct {
 }
 
 type ChainHeadEvent struct{ Block *types.Block }
+
+// StateDiffEvent is posted when a canonical block is imported with the state
+// diff persistence enabled.
+type StateDiffEvent struct {
+	Header *types.Header
+	Diff   *types.StateDiff
+}
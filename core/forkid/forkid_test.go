@@ -50,6 +50,17 @@ func TestCreation(t *testing.T) {
 		genesis *types.Block
 		cases   []testcase
 	}{
+		// Mainnet genesis with a scheduled Cancun fork
+		{
+			&params.ChainConfig{ChainID: big.NewInt(1), CancunTime: u64(1710000000)},
+			core.DefaultGenesisBlock().ToBlock(),
+			[]testcase{
+				{0, 0, ID{Hash: checksumToBytes(0x6170f487), Next: 1710000000}},            // Unsynced
+				{100, 1709999999, ID{Hash: checksumToBytes(0x6170f487), Next: 1710000000}}, // Last Shanghai block
+				{101, 1710000000, ID{Hash: checksumToBytes(0x36e7bd05), Next: 0}},          // First Cancun block
+				{200, 2000000000, ID{Hash: checksumToBytes(0x36e7bd05), Next: 0}},          // Future Cancun block
+			},
+		},
 		// Mainnet test cases
 		{
 			params.MainnetChainConfig,
@@ -103,6 +114,8 @@ func TestCreation(t *testing.T) {
 // TestValidation tests that a local peer correctly validates and accepts a remote
 // fork ID.
 func TestValidation(t *testing.T) {
+	cancunConfig := &params.ChainConfig{ChainID: big.NewInt(1), CancunTime: u64(1710000000)}
+
 	tests := []struct {
 		config *params.ChainConfig
 		head   uint64
@@ -133,6 +146,23 @@ func TestValidation(t *testing.T) {
 		//
 		// This case detects non-upgraded nodes with majority hash power (typical Ropsten mess).
 		{params.MainnetChainConfig, 88888888, 8888888888, ID{Hash: checksumToBytes(0xdce96c2d), Next: 8888888888}, ErrLocalIncompatibleOrStale},
+
+		// Local is Shanghai with Cancun scheduled, remote announces the same. Remote is in sync.
+		{cancunConfig, 100, 1709999999, ID{Hash: checksumToBytes(0x6170f487), Next: 1710000000}, nil},
+
+		// Local is Shanghai with Cancun scheduled, remote is not aware of the fork. Remote
+		// needs software update, but it's not yet a problem.
+		{cancunConfig, 100, 1709999999, ID{Hash: checksumToBytes(0x6170f487), Next: 0}, nil},
+
+		// Local is Cancun, remote is still on Shanghai without knowing about the fork.
+		// Remote needs software update, reject.
+		{cancunConfig, 101, 1710000000, ID{Hash: checksumToBytes(0x6170f487), Next: 0}, ErrRemoteStale},
+
+		// Local is Cancun, remote is Shanghai but announces a different Cancun time. Reject.
+		{cancunConfig, 101, 1710000000, ID{Hash: checksumToBytes(0x6170f487), Next: 1720000000}, ErrRemoteStale},
+
+		// Local is Cancun, remote announces the same. No future fork is announced.
+		{cancunConfig, 101, 1710000000, ID{Hash: checksumToBytes(0x36e7bd05), Next: 0}, nil},
 	}
 	for i, tt := range tests {
 		filter := newFilter(tt.config, core.DefaultGenesisBlock().ToBlock(), func() (uint64, uint64) { return tt.head, tt.time })
@@ -172,9 +202,10 @@ func TestTimeBasedForkInGenesis(t *testing.T) {
 		time       = uint64(1690475657)
 		genesis    = types.NewBlockWithHeader(&types.Header{Time: time})
 		forkidHash = checksumToBytes(crc32.ChecksumIEEE(genesis.Hash().Bytes()))
-		config     = func() *params.ChainConfig {
+		config     = func(cancun *uint64) *params.ChainConfig {
 			return &params.ChainConfig{
-				ChainID: big.NewInt(1337),
+				ChainID:    big.NewInt(1337),
+				CancunTime: cancun,
 			}
 		}
 	)
@@ -182,19 +213,17 @@ func TestTimeBasedForkInGenesis(t *testing.T) {
 		config *params.ChainConfig
 		want   ID
 	}{
-		// NOTE(rgeraldes24): revisit upon new fork
-		/*
-			// Shanghai active before genesis, skip
-			{config(), ID{Hash: forkidHash, Next: time + 1}},
+		// Cancun active before genesis, skip
+		{config(u64(time - 1)), ID{Hash: forkidHash, Next: 0}},
+
+		// Cancun active at genesis, skip
+		{config(u64(time)), ID{Hash: forkidHash, Next: 0}},
 
-			// Shanghai active at genesis, skip
-			{config(), ID{Hash: forkidHash, Next: time + 1}},
+		// Cancun not active, don't skip
+		{config(u64(time + 1)), ID{Hash: forkidHash, Next: time + 1}},
 
-			// Shanghai not active, skip
-			{config(), ID{Hash: forkidHash, Next: time + 1}},
-		*/
 		// No forks
-		{config(), ID{Hash: forkidHash, Next: 0}},
+		{config(nil), ID{Hash: forkidHash, Next: 0}},
 	}
 	for _, tt := range tests {
 		if have := NewID(tt.config, genesis, 0, time); have != tt.want {
@@ -202,3 +231,5 @@ func TestTimeBasedForkInGenesis(t *testing.T) {
 		}
 	}
 }
+
+func u64(val uint64) *uint64 { return &val }
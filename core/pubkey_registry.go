@@ -0,0 +1,108 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
+)
+
+// The public key registry is kept in the storage of the registry system
+// contract. The key of an account is stored at consecutive slots, starting at
+// keccak256(address): the first slot holds the length of the key, followed by
+// the key itself split into 32 byte words.
+//
+// A key is registered by sending a transaction carrying it to the registry.
+// The storage writes are paid for upfront as part of the intrinsic gas of the
+// transaction, see KeyRegistrationGas.
+const registryKeyWords = (pqcrypto.DilithiumPublicKeyLength + common.HashLength - 1) / common.HashLength
+
+// keyRegistrationGas is the cost of populating a registry entry, charged as a
+// fresh SSTORE for the length slot and every word of the key.
+const keyRegistrationGas = (registryKeyWords + 1) * params.SstoreSetGasEIP2200
+
+// registryKeyLength is the length marker of a populated registry entry.
+var registryKeyLength = common.Hash(uint256.NewInt(pqcrypto.DilithiumPublicKeyLength).Bytes32())
+
+// registrySlot returns the storage slot of the i'th word of the registry entry
+// of the given address.
+func registrySlot(addr common.Address, i uint64) common.Hash {
+	base := new(uint256.Int).SetBytes(crypto.Keccak256(addr.Bytes()))
+	return base.AddUint64(base, i).Bytes32()
+}
+
+// IsKeyRegistration reports whether a transaction with the given recipient and
+// public key registers the key in the public key registry. Only meaningful
+// once the Cancun fork is active.
+func IsKeyRegistration(to *common.Address, pubkey []byte) bool {
+	return to != nil && *to == params.PublicKeyRegistryAddress && len(pubkey) == pqcrypto.DilithiumPublicKeyLength
+}
+
+// KeyRegistrationGas returns the gas a transaction has to pay on top of its
+// intrinsic gas for registering its public key, zero if the transaction does
+// not register one. The gas is charged even if the key is registered already.
+func KeyRegistrationGas(to *common.Address, pubkey []byte) uint64 {
+	if !IsKeyRegistration(to, pubkey) {
+		return 0
+	}
+	return keyRegistrationGas
+}
+
+// RegisteredPublicKey returns the public key registered for the given address,
+// or nil if the account has not registered one yet.
+func RegisteredPublicKey(statedb vm.StateDB, addr common.Address) []byte {
+	registry := params.PublicKeyRegistryAddress
+	if statedb.GetState(registry, registrySlot(addr, 0)) != registryKeyLength {
+		return nil
+	}
+	pubkey := make([]byte, registryKeyWords*common.HashLength)
+	for i := uint64(0); i < registryKeyWords; i++ {
+		word := statedb.GetState(registry, registrySlot(addr, i+1))
+		copy(pubkey[i*common.HashLength:], word[:])
+	}
+	return pubkey[:pqcrypto.DilithiumPublicKeyLength]
+}
+
+// RegisterPublicKey records the given public key in the registry under the
+// address derived from it. Registering an already known key is a no-op.
+//
+// The registry contract has to be deployed, otherwise the account is deemed
+// empty and removed from the state together with the registered keys.
+func RegisterPublicKey(statedb vm.StateDB, pubkey []byte) {
+	if len(pubkey) != pqcrypto.DilithiumPublicKeyLength {
+		return
+	}
+	var (
+		registry = params.PublicKeyRegistryAddress
+		addr     = pqcrypto.DilithiumPKToAddress(pubkey)
+	)
+	if statedb.GetState(registry, registrySlot(addr, 0)) == registryKeyLength {
+		return
+	}
+	statedb.SetState(registry, registrySlot(addr, 0), registryKeyLength)
+
+	padded := make([]byte, registryKeyWords*common.HashLength)
+	copy(padded, pubkey)
+	for i := uint64(0); i < registryKeyWords; i++ {
+		word := common.BytesToHash(padded[i*common.HashLength : (i+1)*common.HashLength])
+		statedb.SetState(registry, registrySlot(addr, i+1), word)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/dilithium"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
+)
+
+func TestPublicKeyRegistry(t *testing.T) {
+	var (
+		key, _     = pqcrypto.GenerateDilithiumKey()
+		pk         = key.GetPK()
+		addr       = common.Address(key.GetAddress())
+		statedb, _ = state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	)
+	statedb.SetCode(params.PublicKeyRegistryAddress, params.PublicKeyRegistryCode)
+	if have := RegisteredPublicKey(statedb, addr); have != nil {
+		t.Fatalf("unexpected registered key before registration: %x", have)
+	}
+	RegisterPublicKey(statedb, pk[:])
+	if have := RegisteredPublicKey(statedb, addr); !bytes.Equal(have, pk[:]) {
+		t.Fatalf("registered key mismatch: have %x, want %x", have, pk)
+	}
+	// The registry must survive the removal of empty accounts
+	statedb.Finalise(true)
+	if have := RegisteredPublicKey(statedb, addr); !bytes.Equal(have, pk[:]) {
+		t.Fatalf("registered key lost after finalisation: have %x, want %x", have, pk)
+	}
+}
+
+// Tests that registered key transactions are accepted once the sender has
+// registered its public key by a paid transaction to the registry, and
+// rejected otherwise.
+func TestRegisteredKeyTransactions(t *testing.T) {
+	var (
+		config  = params.TestChainConfig
+		signer  = types.LatestSigner(config)
+		key1, _ = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		key2, _ = pqcrypto.HexToDilithium("0202020202020202020202020202020202020202020202020202002020202020")
+		addr1   = common.Address(key1.GetAddress())
+		addr2   = common.Address(key2.GetAddress())
+		gspec   = &Genesis{
+			Config: config,
+			Alloc: GenesisAlloc{
+				addr1: {Balance: big.NewInt(1000000000000000000)},
+				addr2: {Balance: big.NewInt(1000000000000000000)},
+				// Cancun is active since genesis, the registry has to be allocated
+				params.PublicKeyRegistryAddress: {Nonce: 1, Code: params.PublicKeyRegistryCode, Balance: common.Big0},
+			},
+		}
+		registry = params.PublicKeyRegistryAddress
+		regGas   = params.TxGas + KeyRegistrationGas(&registry, make([]byte, pqcrypto.DilithiumPublicKeyLength)) + 100 // plus the registry code execution
+	)
+	mkDynamicTx := func(key *dilithium.Dilithium, nonce uint64, to common.Address, gas uint64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(0),
+			GasFeeCap: big.NewInt(params.InitialBaseFee),
+			Gas:       gas,
+			To:        &to,
+			Value:     big.NewInt(0),
+		})
+	}
+	mkRegisteredKeyTx := func(key *dilithium.Dilithium, from common.Address, nonce uint64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.RegisteredKeyTx{
+			ChainID:   config.ChainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(0),
+			GasFeeCap: big.NewInt(params.InitialBaseFee),
+			Gas:       params.TxGas,
+			To:        &common.Address{},
+			Value:     big.NewInt(0),
+			From:      from,
+		})
+	}
+	// Plain transactions carrying the public key don't register it, the
+	// transaction sent to the registry does, allowing the last one to omit it.
+	_, blocks, _ := GenerateChainWithGenesis(gspec, beacon.NewFaker(), 3, func(i int, b *BlockGen) {
+		switch i {
+		case 0:
+			b.AddTx(mkDynamicTx(key1, 0, common.Address{}, params.TxGas))
+			b.AddTx(mkDynamicTx(key2, 0, common.Address{}, params.TxGas))
+		case 1:
+			b.AddTx(mkDynamicTx(key1, 1, registry, regGas))
+		case 2:
+			b.AddTx(mkRegisteredKeyTx(key1, addr1, 2))
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	statedb, _ := chain.State()
+	if have, want := statedb.GetNonce(addr1), uint64(3); have != want {
+		t.Fatalf("sender nonce mismatch: have %d, want %d", have, want)
+	}
+	pk := key1.GetPK()
+	if have := RegisteredPublicKey(statedb, addr1); !bytes.Equal(have, pk[:]) {
+		t.Fatalf("registered key mismatch: have %x, want %x", have, pk)
+	}
+	if have := RegisteredPublicKey(statedb, addr2); have != nil {
+		t.Fatalf("unexpected registered key: %x", have)
+	}
+	for i, tt := range []struct {
+		tx   *types.Transaction
+		want error
+	}{
+		{ // ErrPublicKeyNotRegistered
+			tx:   mkRegisteredKeyTx(key2, addr2, 1),
+			want: ErrPublicKeyNotRegistered,
+		},
+		{ // ErrInvalidSignature
+			tx:   mkRegisteredKeyTx(key2, addr1, 3),
+			want: ErrInvalidSignature,
+		},
+		{ // ErrIntrinsicGas, the registration is not paid for
+			tx:   mkDynamicTx(key2, 1, registry, params.TxGas),
+			want: ErrIntrinsicGas,
+		},
+	} {
+		block := GenerateBadBlock(blocks[len(blocks)-1], beacon.NewFaker(), types.Transactions{tt.tx}, config)
+		_, err := chain.InsertChain(types.Blocks{block})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.want)
+		}
+	}
+}
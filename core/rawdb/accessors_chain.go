@@ -25,6 +25,7 @@ import (
 	"slices"
 
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/misc/eip1559"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/log"
@@ -444,9 +445,12 @@ func ReadBodyRLP(db zonddb.Reader, hash common.Hash, number uint64) rlp.RawValue
 		// Check if the data is in ancients
 		if isCanon(reader, number, hash) {
 			data, _ = reader.Ancient(ChainFreezerBodiesTable, number)
-			return nil
+			if len(data) > 0 {
+				return nil
+			}
 		}
-		// If not, try reading from leveldb
+		// If not, or if the ancient data is pruned, try reading from
+		// leveldb. The genesis block is always kept there.
 		data, _ = db.Get(blockBodyKey(number, hash))
 		return nil
 	})
@@ -539,9 +543,12 @@ func ReadReceiptsRLP(db zonddb.Reader, hash common.Hash, number uint64) rlp.RawV
 		// Check if the data is in ancients
 		if isCanon(reader, number, hash) {
 			data, _ = reader.Ancient(ChainFreezerReceiptTable, number)
-			return nil
+			if len(data) > 0 {
+				return nil
+			}
 		}
-		// If not, try reading from leveldb
+		// If not, or if the ancient data is pruned, try reading from
+		// leveldb. The genesis block is always kept there.
 		data, _ = db.Get(blockReceiptsKey(number, hash))
 		return nil
 	})
@@ -596,7 +603,12 @@ func ReadReceipts(db zonddb.Reader, hash common.Hash, number uint64, time uint64
 	} else {
 		baseFee = header.BaseFee
 	}
-	if err := receipts.DeriveFields(config, hash, number, time, baseFee, body.Transactions); err != nil {
+	// Compute effective blob gas price.
+	var blobGasPrice *big.Int
+	if header != nil && header.ExcessBlobGas != nil {
+		blobGasPrice = eip1559.CalcBlobFee(*header.ExcessBlobGas)
+	}
+	if err := receipts.DeriveFields(config, hash, number, time, baseFee, blobGasPrice, body.Transactions); err != nil {
 		log.Error("Failed to derive block receipts fields", "hash", hash, "number", number, "err", err)
 		return nil
 	}
@@ -758,6 +770,21 @@ func writeAncientBlock(op zonddb.AncientWriteOp, block *types.Block, header *typ
 	return nil
 }
 
+// WriteAncientHeaderChain writes the headers into the ancient store along with
+// empty bodies and receipts, for chains whose history is not available below
+// the last header. The placeholders must be pruned afterwards by truncating the
+// tail of the ancient store past the headers.
+func WriteAncientHeaderChain(db zonddb.AncientWriter, headers []*types.Header) (int64, error) {
+	return db.ModifyAncients(func(op zonddb.AncientWriteOp) error {
+		for _, header := range headers {
+			if err := writeAncientBlock(op, types.NewBlockWithHeader(header), header, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // DeleteBlock removes all block data associated with a hash.
 func DeleteBlock(db zonddb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
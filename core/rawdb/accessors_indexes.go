@@ -18,12 +18,14 @@ package rawdb
 
 import (
 	"bytes"
+	"encoding/binary"
 	"math/big"
 
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/log"
 	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/rlp"
 	"github.com/theQRL/go-zond/zonddb"
 )
 
@@ -165,3 +167,117 @@ func DeleteBloombits(db zonddb.Database, bit uint, from uint64, to uint64) {
 		log.Crit("Failed to delete bloom bits", "err", it.Error())
 	}
 }
+
+// ReadTraceIndexTail retrieves the number of the oldest block whose call traces
+// have been indexed. Nil is returned if the trace indexing has never run.
+func ReadTraceIndexTail(db zonddb.KeyValueReader) *uint64 {
+	data, _ := db.Get(traceIndexTailKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteTraceIndexTail stores the number of the oldest block whose call traces
+// have been indexed.
+func WriteTraceIndexTail(db zonddb.KeyValueWriter, number uint64) {
+	if err := db.Put(traceIndexTailKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the trace index tail", "err", err)
+	}
+}
+
+// ReadTraceIndexHead retrieves the number of the latest block whose call traces
+// have been indexed. Nil is returned if the trace indexing has never run.
+func ReadTraceIndexHead(db zonddb.KeyValueReader) *uint64 {
+	data, _ := db.Get(traceIndexHeadKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteTraceIndexHead stores the number of the latest block whose call traces
+// have been indexed.
+func WriteTraceIndexHead(db zonddb.KeyValueWriter, number uint64) {
+	if err := db.Put(traceIndexHeadKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the trace index head", "err", err)
+	}
+}
+
+// ReadBlockTraces retrieves the json encoded call traces of the transactions in
+// the block with the given hash and number, nil if they are not indexed.
+func ReadBlockTraces(db zonddb.KeyValueReader, hash common.Hash, number uint64) [][]byte {
+	data, _ := db.Get(blockTracesKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var traces [][]byte
+	if err := rlp.DecodeBytes(data, &traces); err != nil {
+		log.Error("Invalid block traces RLP", "hash", hash, "number", number, "err", err)
+		return nil
+	}
+	return traces
+}
+
+// WriteBlockTraces stores the json encoded call traces of the transactions in
+// the block with the given hash and number.
+func WriteBlockTraces(db zonddb.KeyValueWriter, hash common.Hash, number uint64, traces [][]byte) {
+	data, err := rlp.EncodeToBytes(traces)
+	if err != nil {
+		log.Crit("Failed to RLP encode block traces", "err", err)
+	}
+	if err := db.Put(blockTracesKey(number, hash), data); err != nil {
+		log.Crit("Failed to store block traces", "err", err)
+	}
+}
+
+// DeleteBlockTraces removes the call traces of all the blocks, canonical or not,
+// with the given number. The traces are looked up in the database and deleted
+// through the given writer, so that the deletion can be part of a batch.
+func DeleteBlockTraces(db zonddb.Iteratee, writer zonddb.KeyValueWriter, number uint64) {
+	prefix := append(blockTracesPrefix, encodeBlockNumber(number)...)
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if len(it.Key()) != len(prefix)+common.HashLength {
+			continue
+		}
+		if err := writer.Delete(it.Key()); err != nil {
+			log.Crit("Failed to delete block traces", "err", err)
+		}
+	}
+}
+
+// WriteTraceAddressIndex marks the block with the given number as containing a
+// call trace from or to the given address.
+func WriteTraceAddressIndex(db zonddb.KeyValueWriter, address common.Address, number uint64) {
+	if err := db.Put(traceAddressKey(address, number), nil); err != nil {
+		log.Crit("Failed to store trace address index", "err", err)
+	}
+}
+
+// ReadTraceAddressIndex returns the numbers of the blocks within the given
+// inclusive range containing a call trace from or to the given address. The
+// index is not cleaned up on reorgs, so the returned blocks may also include
+// the ones which were replaced on the canonical chain.
+func ReadTraceAddressIndex(db zonddb.Iteratee, address common.Address, from uint64, to uint64) []uint64 {
+	prefix := append(traceAddressPrefix, address.Bytes()...)
+	it := db.NewIterator(prefix, encodeBlockNumber(from))
+	defer it.Release()
+
+	var numbers []uint64
+	for it.Next() {
+		if len(it.Key()) != len(prefix)+8 {
+			continue
+		}
+		number := binary.BigEndian.Uint64(it.Key()[len(prefix):])
+		if number > to {
+			break
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers
+}
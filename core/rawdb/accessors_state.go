@@ -20,7 +20,9 @@ import (
 	"encoding/binary"
 
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/rlp"
 	"github.com/theQRL/go-zond/zonddb"
 )
 
@@ -137,6 +139,138 @@ func WritePersistentStateID(db zonddb.KeyValueWriter, number uint64) {
 	}
 }
 
+// ReadStateHistoryIndexHead retrieves the id of the latest indexed state history.
+// Nil is returned if the state histories are not indexed at all.
+func ReadStateHistoryIndexHead(db zonddb.KeyValueReader) *uint64 {
+	data, _ := db.Get(stateHistoryIndexHeadKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteStateHistoryIndexHead stores the id of the latest indexed state history
+// into database.
+func WriteStateHistoryIndexHead(db zonddb.KeyValueWriter, id uint64) {
+	if err := db.Put(stateHistoryIndexHeadKey, encodeBlockNumber(id)); err != nil {
+		log.Crit("Failed to store the state history index head", "err", err)
+	}
+}
+
+// DeleteStateHistoryIndexHead deletes the id of the latest indexed state history.
+func DeleteStateHistoryIndexHead(db zonddb.KeyValueWriter) {
+	if err := db.Delete(stateHistoryIndexHeadKey); err != nil {
+		log.Crit("Failed to remove the state history index head", "err", err)
+	}
+}
+
+// WriteStateHistoryAccountIndex marks the account as mutated by the state
+// history with the given id.
+func WriteStateHistoryAccountIndex(db zonddb.KeyValueWriter, address common.Address, id uint64) {
+	if err := db.Put(stateHistoryAccountIndexKey(address, id), nil); err != nil {
+		log.Crit("Failed to store account history index", "err", err)
+	}
+}
+
+// DeleteStateHistoryAccountIndex removes the account history index entry
+// belonging to the state history with the given id.
+func DeleteStateHistoryAccountIndex(db zonddb.KeyValueWriter, address common.Address, id uint64) {
+	if err := db.Delete(stateHistoryAccountIndexKey(address, id)); err != nil {
+		log.Crit("Failed to delete account history index", "err", err)
+	}
+}
+
+// WriteStateHistoryStorageIndex marks the storage slot as mutated by the state
+// history with the given id.
+func WriteStateHistoryStorageIndex(db zonddb.KeyValueWriter, address common.Address, slotHash common.Hash, id uint64) {
+	if err := db.Put(stateHistoryStorageIndexKey(address, slotHash, id), nil); err != nil {
+		log.Crit("Failed to store storage history index", "err", err)
+	}
+}
+
+// DeleteStateHistoryStorageIndex removes the storage history index entry
+// belonging to the state history with the given id.
+func DeleteStateHistoryStorageIndex(db zonddb.KeyValueWriter, address common.Address, slotHash common.Hash, id uint64) {
+	if err := db.Delete(stateHistoryStorageIndexKey(address, slotHash, id)); err != nil {
+		log.Crit("Failed to delete storage history index", "err", err)
+	}
+}
+
+// WriteStateHistoryIncompleteIndex marks the storage of the account as not
+// fully recorded in the state history with the given id.
+func WriteStateHistoryIncompleteIndex(db zonddb.KeyValueWriter, address common.Address, id uint64) {
+	if err := db.Put(stateHistoryIncompleteIndexKey(address, id), nil); err != nil {
+		log.Crit("Failed to store incomplete history index", "err", err)
+	}
+}
+
+// DeleteStateHistoryIncompleteIndex removes the incomplete history index entry
+// belonging to the state history with the given id.
+func DeleteStateHistoryIncompleteIndex(db zonddb.KeyValueWriter, address common.Address, id uint64) {
+	if err := db.Delete(stateHistoryIncompleteIndexKey(address, id)); err != nil {
+		log.Crit("Failed to delete incomplete history index", "err", err)
+	}
+}
+
+// FindStateHistoryAccountIndex returns the id of the first state history, no
+// lower than the given one, in which the account was mutated.
+func FindStateHistoryAccountIndex(db zonddb.Iteratee, address common.Address, from uint64) (uint64, bool) {
+	prefix := stateHistoryAccountIndexKey(address, 0)
+	return findStateHistoryIndex(db, prefix[:len(prefix)-8], from)
+}
+
+// FindStateHistoryStorageIndex returns the id of the first state history, no
+// lower than the given one, in which the storage slot was mutated.
+func FindStateHistoryStorageIndex(db zonddb.Iteratee, address common.Address, slotHash common.Hash, from uint64) (uint64, bool) {
+	prefix := stateHistoryStorageIndexKey(address, slotHash, 0)
+	return findStateHistoryIndex(db, prefix[:len(prefix)-8], from)
+}
+
+// FindStateHistoryIncompleteIndex returns the id of the first state history,
+// no lower than the given one, in which the storage of the account was not
+// fully recorded.
+func FindStateHistoryIncompleteIndex(db zonddb.Iteratee, address common.Address, from uint64) (uint64, bool) {
+	prefix := stateHistoryIncompleteIndexKey(address, 0)
+	return findStateHistoryIndex(db, prefix[:len(prefix)-8], from)
+}
+
+// findStateHistoryIndex seeks the first index entry under the given prefix
+// whose history id is no lower than the given one.
+func findStateHistoryIndex(db zonddb.Iteratee, prefix []byte, from uint64) (uint64, bool) {
+	it := db.NewIterator(prefix, encodeBlockNumber(from))
+	defer it.Release()
+
+	for it.Next() {
+		if key := it.Key(); len(key) == len(prefix)+8 {
+			return binary.BigEndian.Uint64(key[len(prefix):]), true
+		}
+	}
+	return 0, false
+}
+
+// DeleteStateHistoryIndex removes all the state history index entries along
+// with the index head marker from the database.
+func DeleteStateHistoryIndex(db zonddb.KeyValueStore) error {
+	batch := db.NewBatch()
+	for _, prefix := range [][]byte{StateHistoryAccountIndexPrefix, StateHistoryStorageIndexPrefix, StateHistoryIncompleteIndexPrefix} {
+		it := db.NewIterator(prefix, nil)
+		for it.Next() {
+			batch.Delete(it.Key())
+			if batch.ValueSize() >= zonddb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					it.Release()
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		it.Release()
+	}
+	DeleteStateHistoryIndexHead(batch)
+	return batch.Write()
+}
+
 // ReadTrieJournal retrieves the serialized in-memory trie nodes of layers saved at
 // the last shutdown.
 func ReadTrieJournal(db zonddb.KeyValueReader) []byte {
@@ -264,3 +398,60 @@ func WriteStateHistory(db zonddb.AncientWriter, id uint64, meta []byte, accountI
 		return nil
 	})
 }
+
+// ReadStateDiff retrieves the state diff of the block with the given hash and
+// number, nil if it's not stored.
+func ReadStateDiff(db zonddb.KeyValueReader, hash common.Hash, number uint64) *types.StateDiff {
+	data, _ := db.Get(stateDiffKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	diff := new(types.StateDiff)
+	if err := rlp.DecodeBytes(data, diff); err != nil {
+		log.Error("Invalid state diff RLP", "hash", hash, "number", number, "err", err)
+		return nil
+	}
+	return diff
+}
+
+// WriteStateDiff stores the state diff of the block with the given hash and
+// number.
+func WriteStateDiff(db zonddb.KeyValueWriter, hash common.Hash, number uint64, diff *types.StateDiff) {
+	data, err := rlp.EncodeToBytes(diff)
+	if err != nil {
+		log.Crit("Failed to RLP encode state diff", "err", err)
+	}
+	if err := db.Put(stateDiffKey(number, hash), data); err != nil {
+		log.Crit("Failed to store state diff", "err", err)
+	}
+}
+
+// DeleteStateDiffs removes the state diffs of all the blocks, canonical or not,
+// with the number below the given limit.
+func DeleteStateDiffs(db zonddb.KeyValueStore, limit uint64) {
+	it := db.NewIterator(stateDiffPrefix, nil)
+	defer it.Release()
+
+	batch := db.NewBatch()
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(stateDiffPrefix)+8+common.HashLength {
+			continue
+		}
+		if binary.BigEndian.Uint64(key[len(stateDiffPrefix):]) >= limit {
+			break
+		}
+		if err := batch.Delete(key); err != nil {
+			log.Crit("Failed to delete state diff", "err", err)
+		}
+		if batch.ValueSize() > zonddb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed to delete state diffs", "err", err)
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to delete state diffs", "err", err)
+	}
+}
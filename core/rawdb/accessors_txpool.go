@@ -0,0 +1,68 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/zonddb"
+)
+
+// ReadLocalTransactions retrieves all the stored local transactions, grouped
+// by sender account and sorted by nonce. Entries which fail to decode are
+// skipped.
+func ReadLocalTransactions(db zonddb.Iteratee) map[common.Address][]*types.Transaction {
+	it := db.NewIterator(localTxPrefix, nil)
+	defer it.Release()
+
+	txs := make(map[common.Address][]*types.Transaction)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(localTxPrefix)+common.AddressLength+8 {
+			continue
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(it.Value()); err != nil {
+			log.Error("Invalid local transaction", "key", common.Bytes2Hex(key), "err", err)
+			continue
+		}
+		addr := common.BytesToAddress(key[len(localTxPrefix) : len(localTxPrefix)+common.AddressLength])
+		txs[addr] = append(txs[addr], tx)
+	}
+	return txs
+}
+
+// WriteLocalTransaction stores a local transaction of the given sender,
+// replacing any previous one with the same nonce.
+func WriteLocalTransaction(db zonddb.KeyValueWriter, sender common.Address, tx *types.Transaction) {
+	blob, err := tx.MarshalBinary()
+	if err != nil {
+		log.Crit("Failed to encode local transaction", "err", err)
+	}
+	if err := db.Put(localTxKey(sender, tx.Nonce()), blob); err != nil {
+		log.Crit("Failed to store local transaction", "err", err)
+	}
+}
+
+// DeleteLocalTransaction removes the local transaction of the given sender
+// with the given nonce.
+func DeleteLocalTransaction(db zonddb.KeyValueWriter, sender common.Address, nonce uint64) {
+	if err := db.Delete(localTxKey(sender, nonce)); err != nil {
+		log.Crit("Failed to delete local transaction", "err", err)
+	}
+}
@@ -42,6 +42,13 @@ var chainFreezerNoSnappy = map[string]bool{
 	ChainFreezerReceiptTable: false,
 }
 
+// chainFreezerPrunable lists the ancient-tables whose items can be pruned from
+// the tail to expire the chain history. Headers and hashes are always retained.
+var chainFreezerPrunable = map[string]bool{
+	ChainFreezerBodiesTable:  true,
+	ChainFreezerReceiptTable: true,
+}
+
 const (
 	// stateHistoryTableSize defines the maximum size of freezer data files.
 	stateHistoryTableSize = 2 * 1000 * 1000 * 1000
@@ -17,6 +17,7 @@
 package rawdb
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/theQRL/go-zond/common"
@@ -126,6 +127,9 @@ func InspectFreezerTable(ancient string, freezerName string, tableName string, s
 	switch freezerName {
 	case chainFreezerName:
 		path, tables = resolveChainFreezerDir(ancient), chainFreezerNoSnappy
+		if PreexistingAncientStore(path) == AncientEnginePebble {
+			return errors.New("pebble ancient store has no table index")
+		}
 	default:
 		return fmt.Errorf("unknown freezer, supported ones: %v", freezers)
 	}
@@ -39,28 +39,41 @@ const (
 	freezerBatchLimit = 30000
 )
 
-// chainFreezer is a wrapper of freezer with additional chain freezing feature.
-// The background thread will keep moving ancient chain segments from key-value
-// database to flat files for saving space on live database.
+// chainFreezer is a wrapper of an ancient store with additional chain freezing
+// feature. The background thread will keep moving ancient chain segments from
+// key-value database to the ancient store, flat files or a dedicated Pebble
+// instance, for saving space on live database.
 type chainFreezer struct {
 	threshold atomic.Uint64 // Number of recent blocks not to freeze (params.FullImmutabilityThreshold apart from tests)
 
-	*Freezer
-	quit    chan struct{}
-	wg      sync.WaitGroup
-	trigger chan chan struct{} // Manual blocking freeze trigger, test determinism
+	zonddb.AncientStore
+	readonly bool
+	quit     chan struct{}
+	wg       sync.WaitGroup
+	trigger  chan chan struct{} // Manual blocking freeze trigger, test determinism
 }
 
-// newChainFreezer initializes the freezer for ancient chain data.
-func newChainFreezer(datadir string, namespace string, readonly bool) (*chainFreezer, error) {
-	freezer, err := NewChainFreezer(datadir, namespace, readonly)
+// newChainFreezer initializes the freezer for ancient chain data, backed by
+// the ancient store of the given engine.
+func newChainFreezer(datadir string, engine string, namespace string, readonly bool) (*chainFreezer, error) {
+	engine, err := resolveAncientEngine(datadir, engine)
+	if err != nil {
+		return nil, err
+	}
+	var store zonddb.AncientStore
+	if engine == AncientEnginePebble {
+		store, err = NewPebbleChainFreezer(datadir, namespace, readonly)
+	} else {
+		store, err = NewChainFreezer(datadir, namespace, readonly)
+	}
 	if err != nil {
 		return nil, err
 	}
 	cf := chainFreezer{
-		Freezer: freezer,
-		quit:    make(chan struct{}),
-		trigger: make(chan chan struct{}),
+		AncientStore: store,
+		readonly:     readonly,
+		quit:         make(chan struct{}),
+		trigger:      make(chan chan struct{}),
 	}
 	cf.threshold.Store(params.FullImmutabilityThreshold)
 	return &cf, nil
@@ -74,7 +87,7 @@ func (f *chainFreezer) Close() error {
 		close(f.quit)
 	}
 	f.wg.Wait()
-	return f.Freezer.Close()
+	return f.AncientStore.Close()
 }
 
 // freeze is a background thread that periodically checks the blockchain for any
@@ -123,7 +136,7 @@ func (f *chainFreezer) freeze(db zonddb.KeyValueStore) {
 		}
 		number := ReadHeaderNumber(nfdb, hash)
 		threshold := f.threshold.Load()
-		frozen := f.frozen.Load()
+		frozen, _ := f.Ancients()
 		switch {
 		case number == nil:
 			log.Error("Current full block number unavailable", "hash", hash)
@@ -184,7 +197,7 @@ func (f *chainFreezer) freeze(db zonddb.KeyValueStore) {
 
 		// Wipe out side chains also and track dangling side chains
 		var dangling []common.Hash
-		frozen = f.frozen.Load() // Needs reload after during freezeRange
+		frozen, _ = f.Ancients() // Needs reload after during freezeRange
 		for number := first; number < frozen; number++ {
 			// Always keep the genesis block in active database
 			if number != 0 {
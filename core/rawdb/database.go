@@ -198,8 +198,20 @@ func resolveChainFreezerDir(ancient string) string {
 // storage. The passed ancient indicates the path of root ancient directory
 // where the chain freezer can be opened.
 func NewDatabaseWithFreezer(db zonddb.KeyValueStore, ancient string, namespace string, readonly bool) (zonddb.Database, error) {
+	return newDatabaseWithFreezer(db, ancient, "", namespace, readonly)
+}
+
+// newDatabaseWithFreezer creates a high level database on top of a given key-
+// value data store with a freezer backed by the ancient store of the given
+// engine. If no engine is specified, the one of the pre-existing ancient store
+// is used, defaulting to flat files.
+func newDatabaseWithFreezer(db zonddb.KeyValueStore, ancient string, engine string, namespace string, readonly bool) (zonddb.Database, error) {
+	// Recover from an interrupted migration of the chain freezer, if any
+	if err := recoverChainFreezerMigration(ancient, readonly); err != nil {
+		return nil, err
+	}
 	// Create the idle freezer instance
-	frdb, err := newChainFreezer(resolveChainFreezerDir(ancient), namespace, readonly)
+	frdb, err := newChainFreezer(resolveChainFreezerDir(ancient), engine, namespace, readonly)
 	if err != nil {
 		printChainMetadata(db)
 		return nil, err
@@ -326,6 +338,16 @@ const (
 	dbLeveldb = "leveldb"
 )
 
+const (
+	// AncientEngineFile is the ancient store engine keeping the chain segments
+	// in flat files.
+	AncientEngineFile = "file"
+
+	// AncientEnginePebble is the ancient store engine keeping the chain segments
+	// in a dedicated Pebble instance.
+	AncientEnginePebble = "pebble"
+)
+
 // PreexistingDatabase checks the given data directory whether a database is already
 // instantiated at that location, and if so, returns the type of database (or the
 // empty string).
@@ -342,12 +364,51 @@ func PreexistingDatabase(path string) string {
 	return dbLeveldb
 }
 
+// PreexistingAncientStore checks the given chain freezer directory whether an
+// ancient store is already instantiated at that location, and if so, returns
+// its engine (or the empty string).
+func PreexistingAncientStore(path string) string {
+	if PreexistingDatabase(path) == dbPebble {
+		return AncientEnginePebble
+	}
+	// The flat-file freezer always holds the FLOCK file lock, even if it's empty
+	if common.FileExist(filepath.Join(path, "FLOCK")) {
+		return AncientEngineFile
+	}
+	return ""
+}
+
+// resolveAncientEngine returns the engine of the ancient store to open in the
+// given chain freezer directory.
+//
+//	                      engine == null        engine != null
+//	                   +----------------------------------------
+//	store non-existent |  file default     |  specified engine
+//	store existent     |  from store       |  specified engine (if compatible)
+func resolveAncientEngine(path string, engine string) (string, error) {
+	if len(engine) != 0 && engine != AncientEngineFile && engine != AncientEnginePebble {
+		return "", fmt.Errorf("unknown db.ancient.engine %v", engine)
+	}
+	existing := PreexistingAncientStore(path)
+	if len(existing) != 0 && len(engine) != 0 && engine != existing {
+		return "", fmt.Errorf("db.ancient.engine choice was %v but found pre-existing %v ancient store in specified ancient directory", engine, existing)
+	}
+	if len(existing) != 0 {
+		return existing, nil
+	}
+	if len(engine) != 0 {
+		return engine, nil
+	}
+	return AncientEngineFile, nil
+}
+
 // OpenOptions contains the options to apply when opening a database.
 // OBS: If AncientsDirectory is empty, it indicates that no freezer is to be used.
 type OpenOptions struct {
 	Type              string // "leveldb" | "pebble"
 	Directory         string // the datadir
 	AncientsDirectory string // the ancients-dir
+	AncientEngine     string // "file" | "pebble"
 	Namespace         string // the namespace for database relevant metrics
 	Cache             int    // the capacity(in megabytes) of the data caching
 	Handles           int    // number of files to be open simultaneously
@@ -410,7 +471,7 @@ func Open(o OpenOptions) (zonddb.Database, error) {
 	if len(o.AncientsDirectory) == 0 {
 		return kvdb, nil
 	}
-	frdb, err := NewDatabaseWithFreezer(kvdb, o.AncientsDirectory, o.Namespace, o.ReadOnly)
+	frdb, err := newDatabaseWithFreezer(kvdb, o.AncientsDirectory, o.AncientEngine, o.Namespace, o.ReadOnly)
 	if err != nil {
 		kvdb.Close()
 		return nil, err
@@ -463,14 +524,19 @@ func InspectDatabase(db zonddb.Database, keyPrefix, keyStart []byte) error {
 		headers         stat
 		bodies          stat
 		receipts        stat
+		stateDiffs      stat
+		blockTraces     stat
 		numHashPairings stat
 		hashNumPairings stat
 		legacyTries     stat
 		stateLookups    stat
+		historyIndexes  stat
 		accountTries    stat
 		storageTries    stat
 		codes           stat
 		txLookups       stat
+		traceAddresses  stat
+		localTxs        stat
 		accountSnaps    stat
 		storageSnaps    stat
 		preimages       stat
@@ -502,6 +568,10 @@ func InspectDatabase(db zonddb.Database, keyPrefix, keyStart []byte) error {
 			bodies.Add(size)
 		case bytes.HasPrefix(key, blockReceiptsPrefix) && len(key) == (len(blockReceiptsPrefix)+8+common.HashLength):
 			receipts.Add(size)
+		case bytes.HasPrefix(key, stateDiffPrefix) && len(key) == (len(stateDiffPrefix)+8+common.HashLength):
+			stateDiffs.Add(size)
+		case bytes.HasPrefix(key, blockTracesPrefix) && len(key) == (len(blockTracesPrefix)+8+common.HashLength):
+			blockTraces.Add(size)
 		case bytes.HasPrefix(key, headerPrefix) && bytes.HasSuffix(key, headerHashSuffix):
 			numHashPairings.Add(size)
 		case bytes.HasPrefix(key, headerNumberPrefix) && len(key) == (len(headerNumberPrefix)+common.HashLength):
@@ -510,6 +580,12 @@ func InspectDatabase(db zonddb.Database, keyPrefix, keyStart []byte) error {
 			legacyTries.Add(size)
 		case bytes.HasPrefix(key, stateIDPrefix) && len(key) == len(stateIDPrefix)+common.HashLength:
 			stateLookups.Add(size)
+		case bytes.HasPrefix(key, StateHistoryAccountIndexPrefix) && len(key) == len(StateHistoryAccountIndexPrefix)+common.AddressLength+8:
+			historyIndexes.Add(size)
+		case bytes.HasPrefix(key, StateHistoryStorageIndexPrefix) && len(key) == len(StateHistoryStorageIndexPrefix)+common.AddressLength+common.HashLength+8:
+			historyIndexes.Add(size)
+		case bytes.HasPrefix(key, StateHistoryIncompleteIndexPrefix) && len(key) == len(StateHistoryIncompleteIndexPrefix)+common.AddressLength+8:
+			historyIndexes.Add(size)
 		case IsAccountTrieNode(key):
 			accountTries.Add(size)
 		case IsStorageTrieNode(key):
@@ -518,6 +594,10 @@ func InspectDatabase(db zonddb.Database, keyPrefix, keyStart []byte) error {
 			codes.Add(size)
 		case bytes.HasPrefix(key, txLookupPrefix) && len(key) == (len(txLookupPrefix)+common.HashLength):
 			txLookups.Add(size)
+		case bytes.HasPrefix(key, traceAddressPrefix) && len(key) == (len(traceAddressPrefix)+common.AddressLength+8):
+			traceAddresses.Add(size)
+		case bytes.HasPrefix(key, localTxPrefix) && len(key) == (len(localTxPrefix)+common.AddressLength+8):
+			localTxs.Add(size)
 		case bytes.HasPrefix(key, SnapshotAccountPrefix) && len(key) == (len(SnapshotAccountPrefix)+common.HashLength):
 			accountSnaps.Add(size)
 		case bytes.HasPrefix(key, SnapshotStoragePrefix) && len(key) == (len(SnapshotStoragePrefix)+2*common.HashLength):
@@ -549,7 +629,8 @@ func InspectDatabase(db zonddb.Database, keyPrefix, keyStart []byte) error {
 				lastPivotKey, fastTrieProgressKey, snapshotDisabledKey, SnapshotRootKey, snapshotJournalKey,
 				snapshotGeneratorKey, snapshotRecoveryKey, txIndexTailKey, fastTxLookupLimitKey,
 				uncleanShutdownKey, badBlockKey, transitionStatusKey, skeletonSyncStatusKey,
-				persistentStateIDKey, trieJournalKey, snapshotSyncStatusKey,
+				persistentStateIDKey, trieJournalKey, snapshotSyncStatusKey, stateHistoryIndexHeadKey,
+				traceIndexTailKey, traceIndexHeadKey,
 			} {
 				if bytes.Equal(key, meta) {
 					metadata.Add(size)
@@ -572,13 +653,18 @@ func InspectDatabase(db zonddb.Database, keyPrefix, keyStart []byte) error {
 		{"Key-Value store", "Headers", headers.Size(), headers.Count()},
 		{"Key-Value store", "Bodies", bodies.Size(), bodies.Count()},
 		{"Key-Value store", "Receipt lists", receipts.Size(), receipts.Count()},
+		{"Key-Value store", "Block state diffs", stateDiffs.Size(), stateDiffs.Count()},
+		{"Key-Value store", "Block call traces", blockTraces.Size(), blockTraces.Count()},
 		{"Key-Value store", "Block number->hash", numHashPairings.Size(), numHashPairings.Count()},
 		{"Key-Value store", "Block hash->number", hashNumPairings.Size(), hashNumPairings.Count()},
 		{"Key-Value store", "Transaction index", txLookups.Size(), txLookups.Count()},
+		{"Key-Value store", "Local transactions", localTxs.Size(), localTxs.Count()},
 		{"Key-Value store", "Bloombit index", bloomBits.Size(), bloomBits.Count()},
+		{"Key-Value store", "Trace address index", traceAddresses.Size(), traceAddresses.Count()},
 		{"Key-Value store", "Contract codes", codes.Size(), codes.Count()},
 		{"Key-Value store", "Hash trie nodes", legacyTries.Size(), legacyTries.Count()},
 		{"Key-Value store", "Path trie state lookups", stateLookups.Size(), stateLookups.Count()},
+		{"Key-Value store", "Path state history indexes", historyIndexes.Size(), historyIndexes.Count()},
 		{"Key-Value store", "Path trie account nodes", accountTries.Size(), accountTries.Count()},
 		{"Key-Value store", "Path trie storage nodes", storageTries.Size(), storageTries.Count()},
 		{"Key-Value store", "Trie preimages", preimages.Size(), preimages.Count()},
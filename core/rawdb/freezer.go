@@ -72,6 +72,7 @@ type Freezer struct {
 
 	readonly     bool
 	tables       map[string]*freezerTable // Data tables for storing everything
+	prunable     map[string]bool          // Tables which can be truncated from the tail, nil means all
 	instanceLock *flock.Flock             // File-system lock to prevent double opens
 	closeOnce    sync.Once
 }
@@ -79,7 +80,7 @@ type Freezer struct {
 // NewChainFreezer is a small utility method around NewFreezer that sets the
 // default parameters for the chain storage.
 func NewChainFreezer(datadir string, namespace string, readonly bool) (*Freezer, error) {
-	return NewFreezer(datadir, namespace, readonly, freezerTableSize, chainFreezerNoSnappy)
+	return newFreezer(datadir, namespace, readonly, freezerTableSize, chainFreezerNoSnappy, chainFreezerPrunable)
 }
 
 // NewFreezer creates a freezer instance for maintaining immutable ordered
@@ -88,6 +89,13 @@ func NewChainFreezer(datadir string, namespace string, readonly bool) (*Freezer,
 // The 'tables' argument defines the data tables. If the value of a map
 // entry is true, snappy compression is disabled for the table.
 func NewFreezer(datadir string, namespace string, readonly bool, maxTableSize uint32, tables map[string]bool) (*Freezer, error) {
+	return newFreezer(datadir, namespace, readonly, maxTableSize, tables, nil)
+}
+
+// newFreezer creates a freezer instance in which only the given tables can be
+// truncated from the tail, the others always retain all their items. If no
+// prunable tables are specified, all of them are.
+func newFreezer(datadir string, namespace string, readonly bool, maxTableSize uint32, tables map[string]bool, prunable map[string]bool) (*Freezer, error) {
 	// Create the initial freezer object
 	var (
 		readMeter  = metrics.NewRegisteredMeter(namespace+"ancient/read", nil)
@@ -117,6 +125,7 @@ func NewFreezer(datadir string, namespace string, readonly bool, maxTableSize ui
 	freezer := &Freezer{
 		readonly:     readonly,
 		tables:       make(map[string]*freezerTable),
+		prunable:     prunable,
 		instanceLock: lock,
 	}
 
@@ -213,7 +222,8 @@ func (f *Freezer) Ancients() (uint64, error) {
 	return f.frozen.Load(), nil
 }
 
-// Tail returns the number of first stored item in the freezer.
+// Tail returns the number of first stored item in the prunable tables of the
+// freezer.
 func (f *Freezer) Tail() (uint64, error) {
 	return f.tail.Load(), nil
 }
@@ -296,7 +306,8 @@ func (f *Freezer) TruncateHead(items uint64) (uint64, error) {
 	return oitems, nil
 }
 
-// TruncateTail discards any recent data below the provided threshold number.
+// TruncateTail discards any recent data below the provided threshold number from
+// the prunable tables.
 func (f *Freezer) TruncateTail(tail uint64) (uint64, error) {
 	if f.readonly {
 		return 0, errReadOnly
@@ -308,7 +319,10 @@ func (f *Freezer) TruncateTail(tail uint64) (uint64, error) {
 	if old >= tail {
 		return old, nil
 	}
-	for _, table := range f.tables {
+	for kind, table := range f.tables {
+		if !f.isPrunable(kind) {
+			continue
+		}
 		if err := table.truncateTail(tail); err != nil {
 			return 0, err
 		}
@@ -331,31 +345,43 @@ func (f *Freezer) Sync() error {
 	return nil
 }
 
-// validate checks that every table has the same boundary.
-// Used instead of `repair` in readonly mode.
+// isPrunable reports whether the given table can be truncated from the tail.
+func (f *Freezer) isPrunable(kind string) bool {
+	return f.prunable == nil || f.prunable[kind]
+}
+
+// validate checks that every table has the same boundary, the tail only being
+// checked across the prunable tables. Used instead of `repair` in readonly mode.
 func (f *Freezer) validate() error {
 	if len(f.tables) == 0 {
 		return nil
 	}
 	var (
-		head uint64
-		tail uint64
-		name string
+		head     uint64
+		tail     uint64
+		name     string
+		tailName string
 	)
 	// Hack to get boundary of any table
 	for kind, table := range f.tables {
 		head = table.items.Load()
-		tail = table.itemHidden.Load()
 		name = kind
 		break
 	}
+	for kind, table := range f.tables {
+		if f.isPrunable(kind) {
+			tail = table.itemHidden.Load()
+			tailName = kind
+			break
+		}
+	}
 	// Now check every table against those boundaries.
 	for kind, table := range f.tables {
 		if head != table.items.Load() {
 			return fmt.Errorf("freezer tables %s and %s have differing head: %d != %d", kind, name, table.items.Load(), head)
 		}
-		if tail != table.itemHidden.Load() {
-			return fmt.Errorf("freezer tables %s and %s have differing tail: %d != %d", kind, name, table.itemHidden.Load(), tail)
+		if f.isPrunable(kind) && tail != table.itemHidden.Load() {
+			return fmt.Errorf("freezer tables %s and %s have differing tail: %d != %d", kind, tailName, table.itemHidden.Load(), tail)
 		}
 	}
 	f.frozen.Store(head)
@@ -369,20 +395,23 @@ func (f *Freezer) repair() error {
 		head = uint64(math.MaxUint64)
 		tail = uint64(0)
 	)
-	for _, table := range f.tables {
+	for kind, table := range f.tables {
 		items := table.items.Load()
 		if head > items {
 			head = items
 		}
 		hidden := table.itemHidden.Load()
-		if hidden > tail {
+		if f.isPrunable(kind) && hidden > tail {
 			tail = hidden
 		}
 	}
-	for _, table := range f.tables {
+	for kind, table := range f.tables {
 		if err := table.truncateHead(head); err != nil {
 			return err
 		}
+		if !f.isPrunable(kind) {
+			continue
+		}
 		if err := table.truncateTail(tail); err != nil {
 			return err
 		}
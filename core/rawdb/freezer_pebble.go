@@ -0,0 +1,708 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/metrics"
+	"github.com/theQRL/go-zond/rlp"
+	"github.com/theQRL/go-zond/zonddb"
+)
+
+const (
+	// pebbleFreezerCache is the amount of memory in megabytes allocated to the
+	// internal caching of the ancient store database.
+	pebbleFreezerCache = 64
+
+	// pebbleFreezerHandles is the number of file handles allocated to the
+	// ancient store database.
+	pebbleFreezerHandles = 256
+)
+
+var (
+	// pebbleFreezerHeadKey tracks the number of items stored in every table.
+	pebbleFreezerHeadKey = []byte("AncientHead")
+
+	// pebbleFreezerTailKey tracks the number of the first stored item.
+	pebbleFreezerTailKey = []byte("AncientTail")
+
+	// pebbleFreezerItemPrefix is the prefix of the stored items, followed by
+	// the length prefixed table name and the item number.
+	pebbleFreezerItemPrefix = []byte("a")
+)
+
+// pebbleFreezerTableKey = pebbleFreezerItemPrefix + len(kind) + kind
+func pebbleFreezerTableKey(kind string) []byte {
+	key := make([]byte, 0, len(pebbleFreezerItemPrefix)+1+len(kind)+8)
+	key = append(key, pebbleFreezerItemPrefix...)
+	key = append(key, byte(len(kind)))
+	return append(key, kind...)
+}
+
+// pebbleFreezerItemKey = pebbleFreezerItemPrefix + len(kind) + kind + number (uint64 big endian)
+func pebbleFreezerItemKey(kind string, number uint64) []byte {
+	return append(pebbleFreezerTableKey(kind), encodeBlockNumber(number)...)
+}
+
+// PebbleFreezer is an ancient store keeping the immutable ordered data in a
+// key-value database of its own, usually a dedicated Pebble instance, instead
+// of flat files. It provides the same semantics as the flat-file Freezer, the
+// items of all tables are appended in lockstep and can be truncated from the
+// head, and the ones of the prunable tables from the tail.
+//
+// The items are only made visible by the head and tail markers stored along
+// with them, so interrupted writes and truncations leave at most some dangling
+// items behind, which are overwritten or deleted later.
+type PebbleFreezer struct {
+	frozen atomic.Uint64 // Number of items already frozen
+	tail   atomic.Uint64 // Number of the first stored item in the prunable tables
+
+	// This lock synchronizes writers and the truncate operation, as well as
+	// the "atomic" (batched) read operations.
+	lock sync.RWMutex
+
+	db        zonddb.KeyValueStore // Database storing the items and the markers
+	readonly  bool
+	tables    map[string]bool // Tables tracked by the freezer, flagged if they are prunable
+	closeOnce sync.Once
+
+	readMeter  metrics.Meter // Meter for measuring the effective amount of data read
+	writeMeter metrics.Meter // Meter for measuring the effective amount of data written
+}
+
+// NewPebbleChainFreezer is a small utility method around NewPebbleFreezer that
+// sets the default parameters for the chain storage.
+func NewPebbleChainFreezer(datadir string, namespace string, readonly bool) (*PebbleFreezer, error) {
+	return newPebbleFreezerDB(datadir, namespace, readonly, chainFreezerNoSnappy, chainFreezerPrunable)
+}
+
+// NewPebbleFreezer opens a Pebble backed ancient store in the given directory,
+// maintaining the given tables. The values of the table map are ignored, the
+// items are compressed by the database itself.
+func NewPebbleFreezer(datadir string, namespace string, readonly bool, tables map[string]bool) (*PebbleFreezer, error) {
+	return newPebbleFreezerDB(datadir, namespace, readonly, tables, nil)
+}
+
+// newPebbleFreezerDB opens a Pebble backed ancient store in the given directory
+// in which only the given tables can be truncated from the tail. If no prunable
+// tables are specified, all of them are.
+func newPebbleFreezerDB(datadir string, namespace string, readonly bool, tables map[string]bool, prunable map[string]bool) (*PebbleFreezer, error) {
+	db, err := NewPebbleDBDatabase(datadir, pebbleFreezerCache, pebbleFreezerHandles, namespace+"ancient/", readonly, false)
+	if err != nil {
+		return nil, err
+	}
+	freezer, err := newPebbleFreezer(db, namespace, readonly, tables, prunable)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	log.Info("Opened ancient database", "database", datadir, "engine", "pebble", "readonly", readonly)
+	return freezer, nil
+}
+
+// newPebbleFreezer creates an ancient store on top of the given key-value store,
+// which is owned and closed by the freezer afterwards.
+func newPebbleFreezer(db zonddb.KeyValueStore, namespace string, readonly bool, tables map[string]bool, prunable map[string]bool) (*PebbleFreezer, error) {
+	freezer := &PebbleFreezer{
+		db:         db,
+		readonly:   readonly,
+		tables:     make(map[string]bool, len(tables)),
+		readMeter:  metrics.NewRegisteredMeter(namespace+"ancient/read", nil),
+		writeMeter: metrics.NewRegisteredMeter(namespace+"ancient/write", nil),
+	}
+	for name := range tables {
+		if len(name) > 255 {
+			return nil, fmt.Errorf("table name too long: %s", name)
+		}
+		freezer.tables[name] = prunable == nil || prunable[name]
+	}
+	head, err := readPebbleFreezerMarker(db, pebbleFreezerHeadKey)
+	if err != nil {
+		return nil, err
+	}
+	tail, err := readPebbleFreezerMarker(db, pebbleFreezerTailKey)
+	if err != nil {
+		return nil, err
+	}
+	if tail > head {
+		return nil, fmt.Errorf("ancient store tail %d above head %d", tail, head)
+	}
+	freezer.frozen.Store(head)
+	freezer.tail.Store(tail)
+	return freezer, nil
+}
+
+// readPebbleFreezerMarker retrieves the head or tail marker, zero if it's not
+// stored yet.
+func readPebbleFreezerMarker(db zonddb.KeyValueReader, key []byte) (uint64, error) {
+	if has, err := db.Has(key); err != nil || !has {
+		return 0, err
+	}
+	blob, err := db.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(blob) != 8 {
+		return 0, fmt.Errorf("invalid ancient store marker %q: %x", key, blob)
+	}
+	return binary.BigEndian.Uint64(blob), nil
+}
+
+// writePebbleFreezerMarker stores the head or tail marker.
+func writePebbleFreezerMarker(db zonddb.KeyValueWriter, key []byte, value uint64) error {
+	return db.Put(key, encodeBlockNumber(value))
+}
+
+// Close terminates the ancient store, closing the backing database.
+func (f *PebbleFreezer) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var err error
+	f.closeOnce.Do(func() {
+		err = f.db.Close()
+	})
+	return err
+}
+
+// HasAncient returns an indicator whether the specified ancient data exists
+// in the freezer.
+func (f *PebbleFreezer) HasAncient(kind string, number uint64) (bool, error) {
+	if _, ok := f.tables[kind]; !ok {
+		return false, nil
+	}
+	return number >= f.first(kind) && number < f.frozen.Load(), nil
+}
+
+// Ancient retrieves an ancient binary blob from the freezer.
+func (f *PebbleFreezer) Ancient(kind string, number uint64) ([]byte, error) {
+	if _, ok := f.tables[kind]; !ok {
+		return nil, errUnknownTable
+	}
+	if number < f.first(kind) || number >= f.frozen.Load() {
+		return nil, errOutOfBounds
+	}
+	blob, err := f.db.Get(pebbleFreezerItemKey(kind, number))
+	if err != nil {
+		return nil, err
+	}
+	f.readMeter.Mark(int64(len(blob)))
+	return blob, nil
+}
+
+// AncientRange retrieves multiple items in sequence, starting from the index 'start'.
+// It will return
+//   - at most 'count' items,
+//   - if maxBytes is specified: at least 1 item (even if exceeding the maxByteSize),
+//     but will otherwise return as many items as fit into maxByteSize.
+//   - if maxBytes is not specified, 'count' items will be returned if they are present.
+func (f *PebbleFreezer) AncientRange(kind string, start, count, maxBytes uint64) ([][]byte, error) {
+	if _, ok := f.tables[kind]; !ok {
+		return nil, errUnknownTable
+	}
+	items := f.frozen.Load()
+	if items <= start || f.first(kind) > start || count == 0 {
+		return nil, errOutOfBounds
+	}
+	if start+count > items {
+		count = items - start
+	}
+	var (
+		prefix = pebbleFreezerTableKey(kind)
+		it     = f.db.NewIterator(prefix, encodeBlockNumber(start))
+		output = make([][]byte, 0, count)
+		size   uint64
+	)
+	defer it.Release()
+
+	for uint64(len(output)) < count && it.Next() {
+		if number := binary.BigEndian.Uint64(it.Key()[len(prefix):]); number != start+uint64(len(output)) {
+			return nil, fmt.Errorf("missing item %d in table %s", start+uint64(len(output)), kind)
+		}
+		if len(output) > 0 && maxBytes != 0 && size+uint64(len(it.Value())) > maxBytes {
+			break
+		}
+		output = append(output, common.CopyBytes(it.Value()))
+		size += uint64(len(it.Value()))
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if len(output) == 0 {
+		return nil, fmt.Errorf("missing item %d in table %s", start, kind)
+	}
+	f.readMeter.Mark(int64(size))
+	return output, nil
+}
+
+// Ancients returns the length of the frozen items.
+func (f *PebbleFreezer) Ancients() (uint64, error) {
+	return f.frozen.Load(), nil
+}
+
+// Tail returns the number of first stored item in the prunable tables of the
+// freezer.
+func (f *PebbleFreezer) Tail() (uint64, error) {
+	return f.tail.Load(), nil
+}
+
+// first returns the number of the first stored item in the given table.
+func (f *PebbleFreezer) first(kind string) uint64 {
+	if f.tables[kind] {
+		return f.tail.Load()
+	}
+	return 0
+}
+
+// AncientSize returns the ancient size of the specified category. The items are
+// iterated to sum up their sizes, it's only meant for debugging.
+func (f *PebbleFreezer) AncientSize(kind string) (uint64, error) {
+	if _, ok := f.tables[kind]; !ok {
+		return 0, errUnknownTable
+	}
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	var (
+		prefix = pebbleFreezerTableKey(kind)
+		it     = f.db.NewIterator(prefix, encodeBlockNumber(f.first(kind)))
+		limit  = f.frozen.Load()
+		size   uint64
+	)
+	defer it.Release()
+
+	for it.Next() {
+		if binary.BigEndian.Uint64(it.Key()[len(prefix):]) >= limit {
+			break
+		}
+		size += uint64(len(it.Key()) + len(it.Value()))
+	}
+	return size, it.Error()
+}
+
+// ReadAncients runs the given read operation while ensuring that no writes take place
+// on the underlying freezer.
+func (f *PebbleFreezer) ReadAncients(fn func(zonddb.AncientReaderOp) error) (err error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return fn(f)
+}
+
+// ModifyAncients runs the given write operation. The appended items are only
+// made visible if all of them are written successfully.
+func (f *PebbleFreezer) ModifyAncients(fn func(zonddb.AncientWriteOp) error) (writeSize int64, err error) {
+	if f.readonly {
+		return 0, errReadOnly
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	prevItem := f.frozen.Load()
+	op := &pebbleFreezerBatch{
+		batch: f.db.NewBatch(),
+		next:  make(map[string]uint64, len(f.tables)),
+	}
+	for kind := range f.tables {
+		op.next[kind] = prevItem
+	}
+	// Delete the items already flushed in case of error, they are not visible
+	// anyway but would be left dangling otherwise.
+	defer func() {
+		if err != nil && op.flushed {
+			if err := f.deleteItems(prevItem, op.limit(), false); err != nil {
+				log.Error("Ancient store roll-back failed", "index", prevItem, "err", err)
+			}
+		}
+	}()
+	if err := fn(op); err != nil {
+		return 0, err
+	}
+	// Check that count agrees on all tables
+	item := uint64(math.MaxUint64)
+	for kind, next := range op.next {
+		if item < math.MaxUint64 && next != item {
+			return 0, fmt.Errorf("table %s is at item %d, want %d", kind, next, item)
+		}
+		item = next
+	}
+	if len(op.next) == 0 {
+		item = prevItem
+	}
+	if err := writePebbleFreezerMarker(op.batch, pebbleFreezerHeadKey, item); err != nil {
+		return 0, err
+	}
+	if err := op.batch.Write(); err != nil {
+		return 0, err
+	}
+	f.frozen.Store(item)
+	f.writeMeter.Mark(op.size)
+	return op.size, nil
+}
+
+// TruncateHead discards any recent data above the provided threshold number.
+// It returns the previous head number.
+func (f *PebbleFreezer) TruncateHead(items uint64) (uint64, error) {
+	if f.readonly {
+		return 0, errReadOnly
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	oitems := f.frozen.Load()
+	if oitems <= items {
+		return oitems, nil
+	}
+	// Hide the truncated items first, then delete them
+	batch := f.db.NewBatch()
+	writePebbleFreezerMarker(batch, pebbleFreezerHeadKey, items)
+	if f.tail.Load() > items {
+		writePebbleFreezerMarker(batch, pebbleFreezerTailKey, items)
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	f.frozen.Store(items)
+	if f.tail.Load() > items {
+		f.tail.Store(items)
+	}
+	return oitems, f.deleteItems(items, oitems, false)
+}
+
+// TruncateTail discards any recent data below the provided threshold number from
+// the prunable tables.
+func (f *PebbleFreezer) TruncateTail(tail uint64) (uint64, error) {
+	if f.readonly {
+		return 0, errReadOnly
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	old := f.tail.Load()
+	if old >= tail {
+		return old, nil
+	}
+	if f.frozen.Load() < tail {
+		return 0, errors.New("truncation above head")
+	}
+	// Hide the truncated items first, then delete them
+	batch := f.db.NewBatch()
+	writePebbleFreezerMarker(batch, pebbleFreezerTailKey, tail)
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	f.tail.Store(tail)
+	return old, f.deleteItems(old, tail, true)
+}
+
+// deleteItems removes the items within the range [from, to) from all tables, or
+// from the prunable ones only.
+func (f *PebbleFreezer) deleteItems(from, to uint64, prunableOnly bool) error {
+	batch := f.db.NewBatch()
+	for kind, prunable := range f.tables {
+		if prunableOnly && !prunable {
+			continue
+		}
+		for number := from; number < to; number++ {
+			batch.Delete(pebbleFreezerItemKey(kind, number))
+			if batch.ValueSize() >= zonddb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					return err
+				}
+				batch.Reset()
+			}
+		}
+	}
+	return batch.Write()
+}
+
+// Sync is a noop, the writes are flushed to disk by the database when they are
+// committed.
+func (f *PebbleFreezer) Sync() error {
+	return nil
+}
+
+// MigrateTable processes the entries in a given table in sequence
+// converting them to a new format if they're of an old format.
+func (f *PebbleFreezer) MigrateTable(kind string, convert convertLegacyFn) error {
+	if f.readonly {
+		return errReadOnly
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.tables[kind]; !ok {
+		return errUnknownTable
+	}
+	var (
+		prefix = pebbleFreezerTableKey(kind)
+		it     = f.db.NewIterator(prefix, encodeBlockNumber(f.first(kind)))
+		limit  = f.frozen.Load()
+		batch  = f.db.NewBatch()
+	)
+	defer it.Release()
+
+	for it.Next() {
+		if binary.BigEndian.Uint64(it.Key()[len(prefix):]) >= limit {
+			break
+		}
+		item, err := convert(it.Value())
+		if err != nil {
+			return err
+		}
+		batch.Put(common.CopyBytes(it.Key()), item)
+		if batch.ValueSize() >= zonddb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// pebbleFreezerBatch is a write operation of multiple items on a Pebble backed
+// ancient store. The items are flushed to the database once the batch grows
+// large, but they are not visible until the head marker is updated.
+type pebbleFreezerBatch struct {
+	batch   zonddb.Batch
+	next    map[string]uint64 // Number of the next item expected by every table
+	size    int64             // Total size of the appended items
+	flushed bool              // Whether some items were already flushed
+}
+
+// Append adds an RLP-encoded item of the given kind.
+func (op *pebbleFreezerBatch) Append(kind string, number uint64, item interface{}) error {
+	blob, err := rlp.EncodeToBytes(item)
+	if err != nil {
+		return err
+	}
+	return op.AppendRaw(kind, number, blob)
+}
+
+// AppendRaw adds an item of the given kind.
+func (op *pebbleFreezerBatch) AppendRaw(kind string, number uint64, item []byte) error {
+	next, ok := op.next[kind]
+	if !ok {
+		return errUnknownTable
+	}
+	if number != next {
+		return errOutOrderInsertion
+	}
+	if err := op.batch.Put(pebbleFreezerItemKey(kind, number), item); err != nil {
+		return err
+	}
+	op.next[kind] = next + 1
+	op.size += int64(len(item))
+
+	if op.batch.ValueSize() >= zonddb.IdealBatchSize {
+		if err := op.batch.Write(); err != nil {
+			return err
+		}
+		op.batch.Reset()
+		op.flushed = true
+	}
+	return nil
+}
+
+// limit returns the number of the item following the last appended one across
+// all tables.
+func (op *pebbleFreezerBatch) limit() uint64 {
+	var limit uint64
+	for _, next := range op.next {
+		limit = max(limit, next)
+	}
+	return limit
+}
+
+const (
+	// migrationNewSuffix is the suffix of the directory the Pebble backed chain
+	// freezer is built in during a migration.
+	migrationNewSuffix = ".pebble"
+
+	// migrationOldSuffix is the suffix of the directory the flat-file chain
+	// freezer is moved to while the migrated store is swapped in.
+	migrationOldSuffix = ".old"
+)
+
+// recoverChainFreezerMigration completes a chain freezer migration interrupted
+// while swapping the new store in, which would otherwise leave no chain freezer
+// in place. The flat files are only moved aside once the new store is complete,
+// so the swap is finished if the new store is present, and rolled back if not.
+func recoverChainFreezerMigration(ancient string, readonly bool) error {
+	var (
+		target = filepath.Join(ancient, chainFreezerName)
+		old    = target + migrationOldSuffix
+		tmp    = target + migrationNewSuffix
+	)
+	if !common.FileExist(old) {
+		return nil
+	}
+	if readonly {
+		return errors.New("interrupted chain ancient store migration, open the database in write mode to recover")
+	}
+	if !common.FileExist(target) {
+		if !common.FileExist(tmp) {
+			log.Warn("Rolling back interrupted chain ancient store migration", "path", target)
+			return os.Rename(old, target)
+		}
+		log.Warn("Completing interrupted chain ancient store migration", "path", target)
+		if err := os.Rename(tmp, target); err != nil {
+			return err
+		}
+	}
+	return os.RemoveAll(old)
+}
+
+// MigrateChainFreezer converts the flat-file chain freezer in the given root
+// ancient directory into a Pebble backed ancient store in place. The new store
+// is built next to the flat files and only swapped in once it's complete, so an
+// interrupted migration leaves the original freezer intact.
+func MigrateChainFreezer(ancient string) error {
+	if err := recoverChainFreezerMigration(ancient, false); err != nil {
+		return err
+	}
+	path := resolveChainFreezerDir(ancient)
+	switch PreexistingAncientStore(path) {
+	case AncientEngineFile:
+	case AncientEnginePebble:
+		return errors.New("chain ancient store is already backed by pebble")
+	default:
+		return fmt.Errorf("no chain ancient store found in %s", path)
+	}
+	src, err := NewChainFreezer(path, "", true)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// Build the new store from scratch, dropping any leftover of a previously
+	// interrupted migration
+	tmp := filepath.Join(ancient, chainFreezerName+migrationNewSuffix)
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	dst, err := NewPebbleChainFreezer(tmp, "", false)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	var (
+		head, _ = src.Ancients()
+		tail, _ = src.Tail()
+		start   = time.Now()
+		logged  = time.Now()
+	)
+	// Copy the items of every table, the ones pruned from the tail of the flat
+	// files are not available anymore.
+	for kind := range chainFreezerNoSnappy {
+		number := uint64(0)
+		if chainFreezerPrunable[kind] {
+			number = tail
+		}
+		batch := dst.db.NewBatch()
+		for number < head {
+			items, err := src.AncientRange(kind, number, min(1024, head-number), 0)
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				batch.Put(pebbleFreezerItemKey(kind, number), item)
+				number++
+			}
+			if batch.ValueSize() >= zonddb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					return err
+				}
+				batch.Reset()
+			}
+			if time.Since(logged) > 8*time.Second {
+				log.Info("Migrating chain ancient store", "table", kind, "migrated", number, "remaining", head-number, "elapsed", common.PrettyDuration(time.Since(start)))
+				logged = time.Now()
+			}
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+	}
+	// Make the items visible once all of them are copied
+	batch := dst.db.NewBatch()
+	writePebbleFreezerMarker(batch, pebbleFreezerHeadKey, head)
+	writePebbleFreezerMarker(batch, pebbleFreezerTailKey, tail)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	if err := src.Close(); err != nil {
+		return err
+	}
+	// Swap the new store in. If the flat files live in the legacy location, the
+	// root ancient directory, the new store takes precedence as soon as it's in
+	// the chain folder. Otherwise the flat files are moved aside first, and an
+	// interruption before the new store is moved in is recovered from on the
+	// next open.
+	target := filepath.Join(ancient, chainFreezerName)
+	if path == target {
+		old := path + migrationOldSuffix
+		if err := os.Rename(path, old); err != nil {
+			return err
+		}
+		if err := os.Rename(tmp, target); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(old); err != nil {
+			return err
+		}
+	} else {
+		if err := os.Rename(tmp, target); err != nil {
+			return err
+		}
+		for kind := range chainFreezerNoSnappy {
+			files, err := filepath.Glob(filepath.Join(path, kind+".*"))
+			if err != nil {
+				return err
+			}
+			for _, file := range files {
+				if err := os.Remove(file); err != nil {
+					return err
+				}
+			}
+		}
+		if err := os.Remove(filepath.Join(path, "FLOCK")); err != nil {
+			return err
+		}
+	}
+	log.Info("Migrated chain ancient store", "items", head-tail, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
@@ -0,0 +1,276 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/zonddb"
+	"github.com/theQRL/go-zond/zonddb/memorydb"
+)
+
+func TestPebbleFreezerModify(t *testing.T) {
+	t.Parallel()
+
+	db := memorydb.New()
+	f, err := newPebbleFreezer(db, "", false, map[string]bool{"a": true, "b": false}, nil)
+	if err != nil {
+		t.Fatalf("failed to create freezer: %v", err)
+	}
+	// Append some items to both tables
+	_, err = f.ModifyAncients(func(op zonddb.AncientWriteOp) error {
+		for i := 0; i < 10; i++ {
+			if err := op.AppendRaw("a", uint64(i), getChunk(10, i)); err != nil {
+				return err
+			}
+			if err := op.AppendRaw("b", uint64(i), getChunk(20, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to modify ancients: %v", err)
+	}
+	if frozen, _ := f.Ancients(); frozen != 10 {
+		t.Fatalf("frozen items mismatch: have %d, want %d", frozen, 10)
+	}
+	// Out of order insertions and diverging tables are rejected atomically
+	_, err = f.ModifyAncients(func(op zonddb.AncientWriteOp) error {
+		return op.AppendRaw("a", 11, getChunk(10, 11))
+	})
+	if !errors.Is(err, errOutOrderInsertion) {
+		t.Fatalf("out of order insertion error mismatch: have %v, want %v", err, errOutOrderInsertion)
+	}
+	_, err = f.ModifyAncients(func(op zonddb.AncientWriteOp) error {
+		return op.AppendRaw("a", 10, getChunk(10, 10))
+	})
+	if err == nil {
+		t.Fatal("diverging tables accepted")
+	}
+	if has, _ := f.HasAncient("a", 10); has {
+		t.Fatal("rolled back item visible")
+	}
+	// Retrieve the items individually and in ranges
+	for i := 0; i < 10; i++ {
+		if blob, err := f.Ancient("a", uint64(i)); err != nil || !bytes.Equal(blob, getChunk(10, i)) {
+			t.Fatalf("item %d mismatch: have %x, want %x (err %v)", i, blob, getChunk(10, i), err)
+		}
+	}
+	if _, err := f.Ancient("c", 0); !errors.Is(err, errUnknownTable) {
+		t.Fatalf("unknown table error mismatch: have %v, want %v", err, errUnknownTable)
+	}
+	if items, err := f.AncientRange("b", 5, 10, 0); err != nil || len(items) != 5 {
+		t.Fatalf("range mismatch: have %d items, want %d (err %v)", len(items), 5, err)
+	}
+	if items, err := f.AncientRange("b", 0, 10, 50); err != nil || len(items) != 2 {
+		t.Fatalf("byte limited range mismatch: have %d items, want %d (err %v)", len(items), 2, err)
+	}
+	if items, err := f.AncientRange("b", 0, 10, 1); err != nil || len(items) != 1 {
+		t.Fatalf("oversized range mismatch: have %d items, want %d (err %v)", len(items), 1, err)
+	}
+	// Truncate both ends and check the boundaries
+	if old, err := f.TruncateHead(8); err != nil || old != 10 {
+		t.Fatalf("head truncation failed: old %d, err %v", old, err)
+	}
+	if old, err := f.TruncateTail(3); err != nil || old != 0 {
+		t.Fatalf("tail truncation failed: old %d, err %v", old, err)
+	}
+	if _, err := f.TruncateTail(9); err == nil {
+		t.Fatal("tail truncated above head")
+	}
+	if _, err := f.Ancient("a", 2); !errors.Is(err, errOutOfBounds) {
+		t.Fatalf("pruned item error mismatch: have %v, want %v", err, errOutOfBounds)
+	}
+	if _, err := f.Ancient("a", 8); !errors.Is(err, errOutOfBounds) {
+		t.Fatalf("truncated item error mismatch: have %v, want %v", err, errOutOfBounds)
+	}
+	if n := db.Len(); n != 2*5+2 {
+		t.Fatalf("stored entries mismatch: have %d, want %d", n, 2*5+2)
+	}
+	// Reopen the freezer and check the boundaries are persisted
+	f, err = newPebbleFreezer(db, "", true, map[string]bool{"a": true, "b": false}, nil)
+	if err != nil {
+		t.Fatalf("failed to reopen freezer: %v", err)
+	}
+	if head, _ := f.Ancients(); head != 8 {
+		t.Fatalf("head mismatch: have %d, want %d", head, 8)
+	}
+	if tail, _ := f.Tail(); tail != 3 {
+		t.Fatalf("tail mismatch: have %d, want %d", tail, 3)
+	}
+	if _, err := f.TruncateHead(0); !errors.Is(err, errReadOnly) {
+		t.Fatalf("readonly error mismatch: have %v, want %v", err, errReadOnly)
+	}
+}
+
+func TestMigrateChainFreezer(t *testing.T) {
+	if !PebbleEnabled {
+		t.Skip("pebble not supported")
+	}
+	var (
+		ancient = t.TempDir()
+		path    = filepath.Join(ancient, chainFreezerName)
+	)
+	src, err := NewChainFreezer(path, "", false)
+	if err != nil {
+		t.Fatalf("failed to create freezer: %v", err)
+	}
+	_, err = src.ModifyAncients(func(op zonddb.AncientWriteOp) error {
+		for i := 0; i < 2000; i++ {
+			for kind := range chainFreezerNoSnappy {
+				if err := op.AppendRaw(kind, uint64(i), getChunk(32, i)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to modify ancients: %v", err)
+	}
+	// Only the bodies and receipts are pruned from the tail
+	if _, err := src.TruncateTail(100); err != nil {
+		t.Fatalf("failed to truncate tail: %v", err)
+	}
+	if has, _ := src.HasAncient(ChainFreezerHeaderTable, 0); !has {
+		t.Fatal("header pruned from the tail")
+	}
+	if has, _ := src.HasAncient(ChainFreezerBodiesTable, 0); has {
+		t.Fatal("body not pruned from the tail")
+	}
+	src.Close()
+
+	if err := MigrateChainFreezer(ancient); err != nil {
+		t.Fatalf("failed to migrate freezer: %v", err)
+	}
+	if engine := PreexistingAncientStore(path); engine != AncientEnginePebble {
+		t.Fatalf("ancient engine mismatch: have %q, want %q", engine, AncientEnginePebble)
+	}
+	if _, err := resolveAncientEngine(path, AncientEngineFile); err == nil {
+		t.Fatal("conflicting ancient engine accepted")
+	}
+	dst, err := NewPebbleChainFreezer(path, "", true)
+	if err != nil {
+		t.Fatalf("failed to open migrated freezer: %v", err)
+	}
+	defer dst.Close()
+
+	if head, _ := dst.Ancients(); head != 2000 {
+		t.Fatalf("head mismatch: have %d, want %d", head, 2000)
+	}
+	if tail, _ := dst.Tail(); tail != 100 {
+		t.Fatalf("tail mismatch: have %d, want %d", tail, 100)
+	}
+	for kind := range chainFreezerNoSnappy {
+		first := 0
+		if chainFreezerPrunable[kind] {
+			first = 100
+			if _, err := dst.Ancient(kind, 99); !errors.Is(err, errOutOfBounds) {
+				t.Fatalf("table %s pruned item error mismatch: have %v, want %v", kind, err, errOutOfBounds)
+			}
+		}
+		for i := first; i < 2000; i += 97 {
+			if blob, err := dst.Ancient(kind, uint64(i)); err != nil || !bytes.Equal(blob, getChunk(32, i)) {
+				t.Fatalf("table %s item %d mismatch: have %x, want %x (err %v)", kind, i, blob, getChunk(32, i), err)
+			}
+		}
+	}
+	if err := MigrateChainFreezer(ancient); err == nil {
+		t.Fatal("migrated freezer twice")
+	}
+}
+
+func TestMigrateChainFreezerRecovery(t *testing.T) {
+	if !PebbleEnabled {
+		t.Skip("pebble not supported")
+	}
+	// newFreezer creates a chain freezer with a few items at the given path.
+	newFreezer := func(path string, engine string) {
+		f, err := newChainFreezer(path, engine, "", false)
+		if err != nil {
+			t.Fatalf("failed to create freezer: %v", err)
+		}
+		defer f.Close()
+
+		_, err = f.ModifyAncients(func(op zonddb.AncientWriteOp) error {
+			for i := 0; i < 10; i++ {
+				for kind := range chainFreezerNoSnappy {
+					if err := op.AppendRaw(kind, uint64(i), getChunk(32, i)); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("failed to modify ancients: %v", err)
+		}
+	}
+	// checkFreezer opens the database and checks that the chain freezer has
+	// the given engine and items.
+	checkFreezer := func(ancient string, engine string) {
+		db, err := NewDatabaseWithFreezer(NewMemoryDatabase(), ancient, "", false)
+		if err != nil {
+			t.Fatalf("failed to open database: %v", err)
+		}
+		defer db.Close()
+
+		path := filepath.Join(ancient, chainFreezerName)
+		if have := PreexistingAncientStore(path); have != engine {
+			t.Fatalf("ancient engine mismatch: have %q, want %q", have, engine)
+		}
+		if items, _ := db.Ancients(); items != 10 {
+			t.Fatalf("ancient items mismatch: have %d, want %d", items, 10)
+		}
+		if common.FileExist(path+migrationOldSuffix) || common.FileExist(path+migrationNewSuffix) {
+			t.Fatal("migration leftovers not removed")
+		}
+	}
+	// Interrupted after moving the flat files aside, the complete new store is
+	// swapped in
+	ancient := t.TempDir()
+	path := filepath.Join(ancient, chainFreezerName)
+	newFreezer(path+migrationOldSuffix, AncientEngineFile)
+	newFreezer(path+migrationNewSuffix, AncientEnginePebble)
+	checkFreezer(ancient, AncientEnginePebble)
+
+	// Interrupted after swapping the new store in, the flat files are removed
+	ancient = t.TempDir()
+	path = filepath.Join(ancient, chainFreezerName)
+	newFreezer(path+migrationOldSuffix, AncientEngineFile)
+	newFreezer(path, AncientEnginePebble)
+	checkFreezer(ancient, AncientEnginePebble)
+
+	// No new store to swap in, the flat files are restored
+	ancient = t.TempDir()
+	path = filepath.Join(ancient, chainFreezerName)
+	newFreezer(path+migrationOldSuffix, AncientEngineFile)
+	checkFreezer(ancient, AncientEngineFile)
+
+	// A read only database doesn't recover
+	ancient = t.TempDir()
+	path = filepath.Join(ancient, chainFreezerName)
+	newFreezer(path+migrationOldSuffix, AncientEngineFile)
+	if _, err := NewDatabaseWithFreezer(NewMemoryDatabase(), ancient, "", true); err == nil {
+		t.Fatal("interrupted migration opened read only")
+	}
+}
@@ -46,6 +46,10 @@ var (
 	// persistentStateIDKey tracks the id of latest stored state(for path-based only).
 	persistentStateIDKey = []byte("LastStateID")
 
+	// stateHistoryIndexHeadKey tracks the id of the latest indexed state history
+	// (for path-based only).
+	stateHistoryIndexHeadKey = []byte("LastStateHistoryIndex")
+
 	// lastPivotKey tracks the last pivot block used by fast sync (to reenable on sethead).
 	lastPivotKey = []byte("LastPivot")
 
@@ -79,6 +83,12 @@ var (
 	// txIndexTailKey tracks the oldest block whose transactions have been indexed.
 	txIndexTailKey = []byte("TransactionIndexTail")
 
+	// traceIndexTailKey tracks the oldest block whose call traces have been indexed.
+	traceIndexTailKey = []byte("TraceIndexTail")
+
+	// traceIndexHeadKey tracks the latest block whose call traces have been indexed.
+	traceIndexHeadKey = []byte("TraceIndexHead")
+
 	// fastTxLookupLimitKey tracks the transaction lookup limit during fast sync.
 	fastTxLookupLimitKey = []byte("FastTransactionLookupLimit")
 
@@ -98,19 +108,28 @@ var (
 
 	blockBodyPrefix     = []byte("b") // blockBodyPrefix + num (uint64 big endian) + hash -> block body
 	blockReceiptsPrefix = []byte("r") // blockReceiptsPrefix + num (uint64 big endian) + hash -> block receipts
+	stateDiffPrefix     = []byte("D") // stateDiffPrefix + num (uint64 big endian) + hash -> block state diff
+	blockTracesPrefix   = []byte("T") // blockTracesPrefix + num (uint64 big endian) + hash -> block call traces
 
 	txLookupPrefix        = []byte("l") // txLookupPrefix + hash -> transaction/receipt lookup metadata
+	traceAddressPrefix    = []byte("X") // traceAddressPrefix + address + num (uint64 big endian) -> nil
 	bloomBitsPrefix       = []byte("B") // bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> bloom bits
 	SnapshotAccountPrefix = []byte("a") // SnapshotAccountPrefix + account hash -> account trie value
 	SnapshotStoragePrefix = []byte("o") // SnapshotStoragePrefix + account hash + storage hash -> storage trie value
 	CodePrefix            = []byte("c") // CodePrefix + code hash -> account code
 	skeletonHeaderPrefix  = []byte("S") // skeletonHeaderPrefix + num (uint64 big endian) -> header
+	localTxPrefix         = []byte("t") // localTxPrefix + address + nonce (uint64 big endian) -> local transaction
 
 	// Path-based storage scheme of merkle patricia trie.
 	trieNodeAccountPrefix = []byte("A") // trieNodeAccountPrefix + hexPath -> trie node
 	trieNodeStoragePrefix = []byte("O") // trieNodeStoragePrefix + accountHash + hexPath -> trie node
 	stateIDPrefix         = []byte("L") // stateIDPrefix + state root -> state id
 
+	// State history indexes of path-based storage scheme.
+	StateHistoryAccountIndexPrefix    = []byte("ma") // StateHistoryAccountIndexPrefix + address + history id -> nil
+	StateHistoryStorageIndexPrefix    = []byte("ms") // StateHistoryStorageIndexPrefix + address + slot hash + history id -> nil
+	StateHistoryIncompleteIndexPrefix = []byte("mi") // StateHistoryIncompleteIndexPrefix + address + history id -> nil
+
 	PreimagePrefix = []byte("secure-key-")       // PreimagePrefix + hash -> preimage
 	configPrefix   = []byte("ethereum-config-")  // config prefix for the db
 	genesisPrefix  = []byte("ethereum-genesis-") // genesis state prefix for the db
@@ -167,6 +186,21 @@ func blockReceiptsKey(number uint64, hash common.Hash) []byte {
 	return append(append(blockReceiptsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
 }
 
+// stateDiffKey = stateDiffPrefix + num (uint64 big endian) + hash
+func stateDiffKey(number uint64, hash common.Hash) []byte {
+	return append(append(stateDiffPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// blockTracesKey = blockTracesPrefix + num (uint64 big endian) + hash
+func blockTracesKey(number uint64, hash common.Hash) []byte {
+	return append(append(blockTracesPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// traceAddressKey = traceAddressPrefix + address + num (uint64 big endian)
+func traceAddressKey(address common.Address, number uint64) []byte {
+	return append(append(traceAddressPrefix, address.Bytes()...), encodeBlockNumber(number)...)
+}
+
 // txLookupKey = txLookupPrefix + hash
 func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
@@ -240,6 +274,43 @@ func stateIDKey(root common.Hash) []byte {
 	return append(stateIDPrefix, root.Bytes()...)
 }
 
+// stateHistoryAccountIndexKey = StateHistoryAccountIndexPrefix + address + id (uint64 big endian)
+func stateHistoryAccountIndexKey(address common.Address, id uint64) []byte {
+	buf := make([]byte, len(StateHistoryAccountIndexPrefix)+common.AddressLength+8)
+	n := copy(buf, StateHistoryAccountIndexPrefix)
+	n += copy(buf[n:], address.Bytes())
+	binary.BigEndian.PutUint64(buf[n:], id)
+	return buf
+}
+
+// stateHistoryStorageIndexKey = StateHistoryStorageIndexPrefix + address + slot hash + id (uint64 big endian)
+func stateHistoryStorageIndexKey(address common.Address, slotHash common.Hash, id uint64) []byte {
+	buf := make([]byte, len(StateHistoryStorageIndexPrefix)+common.AddressLength+common.HashLength+8)
+	n := copy(buf, StateHistoryStorageIndexPrefix)
+	n += copy(buf[n:], address.Bytes())
+	n += copy(buf[n:], slotHash.Bytes())
+	binary.BigEndian.PutUint64(buf[n:], id)
+	return buf
+}
+
+// stateHistoryIncompleteIndexKey = StateHistoryIncompleteIndexPrefix + address + id (uint64 big endian)
+func stateHistoryIncompleteIndexKey(address common.Address, id uint64) []byte {
+	buf := make([]byte, len(StateHistoryIncompleteIndexPrefix)+common.AddressLength+8)
+	n := copy(buf, StateHistoryIncompleteIndexPrefix)
+	n += copy(buf[n:], address.Bytes())
+	binary.BigEndian.PutUint64(buf[n:], id)
+	return buf
+}
+
+// localTxKey = localTxPrefix + address + nonce (uint64 big endian)
+func localTxKey(address common.Address, nonce uint64) []byte {
+	buf := make([]byte, len(localTxPrefix)+common.AddressLength+8)
+	n := copy(buf, localTxPrefix)
+	n += copy(buf[n:], address.Bytes())
+	binary.BigEndian.PutUint64(buf[n:], nonce)
+	return buf
+}
+
 // accountTrieNodeKey = trieNodeAccountPrefix + nodePath.
 func accountTrieNodeKey(path []byte) []byte {
 	return append(trieNodeAccountPrefix, path...)
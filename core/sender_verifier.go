@@ -0,0 +1,205 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/lru"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/metrics"
+	"github.com/theQRL/go-zond/params"
+)
+
+// senderVerifierCacheLimit is the number of signature verification results
+// retained by the sender verifier.
+const senderVerifierCacheLimit = 32768
+
+var (
+	senderVerifyTimer     = metrics.NewRegisteredTimer("chain/sigverify/time", nil)
+	senderVerifyHitMeter  = metrics.NewRegisteredMeter("chain/sigverify/cache/hit", nil)
+	senderVerifyMissMeter = metrics.NewRegisteredMeter("chain/sigverify/cache/miss", nil)
+)
+
+// SenderVerifier is a concurrent transaction signature verifier, shared by the
+// transaction pool and the block import so that a transaction already checked
+// on admission doesn't need to be verified again when it is included in a block.
+var SenderVerifier = newTxSenderVerifier(runtime.NumCPU(), senderVerifierCacheLimit)
+
+// SenderVerification is the pending result of verifying the signatures of a
+// batch of transactions.
+type SenderVerification struct {
+	txs  []*types.Transaction
+	errs []error
+	done sync.WaitGroup
+}
+
+// Wait blocks until the signatures of all transactions in the batch have been
+// verified and returns the failure of the first invalid one, if any.
+func (v *SenderVerification) Wait() error {
+	v.done.Wait()
+	for i, err := range v.errs {
+		if err != nil {
+			return fmt.Errorf("invalid transaction %d [%v]: %w", i, v.txs[i].Hash().Hex(), err)
+		}
+	}
+	return nil
+}
+
+// txSenderVerifierRequest is a request for verifying the signatures of a batch
+// of transactions with a specific signature scheme.
+//
+// The inc field defines the number of transactions to skip after each check,
+// which is used to feed the same underlying input array to different threads,
+// each one writing the results of its own transactions only.
+type txSenderVerifierRequest struct {
+	signer types.Signer
+	result *SenderVerification
+	start  int
+	inc    int
+}
+
+// txSenderVerifier is a helper structure to concurrently verify transaction
+// signatures on a bounded number of background threads, caching the outcome
+// by transaction hash.
+type txSenderVerifier struct {
+	threads int
+	tasks   chan *txSenderVerifierRequest
+	cache   *lru.Cache[common.Hash, bool]
+}
+
+// newTxSenderVerifier creates a new transaction signature verifier and starts
+// the given number of processing goroutines on construction.
+func newTxSenderVerifier(threads int, cacheLimit int) *txSenderVerifier {
+	verifier := &txSenderVerifier{
+		threads: threads,
+		tasks:   make(chan *txSenderVerifierRequest, threads),
+		cache:   lru.NewCache[common.Hash, bool](cacheLimit),
+	}
+	for i := 0; i < threads; i++ {
+		go verifier.loop()
+	}
+	return verifier
+}
+
+// loop is an infinite loop, verifying the signatures of scheduled batches.
+func (verifier *txSenderVerifier) loop() {
+	for task := range verifier.tasks {
+		txs := task.result.txs
+		for i := task.start; i < len(txs); i += task.inc {
+			task.result.errs[i] = verifier.Verify(task.signer, txs[i])
+		}
+		task.result.done.Done()
+	}
+}
+
+// Verify derives the sender of a transaction and checks its signature, reusing
+// the cached outcome if the transaction was already verified before. The sender
+// is cached in the transaction itself.
+//
+// Registered key transactions carry no public key, their signature can only be
+// checked against the key registry in the state they are executed on. Only the
+// sender is derived for them and nothing is cached, leaving the verification to
+// the state transition.
+func (verifier *txSenderVerifier) Verify(signer types.Signer, tx *types.Transaction) error {
+	if _, err := types.Sender(signer, tx); err != nil {
+		return err
+	}
+	if tx.Type() == types.RegisteredKeyTxType {
+		return nil
+	}
+	hash := tx.Hash()
+	if valid, ok := verifier.cache.Get(hash); ok {
+		senderVerifyHitMeter.Mark(1)
+		if !valid {
+			return types.ErrInvalidSig
+		}
+		return nil
+	}
+	senderVerifyMissMeter.Mark(1)
+
+	start := time.Now()
+	err := types.VerifySignature(signer, tx)
+	senderVerifyTimer.UpdateSince(start)
+
+	verifier.cache.Add(hash, err == nil)
+	return err
+}
+
+// VerifyBatch schedules the signature verification of a batch of transactions
+// on the background threads and returns the pending result.
+func (verifier *txSenderVerifier) VerifyBatch(signer types.Signer, txs []*types.Transaction) *SenderVerification {
+	result := verifier.newVerification(txs)
+	verifier.schedule(signer, result)
+	return result
+}
+
+// VerifyFromBlocks schedules the signature verification of the transactions of
+// a batch of blocks and returns a pending result for each block. The requests
+// are fed to the background threads in block order from a separate goroutine,
+// so the caller can start processing the first blocks while the verification
+// of later ones is still underway.
+func (verifier *txSenderVerifier) VerifyFromBlocks(config *params.ChainConfig, blocks []*types.Block) []*SenderVerification {
+	results := make([]*SenderVerification, len(blocks))
+	for i, block := range blocks {
+		results[i] = verifier.newVerification(block.Transactions())
+	}
+	go func() {
+		for i, block := range blocks {
+			verifier.schedule(types.MakeSigner(config, block.Number(), block.Time()), results[i])
+		}
+	}()
+	return results
+}
+
+// newVerification creates an unscheduled verification result for a batch of
+// transactions, sized to the number of tasks it will be split into.
+func (verifier *txSenderVerifier) newVerification(txs []*types.Transaction) *SenderVerification {
+	result := &SenderVerification{
+		txs:  txs,
+		errs: make([]error, len(txs)),
+	}
+	result.done.Add(verifier.taskCount(len(txs)))
+	return result
+}
+
+// taskCount returns the number of tasks a batch of the given size is split into,
+// ensuring each of them is meaningfully sized.
+func (verifier *txSenderVerifier) taskCount(count int) int {
+	tasks := verifier.threads
+	if count < tasks*4 {
+		tasks = (count + 3) / 4
+	}
+	return tasks
+}
+
+// schedule feeds the verification tasks of a batch to the background threads.
+func (verifier *txSenderVerifier) schedule(signer types.Signer, result *SenderVerification) {
+	tasks := verifier.taskCount(len(result.txs))
+	for i := 0; i < tasks; i++ {
+		verifier.tasks <- &txSenderVerifierRequest{
+			signer: signer,
+			result: result,
+			start:  i,
+			inc:    tasks,
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
+)
+
+// forgeTransaction returns a copy of the given transaction with a different
+// nonce, but carrying the original signature.
+func forgeTransaction(tx *types.Transaction, nonce uint64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   tx.ChainId(),
+		Nonce:     nonce,
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		Gas:       tx.Gas(),
+		To:        tx.To(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+		PublicKey: tx.RawPublicKeyValue(),
+		Signature: tx.RawSignatureValue(),
+	})
+}
+
+func TestSenderVerifier(t *testing.T) {
+	var (
+		key, _   = pqcrypto.GenerateDilithiumKey()
+		signer   = types.LatestSigner(params.TestChainConfig)
+		verifier = newTxSenderVerifier(2, 16)
+		txs      = make([]*types.Transaction, 10)
+	)
+	for i := range txs {
+		txs[i] = types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     uint64(i),
+			GasTipCap: big.NewInt(0),
+			GasFeeCap: big.NewInt(params.InitialBaseFee),
+			Gas:       params.TxGas,
+			To:        &common.Address{},
+			Value:     big.NewInt(0),
+		})
+	}
+	if err := verifier.VerifyBatch(signer, txs).Wait(); err != nil {
+		t.Fatalf("failed to verify valid batch: %v", err)
+	}
+	if have, want := verifier.cache.Len(), len(txs); have != want {
+		t.Fatalf("cached results mismatch: have %d, want %d", have, want)
+	}
+	// Replace a transaction in the batch with a forged one
+	txs[7] = forgeTransaction(txs[6], 7)
+	if err := verifier.VerifyBatch(signer, txs).Wait(); !errors.Is(err, types.ErrInvalidSig) {
+		t.Fatalf("error mismatch: have %v, want %v", err, types.ErrInvalidSig)
+	}
+	// Invalid results are cached too
+	if valid, ok := verifier.cache.Get(txs[7].Hash()); !ok || valid {
+		t.Fatalf("invalid signature not cached: ok %v, valid %v", ok, valid)
+	}
+	if err := verifier.Verify(signer, txs[7]); !errors.Is(err, types.ErrInvalidSig) {
+		t.Fatalf("error mismatch: have %v, want %v", err, types.ErrInvalidSig)
+	}
+	// Registered key transactions are verified against the state, their
+	// outcome must not be cached
+	registered := types.MustSignNewTx(key, signer, &types.RegisteredKeyTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     10,
+		GasTipCap: big.NewInt(0),
+		GasFeeCap: big.NewInt(params.InitialBaseFee),
+		Gas:       params.TxGas,
+		To:        &common.Address{},
+		Value:     big.NewInt(0),
+		From:      key.GetAddress(),
+	})
+	if err := verifier.Verify(signer, registered); err != nil {
+		t.Fatalf("failed to verify registered key transaction: %v", err)
+	}
+	if verifier.cache.Contains(registered.Hash()) {
+		t.Fatal("registered key transaction cached")
+	}
+}
+
+// Tests that blocks containing transactions with invalid signatures are
+// rejected on import.
+func TestInsertInvalidSignature(t *testing.T) {
+	var (
+		config = params.TestChainConfig
+		signer = types.LatestSigner(config)
+		key, _ = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = common.Address(key.GetAddress())
+		gspec  = &Genesis{
+			Config: config,
+			Alloc:  GenesisAlloc{addr: {Balance: big.NewInt(1000000000000000000)}},
+		}
+	)
+	tx := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   config.ChainID,
+		Nonce:     0,
+		GasTipCap: big.NewInt(0),
+		GasFeeCap: big.NewInt(params.InitialBaseFee),
+		Gas:       params.TxGas,
+		To:        &common.Address{},
+		Value:     big.NewInt(0),
+	})
+	_, blocks, _ := GenerateChainWithGenesis(gspec, beacon.NewFaker(), 1, func(i int, b *BlockGen) {
+		b.AddTx(tx)
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	block := GenerateBadBlock(blocks[0], beacon.NewFaker(), types.Transactions{forgeTransaction(tx, 1)}, config)
+	if _, err := chain.InsertChain(types.Blocks{block}); !errors.Is(err, types.ErrInvalidSig) {
+		t.Fatalf("error mismatch: have %v, want %v", err, types.ErrInvalidSig)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"errors"
+	"maps"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/trie"
+	"github.com/theQRL/go-zond/trie/triedb/pathdb"
+	"github.com/theQRL/go-zond/trie/trienode"
+	"github.com/theQRL/go-zond/zonddb"
+)
+
+// errHistoricTrie is returned if an operation that requires the trie nodes
+// is performed on a historic trie.
+var errHistoricTrie = errors.New("not supported by historic state")
+
+// historicDB is a state database serving the historical states which are no
+// longer maintained in the path-based trie database, by rebuilding them from
+// the indexed state histories.
+type historicDB struct {
+	*cachingDB
+}
+
+// NewHistoricDatabase creates a state database for accessing the historical
+// states reachable from the state histories of the given path-based trie
+// database. The returned states can be mutated for executing calls, but can
+// never be committed.
+func NewHistoricDatabase(db zonddb.Database, triedb *trie.Database) Database {
+	return &historicDB{cachingDB: NewDatabaseWithNodeDB(db, triedb).(*cachingDB)}
+}
+
+// OpenTrie opens the historic account trie at a specific root hash.
+func (db *historicDB) OpenTrie(root common.Hash) (Trie, error) {
+	reader, err := db.triedb.HistoricReader(root)
+	if err != nil {
+		return nil, err
+	}
+	return newHistoricTrie(root, reader), nil
+}
+
+// OpenStorageTrie opens the historic storage trie of an account.
+func (db *historicDB) OpenStorageTrie(stateRoot common.Hash, address common.Address, root common.Hash) (Trie, error) {
+	reader, err := db.triedb.HistoricReader(stateRoot)
+	if err != nil {
+		return nil, err
+	}
+	return newHistoricTrie(root, reader), nil
+}
+
+// CopyTrie returns an independent copy of the given trie.
+func (db *historicDB) CopyTrie(t Trie) Trie {
+	if t, ok := t.(*historicTrie); ok {
+		return t.copy()
+	}
+	return db.cachingDB.CopyTrie(t)
+}
+
+// historicTrie implements the Trie interface on top of a historical state
+// reader. The trie nodes are not available, so only the key-value accesses
+// are supported. Mutations are kept in memory and can't be committed.
+type historicTrie struct {
+	root     common.Hash
+	reader   *pathdb.HistoricalStateReader
+	accounts map[common.Address]*types.StateAccount    // Mutated accounts, nil means deleted
+	storages map[common.Address]map[common.Hash][]byte // Mutated slots keyed by slot hash, nil means deleted
+}
+
+// newHistoricTrie constructs the historic trie with the given state reader.
+func newHistoricTrie(root common.Hash, reader *pathdb.HistoricalStateReader) *historicTrie {
+	return &historicTrie{
+		root:     root,
+		reader:   reader,
+		accounts: make(map[common.Address]*types.StateAccount),
+		storages: make(map[common.Address]map[common.Hash][]byte),
+	}
+}
+
+// GetKey returns nil as the preimages are not tracked by historic state.
+func (t *historicTrie) GetKey([]byte) []byte {
+	return nil
+}
+
+// GetStorage returns the value for key stored in the historic state.
+func (t *historicTrie) GetStorage(addr common.Address, key []byte) ([]byte, error) {
+	slotHash := crypto.Keccak256Hash(key)
+	if slots, ok := t.storages[addr]; ok {
+		if value, ok := slots[slotHash]; ok {
+			return value, nil
+		}
+	}
+	return t.reader.Storage(addr, slotHash)
+}
+
+// GetAccount returns the account with the provided address in the historic
+// state, nil if the account is not present.
+func (t *historicTrie) GetAccount(address common.Address) (*types.StateAccount, error) {
+	if account, ok := t.accounts[address]; ok {
+		return account, nil
+	}
+	return t.reader.Account(address)
+}
+
+// UpdateStorage associates key with value in the in-memory mutation set.
+func (t *historicTrie) UpdateStorage(addr common.Address, key, value []byte) error {
+	slots, ok := t.storages[addr]
+	if !ok {
+		slots = make(map[common.Hash][]byte)
+		t.storages[addr] = slots
+	}
+	if len(value) == 0 {
+		value = nil
+	}
+	slots[crypto.Keccak256Hash(key)] = common.CopyBytes(value)
+	return nil
+}
+
+// UpdateAccount associates the account with address in the in-memory mutation
+// set.
+func (t *historicTrie) UpdateAccount(address common.Address, account *types.StateAccount) error {
+	t.accounts[address] = account.Copy()
+	return nil
+}
+
+// UpdateContractCode is a no-op, contract code is not tracked by the trie.
+func (t *historicTrie) UpdateContractCode(address common.Address, codeHash common.Hash, code []byte) error {
+	return nil
+}
+
+// DeleteStorage marks the slot as deleted in the in-memory mutation set.
+func (t *historicTrie) DeleteStorage(addr common.Address, key []byte) error {
+	return t.UpdateStorage(addr, key, nil)
+}
+
+// DeleteAccount marks the account as deleted in the in-memory mutation set.
+func (t *historicTrie) DeleteAccount(address common.Address) error {
+	t.accounts[address] = nil
+	return nil
+}
+
+// Hash returns the root hash of the historic state. The in-memory mutations
+// are not reflected.
+func (t *historicTrie) Hash() common.Hash {
+	return t.root
+}
+
+// Commit is not supported by historic trie, the historic state can't be
+// modified.
+func (t *historicTrie) Commit(collectLeaf bool) (common.Hash, *trienode.NodeSet, error) {
+	return common.Hash{}, nil, errHistoricTrie
+}
+
+// NodeIterator is not supported by historic trie, the trie nodes of historic
+// state are not available.
+func (t *historicTrie) NodeIterator(startKey []byte) (trie.NodeIterator, error) {
+	return nil, errHistoricTrie
+}
+
+// Prove is not supported by historic trie, the trie nodes of historic state
+// are not available.
+func (t *historicTrie) Prove(key []byte, proofDb zonddb.KeyValueWriter) error {
+	return errHistoricTrie
+}
+
+// copy returns an independent copy of the historic trie.
+func (t *historicTrie) copy() *historicTrie {
+	cpy := &historicTrie{
+		root:     t.root,
+		reader:   t.reader,
+		accounts: make(map[common.Address]*types.StateAccount, len(t.accounts)),
+		storages: make(map[common.Address]map[common.Hash][]byte, len(t.storages)),
+	}
+	for addr, account := range t.accounts {
+		if account != nil {
+			account = account.Copy()
+		}
+		cpy.accounts[addr] = account
+	}
+	for addr, slots := range t.storages {
+		cpy.storages[addr] = maps.Clone(slots)
+	}
+	return cpy
+}
@@ -0,0 +1,559 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/rlp"
+	"github.com/theQRL/go-zond/trie"
+	"github.com/theQRL/go-zond/zonddb"
+)
+
+// Chunk kinds of a snapshot archive. The chain related chunks precede the state
+// ones, which are terminated by an end chunk.
+const (
+	ArchiveMeta     byte = 0x00 // Archive metadata, always the first chunk
+	ArchiveHeaders  byte = 0x01 // Batch of consecutive canonical headers
+	ArchiveBlock    byte = 0x02 // Body and receipts of the block the state belongs to
+	ArchiveAccounts byte = 0x10 // Batch of accounts in ascending hash order
+	ArchiveStorage  byte = 0x11 // Batch of storage slots of a single account
+	ArchiveCode     byte = 0x12 // Batch of contract codes
+	ArchiveEnd      byte = 0xff // Summary of the exported state, always the last chunk
+)
+
+const (
+	// archiveChunkSize is the approximate size of the uncompressed payload of
+	// the archive chunks.
+	archiveChunkSize = 1024 * 1024
+
+	// archiveChunkLimit is the maximum size of a compressed chunk payload
+	// accepted when reading an archive.
+	archiveChunkLimit = 64 * 1024 * 1024
+)
+
+var (
+	// archiveMagic is the prefix identifying a snapshot archive.
+	archiveMagic = []byte("gzond-snapshot-v1\n")
+
+	// archiveTable is the checksum table of the archive chunks.
+	archiveTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// ArchiveMetadata is the metadata of a snapshot archive, identifying the chain
+// and the block whose state is contained.
+type ArchiveMetadata struct {
+	Genesis common.Hash // Hash of the genesis block of the chain
+	Number  uint64      // Number of the block the state belongs to
+	Hash    common.Hash // Hash of the block the state belongs to
+	Root    common.Hash // State root of the block
+}
+
+// archiveAccount is an account of the accounts chunk in slim format.
+type archiveAccount struct {
+	Hash    common.Hash
+	Account []byte
+}
+
+// archiveStorage is the payload of the storage chunk.
+type archiveStorage struct {
+	Account common.Hash
+	Slots   []archiveSlot
+}
+
+// archiveSlot is a storage slot of the storage chunk.
+type archiveSlot struct {
+	Hash  common.Hash
+	Value []byte
+}
+
+// archiveCode is a contract code of the code chunk.
+type archiveCode struct {
+	Hash common.Hash
+	Code []byte
+}
+
+// archiveEnd is the payload of the end chunk.
+type archiveEnd struct {
+	Accounts uint64
+	Slots    uint64
+	Codes    uint64
+}
+
+// ArchiveWriter writes the chunks of a snapshot archive. Each chunk consists of
+// a one byte kind, the four byte big endian length of the payload, the snappy
+// compressed RLP encoded payload and the CRC32-C checksum of all of them.
+type ArchiveWriter struct {
+	w *bufio.Writer
+}
+
+// NewArchiveWriter creates a snapshot archive writer on top of w.
+func NewArchiveWriter(w io.Writer) (*ArchiveWriter, error) {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(archiveMagic); err != nil {
+		return nil, err
+	}
+	return &ArchiveWriter{w: bw}, nil
+}
+
+// WriteChunk writes a chunk of the given kind with the RLP encoding of val as
+// its payload.
+func (w *ArchiveWriter) WriteChunk(kind byte, val interface{}) error {
+	blob, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return err
+	}
+	payload := snappy.Encode(nil, blob)
+
+	header := make([]byte, 5)
+	header[0] = kind
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+
+	checksum := crc32.Update(crc32.Checksum(header, archiveTable), archiveTable, payload)
+	for _, data := range [][]byte{header, payload, binary.BigEndian.AppendUint32(nil, checksum)} {
+		if _, err := w.w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (w *ArchiveWriter) Flush() error {
+	return w.w.Flush()
+}
+
+// ArchiveReader reads the chunks of a snapshot archive, verifying their
+// checksums.
+type ArchiveReader struct {
+	r *bufio.Reader
+}
+
+// NewArchiveReader creates a snapshot archive reader on top of r.
+func NewArchiveReader(r io.Reader) (*ArchiveReader, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(archiveMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(magic, archiveMagic) {
+		return nil, errors.New("not a snapshot archive")
+	}
+	return &ArchiveReader{r: br}, nil
+}
+
+// Next reads the next chunk and returns its kind and RLP encoded payload.
+func (r *ArchiveReader) Next() (byte, []byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		return 0, nil, err
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > archiveChunkLimit {
+		return 0, nil, fmt.Errorf("chunk too large: %d bytes", size)
+	}
+	payload := make([]byte, size+4)
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+	payload, checksum := payload[:size], binary.BigEndian.Uint32(payload[size:])
+	if crc32.Update(crc32.Checksum(header, archiveTable), archiveTable, payload) != checksum {
+		return 0, nil, fmt.Errorf("checksum mismatch in chunk %#x", header[0])
+	}
+	blob, err := snappy.Decode(nil, payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	return header[0], blob, nil
+}
+
+// ReadChunk reads the next chunk, which must be of the given kind, and decodes
+// its payload into val.
+func (r *ArchiveReader) ReadChunk(kind byte, val interface{}) error {
+	have, blob, err := r.Next()
+	if err != nil {
+		return err
+	}
+	if have != kind {
+		return fmt.Errorf("unexpected chunk: have %#x, want %#x", have, kind)
+	}
+	return rlp.DecodeBytes(blob, val)
+}
+
+// ExportState writes the state with the given root from the snapshot tree into
+// the archive, together with the contract codes read from the database. The
+// storage chunks of an account always precede the accounts chunk containing
+// it, allowing the state trie to be rebuilt while importing.
+func ExportState(w *ArchiveWriter, snaptree *Tree, root common.Hash, db zonddb.KeyValueReader) error {
+	accIt, err := snaptree.AccountIterator(root, common.Hash{})
+	if err != nil {
+		return err
+	}
+	defer accIt.Release()
+
+	var (
+		summary  archiveEnd
+		accounts []archiveAccount
+		codes    []archiveCode
+		seen     = make(map[common.Hash]struct{})
+		accSize  int
+		codeSize int
+
+		start  = time.Now()
+		logged = time.Now()
+	)
+	for accIt.Next() {
+		hash, slim := accIt.Hash(), common.CopyBytes(accIt.Account())
+		account, err := types.FullAccount(slim)
+		if err != nil {
+			return err
+		}
+		// Export the storage of the account before the account itself
+		if account.Root != types.EmptyRootHash {
+			n, err := exportStorage(w, snaptree, root, hash)
+			if err != nil {
+				return err
+			}
+			summary.Slots += n
+		}
+		// Export the contract code, once for each distinct code
+		if codeHash := common.BytesToHash(account.CodeHash); codeHash != types.EmptyCodeHash {
+			if _, ok := seen[codeHash]; !ok {
+				code := rawdb.ReadCode(db, codeHash)
+				if len(code) == 0 {
+					return fmt.Errorf("missing code %x of account %x", codeHash, hash)
+				}
+				seen[codeHash] = struct{}{}
+				codes, codeSize = append(codes, archiveCode{Hash: codeHash, Code: code}), codeSize+len(code)
+				summary.Codes++
+
+				if codeSize >= archiveChunkSize {
+					if err := w.WriteChunk(ArchiveCode, codes); err != nil {
+						return err
+					}
+					codes, codeSize = codes[:0], 0
+				}
+			}
+		}
+		accounts, accSize = append(accounts, archiveAccount{Hash: hash, Account: slim}), accSize+common.HashLength+len(slim)
+		summary.Accounts++
+
+		if accSize >= archiveChunkSize {
+			if err := w.WriteChunk(ArchiveAccounts, accounts); err != nil {
+				return err
+			}
+			accounts, accSize = accounts[:0], 0
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Exporting state", "at", hash, "accounts", summary.Accounts, "slots", summary.Slots, "codes", summary.Codes, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := accIt.Error(); err != nil {
+		return err
+	}
+	if len(accounts) > 0 {
+		if err := w.WriteChunk(ArchiveAccounts, accounts); err != nil {
+			return err
+		}
+	}
+	if len(codes) > 0 {
+		if err := w.WriteChunk(ArchiveCode, codes); err != nil {
+			return err
+		}
+	}
+	log.Info("Exported state", "root", root, "accounts", summary.Accounts, "slots", summary.Slots, "codes", summary.Codes, "elapsed", common.PrettyDuration(time.Since(start)))
+	return w.WriteChunk(ArchiveEnd, summary)
+}
+
+// exportStorage writes the storage of the given account into the archive and
+// returns the number of slots written.
+func exportStorage(w *ArchiveWriter, snaptree *Tree, root common.Hash, account common.Hash) (uint64, error) {
+	stIt, err := snaptree.StorageIterator(root, account, common.Hash{})
+	if err != nil {
+		return 0, err
+	}
+	defer stIt.Release()
+
+	var (
+		chunk = archiveStorage{Account: account}
+		size  int
+		count uint64
+	)
+	for stIt.Next() {
+		value := common.CopyBytes(stIt.Slot())
+		chunk.Slots, size = append(chunk.Slots, archiveSlot{Hash: stIt.Hash(), Value: value}), size+common.HashLength+len(value)
+		count++
+
+		if size >= archiveChunkSize {
+			if err := w.WriteChunk(ArchiveStorage, &chunk); err != nil {
+				return 0, err
+			}
+			chunk.Slots, size = chunk.Slots[:0], 0
+		}
+	}
+	if err := stIt.Error(); err != nil {
+		return 0, err
+	}
+	if len(chunk.Slots) > 0 {
+		if err := w.WriteChunk(ArchiveStorage, &chunk); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// ImportState reads the state chunks from the archive up to and including the
+// end chunk, and writes the flat snapshot, the contract codes and the state
+// trie rebuilt from them into the database with the given state scheme. Any
+// previous state snapshot, and with the path scheme any previous trie node,
+// is wiped first. An error is returned if the rebuilt state doesn't match the
+// given root.
+func ImportState(r *ArchiveReader, db zonddb.Database, scheme string, root common.Hash) error {
+	if err := wipeState(db, scheme); err != nil {
+		return err
+	}
+	var (
+		batch = db.NewBatch()
+		write = func(owner common.Hash, path []byte, hash common.Hash, blob []byte) {
+			rawdb.WriteTrieNode(batch, owner, path, hash, blob, scheme)
+		}
+		flush = func(force bool) error {
+			if !force && batch.ValueSize() < zonddb.IdealBatchSize {
+				return nil
+			}
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+			return nil
+		}
+		accTrie = trie.NewStackTrie(write)
+		summary archiveEnd
+
+		lastAccount common.Hash                         // Last imported account, zero before the first one
+		storage     *trie.StackTrie                     // Storage trie of the account being imported
+		storageAcc  common.Hash                         // Account of the storage trie being imported
+		lastSlot    common.Hash                         // Last imported slot of the storage being imported
+		roots       = make(map[common.Hash]common.Hash) // Storage roots of the accounts not yet imported
+		codes       = make(map[common.Hash]bool)        // Imported codes (true) and the ones required (false)
+
+		start  = time.Now()
+		logged = time.Now()
+	)
+	// commitStorage finalizes the storage trie being imported.
+	commitStorage := func() error {
+		if storage == nil {
+			return nil
+		}
+		hash, err := storage.Commit()
+		if err != nil {
+			return err
+		}
+		roots[storageAcc], storage = hash, nil
+		return nil
+	}
+	for {
+		kind, blob, err := r.Next()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		switch kind {
+		case ArchiveStorage:
+			var chunk archiveStorage
+			if err := rlp.DecodeBytes(blob, &chunk); err != nil {
+				return err
+			}
+			if storage == nil || chunk.Account != storageAcc {
+				if err := commitStorage(); err != nil {
+					return err
+				}
+				if _, ok := roots[chunk.Account]; ok || (summary.Accounts > 0 && chunk.Account.Cmp(lastAccount) <= 0) {
+					return fmt.Errorf("storage of account %x out of order", chunk.Account)
+				}
+				storage, storageAcc, lastSlot = trie.NewStackTrieWithOwner(write, chunk.Account), chunk.Account, common.Hash{}
+			}
+			for _, slot := range chunk.Slots {
+				if len(slot.Value) == 0 || (lastSlot != (common.Hash{}) && slot.Hash.Cmp(lastSlot) <= 0) {
+					return fmt.Errorf("invalid storage slot %x of account %x", slot.Hash, chunk.Account)
+				}
+				rawdb.WriteStorageSnapshot(batch, chunk.Account, slot.Hash, slot.Value)
+				storage.MustUpdate(slot.Hash[:], slot.Value)
+				lastSlot = slot.Hash
+				summary.Slots++
+			}
+
+		case ArchiveAccounts:
+			var chunk []archiveAccount
+			if err := rlp.DecodeBytes(blob, &chunk); err != nil {
+				return err
+			}
+			if err := commitStorage(); err != nil {
+				return err
+			}
+			for _, entry := range chunk {
+				if summary.Accounts > 0 && entry.Hash.Cmp(lastAccount) <= 0 {
+					return fmt.Errorf("account %x out of order", entry.Hash)
+				}
+				account, err := types.FullAccount(entry.Account)
+				if err != nil {
+					return err
+				}
+				want, ok := roots[entry.Hash]
+				if !ok {
+					want = types.EmptyRootHash
+				}
+				delete(roots, entry.Hash)
+				if account.Root != want {
+					return fmt.Errorf("storage root mismatch of account %x: have %x, want %x", entry.Hash, want, account.Root)
+				}
+				if codeHash := common.BytesToHash(account.CodeHash); codeHash != types.EmptyCodeHash && !codes[codeHash] {
+					codes[codeHash] = false
+				}
+				full, err := rlp.EncodeToBytes(account)
+				if err != nil {
+					return err
+				}
+				rawdb.WriteAccountSnapshot(batch, entry.Hash, entry.Account)
+				accTrie.MustUpdate(entry.Hash[:], full)
+				lastAccount = entry.Hash
+				summary.Accounts++
+			}
+
+		case ArchiveCode:
+			var chunk []archiveCode
+			if err := rlp.DecodeBytes(blob, &chunk); err != nil {
+				return err
+			}
+			for _, code := range chunk {
+				if hash := crypto.Keccak256Hash(code.Code); hash != code.Hash {
+					return fmt.Errorf("code hash mismatch: have %x, want %x", hash, code.Hash)
+				}
+				rawdb.WriteCode(batch, code.Hash, code.Code)
+				codes[code.Hash] = true
+				summary.Codes++
+			}
+
+		case ArchiveEnd:
+			var want archiveEnd
+			if err := rlp.DecodeBytes(blob, &want); err != nil {
+				return err
+			}
+			if len(roots) > 0 || storage != nil {
+				return errors.New("storage without account")
+			}
+			for hash, ok := range codes {
+				if !ok {
+					return fmt.Errorf("missing code %x", hash)
+				}
+			}
+			if summary != want {
+				return fmt.Errorf("state summary mismatch: have %+v, want %+v", summary, want)
+			}
+			hash, err := accTrie.Commit()
+			if err != nil {
+				return err
+			}
+			if hash != root {
+				return fmt.Errorf("state root mismatch: have %x, want %x", hash, root)
+			}
+			// Mark the imported snapshot as complete, discarding any journal
+			// of the previous state.
+			rawdb.WriteSnapshotRoot(batch, root)
+			rawdb.DeleteSnapshotJournal(batch)
+			rawdb.DeleteTrieJournal(batch)
+			journalProgress(batch, nil, nil)
+			if err := flush(true); err != nil {
+				return err
+			}
+			log.Info("Imported state", "root", root, "accounts", summary.Accounts, "slots", summary.Slots, "codes", summary.Codes, "elapsed", common.PrettyDuration(time.Since(start)))
+			return nil
+
+		default:
+			return fmt.Errorf("unexpected chunk %#x", kind)
+		}
+		if err := flush(false); err != nil {
+			return err
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Importing state", "at", lastAccount, "accounts", summary.Accounts, "slots", summary.Slots, "codes", summary.Codes, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+}
+
+// wipeState deletes the flat state snapshot and, with the path scheme, the trie
+// nodes from the database.
+func wipeState(db zonddb.Database, scheme string) error {
+	wipe := func(prefix []byte, match func(key []byte) bool) error {
+		it := db.NewIterator(prefix, nil)
+		defer it.Release()
+
+		batch := db.NewBatch()
+		for it.Next() {
+			if !match(it.Key()) {
+				continue
+			}
+			batch.Delete(it.Key())
+			if batch.ValueSize() >= zonddb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		if err := it.Error(); err != nil {
+			return err
+		}
+		return batch.Write()
+	}
+	if err := wipe(rawdb.SnapshotAccountPrefix, func(key []byte) bool {
+		return len(key) == len(rawdb.SnapshotAccountPrefix)+common.HashLength
+	}); err != nil {
+		return err
+	}
+	if err := wipe(rawdb.SnapshotStoragePrefix, func(key []byte) bool {
+		return len(key) == len(rawdb.SnapshotStoragePrefix)+2*common.HashLength
+	}); err != nil {
+		return err
+	}
+	if scheme == rawdb.PathScheme {
+		return wipe(nil, func(key []byte) bool {
+			return rawdb.IsAccountTrieNode(key) || rawdb.IsStorageTrieNode(key)
+		})
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+)
+
+// Tests that the archive chunks round trip through the writer and reader, and
+// that corrupted chunks are rejected.
+func TestArchiveChunks(t *testing.T) {
+	var (
+		buf  bytes.Buffer
+		meta = ArchiveMetadata{Genesis: common.Hash{0x01}, Number: 10, Hash: common.Hash{0x02}, Root: common.Hash{0x03}}
+		end  = archiveEnd{Accounts: 1, Slots: 2, Codes: 3}
+	)
+	w, err := NewArchiveWriter(&buf)
+	if err != nil {
+		t.Fatalf("failed to create writer: %v", err)
+	}
+	if err := w.WriteChunk(ArchiveMeta, &meta); err != nil {
+		t.Fatalf("failed to write metadata: %v", err)
+	}
+	if err := w.WriteChunk(ArchiveEnd, &end); err != nil {
+		t.Fatalf("failed to write end: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("failed to flush: %v", err)
+	}
+	blob := buf.Bytes()
+
+	r, err := NewArchiveReader(bytes.NewReader(blob))
+	if err != nil {
+		t.Fatalf("failed to create reader: %v", err)
+	}
+	var haveMeta ArchiveMetadata
+	if err := r.ReadChunk(ArchiveMeta, &haveMeta); err != nil {
+		t.Fatalf("failed to read metadata: %v", err)
+	}
+	if haveMeta != meta {
+		t.Fatalf("metadata mismatch: have %+v, want %+v", haveMeta, meta)
+	}
+	var haveEnd archiveEnd
+	if err := r.ReadChunk(ArchiveMeta, &haveEnd); err == nil {
+		t.Fatal("chunk of unexpected kind accepted")
+	}
+	// Corrupt the first byte of the payload of the metadata chunk
+	corrupt := common.CopyBytes(blob)
+	corrupt[len(archiveMagic)+5] ^= 0xff
+
+	r, err = NewArchiveReader(bytes.NewReader(corrupt))
+	if err != nil {
+		t.Fatalf("failed to create reader: %v", err)
+	}
+	if err := r.ReadChunk(ArchiveMeta, &haveMeta); err == nil {
+		t.Fatal("corrupted chunk accepted")
+	}
+	if _, err := NewArchiveReader(bytes.NewReader(blob[1:])); err == nil {
+		t.Fatal("archive without magic accepted")
+	}
+}
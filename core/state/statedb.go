@@ -18,8 +18,11 @@
 package state
 
 import (
+	"bytes"
 	"fmt"
+	"maps"
 	"math/big"
+	"slices"
 	"sort"
 	"time"
 
@@ -63,6 +66,7 @@ type StateDB struct {
 	prefetcher *triePrefetcher
 	trie       Trie
 	hasher     crypto.KeccakState
+	logger     StateLogger       // Nil if the state changes are not traced
 	snaps      *snapshot.Tree    // Nil if snapshot is not available
 	snap       snapshot.Snapshot // Nil if snapshot is not available
 
@@ -77,6 +81,10 @@ type StateDB struct {
 	accountsOrigin map[common.Address][]byte                 // The original value of mutated accounts in 'slim RLP' encoding
 	storagesOrigin map[common.Address]map[common.Hash][]byte // The original value of mutated slots in prefix-zero trimmed rlp format
 
+	// The diff of the last commit, only collected if requested.
+	trackDiff bool
+	diff      *types.StateDiff
+
 	// This map holds 'live' objects, which will get modified while processing
 	// a state transition.
 	stateObjects         map[common.Address]*stateObject
@@ -108,6 +116,9 @@ type StateDB struct {
 	// Per-transaction access list
 	accessList *accessList
 
+	// Transient storage
+	transientStorage transientStorage
+
 	// Journal of state modifications. This is the backbone of
 	// Snapshot and RevertToSnapshot.
 	journal        *journal
@@ -160,6 +171,7 @@ func New(root common.Hash, db Database, snaps *snapshot.Tree) (*StateDB, error)
 		preimages:            make(map[common.Hash][]byte),
 		journal:              newJournal(),
 		accessList:           newAccessList(),
+		transientStorage:     newTransientStorage(),
 		hasher:               crypto.NewKeccakState(),
 	}
 	if sdb.snaps != nil {
@@ -210,6 +222,10 @@ func (s *StateDB) AddLog(log *types.Log) {
 	log.Index = s.logSize
 	s.logs[s.thash] = append(s.logs[s.thash], log)
 	s.logSize++
+
+	if s.logger != nil {
+		s.logger.OnLog(log)
+	}
 }
 
 // GetLogs returns the logs matching the specified transaction hash, and annotates
@@ -709,6 +725,7 @@ func (s *StateDB) Copy() *StateDB {
 	// empty lists, so we do it anyway to not blow up if we ever decide copy them
 	// in the middle of a transaction.
 	state.accessList = s.accessList.Copy()
+	state.transientStorage = s.transientStorage.Copy()
 
 	// If there's a prefetcher running, make an inactive copy of it that can
 	// only access data but does not actively preload (since the user will not
@@ -862,6 +879,12 @@ func (s *StateDB) IntermediateRoot(deleteEmptyObjects bool) common.Hash {
 	return s.trie.Hash()
 }
 
+// SetLogger sets the logger notified of the state changes made through the
+// state database. The copies of the state database are not traced.
+func (s *StateDB) SetLogger(logger StateLogger) {
+	s.logger = logger
+}
+
 // SetTxContext sets the current transaction hash and index which are
 // used when the ZVM emits new state logs. It should be invoked before
 // transaction execution.
@@ -1219,6 +1242,9 @@ func (s *StateDB) Commit(block uint64, deleteEmptyObjects bool) (common.Hash, er
 			s.onCommit(set)
 		}
 	}
+	if s.trackDiff {
+		s.diff = s.stateDiff()
+	}
 	// Clear all internal flags at the end of commit operation.
 	s.accounts = make(map[common.Hash][]byte)
 	s.storages = make(map[common.Hash]map[common.Hash][]byte)
@@ -1229,6 +1255,60 @@ func (s *StateDB) Commit(block uint64, deleteEmptyObjects bool) (common.Hash, er
 	return root, nil
 }
 
+// TrackStateDiff enables collecting the diff of the accounts and storage slots
+// mutated by each commit, retrievable with StateDiff after the commit.
+func (s *StateDB) TrackStateDiff() {
+	s.trackDiff = true
+}
+
+// StateDiff returns the diff of the last commit, nil if the diff tracking is
+// not enabled or nothing was committed yet.
+func (s *StateDB) StateDiff() *types.StateDiff {
+	return s.diff
+}
+
+// stateDiff assembles the diff of the state mutated since the last commit from
+// the original and the current values of the mutated accounts and slots.
+func (s *StateDB) stateDiff() *types.StateDiff {
+	origins := maps.Clone(s.accountsOrigin)
+
+	// The deleted accounts are not tracked in the original values in hash mode,
+	// resolve them from the destructed ones.
+	for addr, prev := range s.stateObjectsDestruct {
+		if _, ok := origins[addr]; !ok && prev != nil {
+			origins[addr] = types.SlimAccountRLP(*prev)
+		}
+	}
+	diff := &types.StateDiff{Accounts: make([]*types.AccountDiff, 0, len(origins))}
+	for addr, prev := range origins {
+		addrHash := crypto.HashData(s.hasher, addr.Bytes())
+		account := &types.AccountDiff{
+			Address: addr,
+			Prev:    prev,
+			Post:    s.accounts[addrHash],
+		}
+		for hash, prev := range s.storagesOrigin[addr] {
+			post := s.storages[addrHash][hash]
+			if bytes.Equal(prev, post) {
+				continue
+			}
+			account.Storage = append(account.Storage, &types.StorageDiff{Hash: hash, Prev: prev, Post: post})
+		}
+		// Skip the accounts created and deleted within the same block
+		if bytes.Equal(account.Prev, account.Post) && len(account.Storage) == 0 {
+			continue
+		}
+		slices.SortFunc(account.Storage, func(a, b *types.StorageDiff) int {
+			return a.Hash.Cmp(b.Hash)
+		})
+		diff.Accounts = append(diff.Accounts, account)
+	}
+	slices.SortFunc(diff.Accounts, func(a, b *types.AccountDiff) int {
+		return a.Address.Cmp(b.Address)
+	})
+	return diff
+}
+
 // Prepare handles the preparatory steps for executing a state transition with.
 // This method must be invoked before state transition.
 //
@@ -1241,6 +1321,7 @@ func (s *StateDB) Commit(block uint64, deleteEmptyObjects bool) (common.Hash, er
 // Potential EIPs:
 // - Reset access list (Berlin)
 // - Add coinbase to access list (EIP-3651)
+// - Reset transient storage (EIP-1153)
 func (s *StateDB) Prepare(rules params.Rules, sender, coinbase common.Address, dst *common.Address, precompiles []common.Address, list types.AccessList) {
 	// Clear out any leftover from previous executions
 	al := newAccessList()
@@ -1261,6 +1342,36 @@ func (s *StateDB) Prepare(rules params.Rules, sender, coinbase common.Address, d
 		}
 	}
 	al.AddAddress(coinbase)
+
+	// Reset transient storage at the beginning of transaction execution
+	s.transientStorage = newTransientStorage()
+}
+
+// SetTransientState sets transient storage for a given account. It
+// adds the change to the journal so that it can be rolled back
+// to its previous value if there is a revert.
+func (s *StateDB) SetTransientState(addr common.Address, key, value common.Hash) {
+	prev := s.GetTransientState(addr, key)
+	if prev == value {
+		return
+	}
+	s.journal.append(transientStorageChange{
+		account:  &addr,
+		key:      key,
+		prevalue: prev,
+	})
+	s.setTransientState(addr, key, value)
+}
+
+// setTransientState is a lower level setter for transient storage. It
+// is called during a revert to prevent modifications to the journal.
+func (s *StateDB) setTransientState(addr common.Address, key, value common.Hash) {
+	s.transientStorage.Set(addr, key, value)
+}
+
+// GetTransientState gets transient storage for a given account.
+func (s *StateDB) GetTransientState(addr common.Address, key common.Hash) common.Hash {
+	return s.transientStorage.Get(addr, key)
 }
 
 // AddAddressToAccessList adds the given address to the access list
@@ -36,6 +36,7 @@ import (
 	"github.com/theQRL/go-zond/core/state/snapshot"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/rlp"
 	"github.com/theQRL/go-zond/trie"
 	"github.com/theQRL/go-zond/trie/triedb/hashdb"
@@ -350,6 +351,16 @@ func newTestAction(addr common.Address, r *rand.Rand) testAction {
 			},
 			args: make([]int64, 1),
 		},
+		{
+			name: "SetTransientState",
+			fn: func(a testAction, s *StateDB) {
+				var key, val common.Hash
+				binary.BigEndian.PutUint16(key[:], uint16(a.args[0]))
+				binary.BigEndian.PutUint16(val[:], uint16(a.args[1]))
+				s.SetTransientState(addr, key, val)
+			},
+			args: make([]int64, 2),
+		},
 	}
 	action := actions[r.Intn(len(actions))]
 	var nameargs []string
@@ -498,6 +509,13 @@ func (test *snapshotTest) checkEqual(state, checkstate *StateDB) error {
 				return checkeq("GetState("+key.Hex()+")", checkstate.GetState(addr, key), value)
 			})
 		}
+		// Check transient storage.
+		for key, value := range state.transientStorage[addr] {
+			checkeq("GetTransientState("+key.Hex()+")", checkstate.GetTransientState(addr, key), value)
+		}
+		for key, value := range checkstate.transientStorage[addr] {
+			checkeq("GetTransientState("+key.Hex()+")", state.GetTransientState(addr, key), value)
+		}
 		if err != nil {
 			return err
 		}
@@ -1106,3 +1124,144 @@ func TestDeleteStorage(t *testing.T) {
 		t.Fatalf("difference found:\nfast: %v\nslow: %v\n", fastRes, slowRes)
 	}
 }
+
+func TestTransientStorage(t *testing.T) {
+	state, _ := New(types.EmptyRootHash, NewDatabase(rawdb.NewMemoryDatabase()), nil)
+
+	key := common.Hash{0x01}
+	value := common.Hash{0x02}
+	addr := common.Address{}
+
+	state.SetTransientState(addr, key, value)
+	if exp, got := 1, state.journal.length(); exp != got {
+		t.Fatalf("journal length mismatch: have %d, want %d", got, exp)
+	}
+	// the retrieved value should equal what was set
+	if got := state.GetTransientState(addr, key); got != value {
+		t.Fatalf("transient storage mismatch: have %x, want %x", got, value)
+	}
+
+	// revert the transient state being set and then check that the
+	// value is now the empty hash
+	state.journal.revert(state, 0)
+	if got, exp := state.GetTransientState(addr, key), (common.Hash{}); exp != got {
+		t.Fatalf("transient storage mismatch: have %x, want %x", got, exp)
+	}
+
+	// set transient state and then copy the statedb and ensure that
+	// the transient state is copied
+	state.SetTransientState(addr, key, value)
+	cpy := state.Copy()
+	if got := cpy.GetTransientState(addr, key); got != value {
+		t.Fatalf("transient storage mismatch: have %x, want %x", got, value)
+	}
+
+	// preparing the next transaction should discard the transient state
+	state.Prepare(params.Rules{}, addr, addr, nil, nil, nil)
+	if got, exp := state.GetTransientState(addr, key), (common.Hash{}); exp != got {
+		t.Fatalf("transient storage not reset: have %x, want %x", got, exp)
+	}
+	if got := cpy.GetTransientState(addr, key); got != value {
+		t.Fatalf("copied transient storage modified: have %x, want %x", got, value)
+	}
+}
+
+// Tests that the diff of the mutated accounts and storage slots is collected
+// on commit if requested.
+func TestStateDiff(t *testing.T) {
+	testStateDiff(t, rawdb.HashScheme)
+	testStateDiff(t, rawdb.PathScheme)
+}
+
+func testStateDiff(t *testing.T, scheme string) {
+	config := &trie.Config{HashDB: hashdb.Defaults}
+	if scheme == rawdb.PathScheme {
+		config = &trie.Config{PathDB: pathdb.Defaults}
+	}
+	memDb := rawdb.NewMemoryDatabase()
+
+	var (
+		db     = NewDatabaseWithNodeDB(memDb, trie.NewDatabase(memDb, config))
+		addrA  = common.Address{0x01}
+		addrB  = common.Address{0x02}
+		addrC  = common.Address{0x03}
+		slot1  = common.Hash{0x01}
+		slot2  = common.Hash{0x02}
+		value1 = common.Hash{0x11}
+		value2 = common.Hash{0x22}
+	)
+	state, _ := New(types.EmptyRootHash, db, nil)
+	state.TrackStateDiff()
+
+	state.AddBalance(addrA, big.NewInt(1))
+	state.SetState(addrA, slot1, value1)
+	state.AddBalance(addrB, big.NewInt(2))
+	state.AddBalance(addrC, big.NewInt(3)) // Created and deleted in the same block
+	state.SubBalance(addrC, big.NewInt(3))
+
+	root, err := state.Commit(1, true)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	diff := state.StateDiff()
+	if len(diff.Accounts) != 2 {
+		t.Fatalf("account diff count mismatch: have %d, want 2", len(diff.Accounts))
+	}
+	if diff.Accounts[0].Address != addrA || diff.Accounts[1].Address != addrB {
+		t.Fatalf("account diff mismatch: have %x, %x", diff.Accounts[0].Address, diff.Accounts[1].Address)
+	}
+	if len(diff.Accounts[0].Prev) != 0 || len(diff.Accounts[0].Storage) != 1 || len(diff.Accounts[1].Storage) != 0 {
+		t.Fatalf("unexpected account diff: %+v, %+v", diff.Accounts[0], diff.Accounts[1])
+	}
+	if slot := diff.Accounts[0].Storage[0]; slot.Hash != crypto.Keccak256Hash(slot1.Bytes()) || len(slot.Prev) != 0 {
+		t.Fatalf("unexpected storage diff: %+v", slot)
+	}
+
+	// Mutate the committed state and check the values before and after
+	state, _ = New(root, db, nil)
+	state.TrackStateDiff()
+
+	state.SetState(addrA, slot1, common.Hash{})
+	state.SetState(addrA, slot2, value2)
+	state.SubBalance(addrB, big.NewInt(2))
+
+	if _, err := state.Commit(2, true); err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	diff = state.StateDiff()
+	if len(diff.Accounts) != 2 {
+		t.Fatalf("account diff count mismatch: have %d, want 2", len(diff.Accounts))
+	}
+	a, b := diff.Accounts[0], diff.Accounts[1]
+	prev, err := types.FullAccount(a.Prev)
+	if err != nil {
+		t.Fatalf("failed to decode account: %v", err)
+	}
+	post, err := types.FullAccount(a.Post)
+	if err != nil {
+		t.Fatalf("failed to decode account: %v", err)
+	}
+	if prev.Balance.Cmp(big.NewInt(1)) != 0 || prev.Root == post.Root {
+		t.Fatalf("unexpected account diff: prev %+v, post %+v", prev, post)
+	}
+	if len(a.Storage) != 2 {
+		t.Fatalf("storage diff count mismatch: have %d, want 2", len(a.Storage))
+	}
+	for _, slot := range a.Storage {
+		switch slot.Hash {
+		case crypto.Keccak256Hash(slot1.Bytes()):
+			if len(slot.Prev) == 0 || len(slot.Post) != 0 {
+				t.Fatalf("unexpected deleted slot diff: %+v", slot)
+			}
+		case crypto.Keccak256Hash(slot2.Bytes()):
+			if len(slot.Prev) != 0 || len(slot.Post) == 0 {
+				t.Fatalf("unexpected created slot diff: %+v", slot)
+			}
+		default:
+			t.Fatalf("unexpected slot diff: %x", slot.Hash)
+		}
+	}
+	if b.Address != addrB || len(b.Prev) == 0 || len(b.Post) != 0 {
+		t.Fatalf("unexpected deleted account diff: %+v", b)
+	}
+}
@@ -68,8 +68,16 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	var (
 		context = NewZVMBlockContext(header, p.bc, nil)
 		vmenv   = vm.NewZVM(context, vm.TxContext{}, statedb, p.config, cfg)
-		signer  = types.MakeSigner(p.config)
+		signer  = types.MakeSigner(p.config, header.Number, header.Time)
 	)
+	logger, _ := cfg.Tracer.(BlockchainLogger)
+	InstallSystemContracts(p.config, p.bc.GetHeader(block.ParentHash(), block.NumberU64()-1), header, statedb)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
+	if p.config.IsPrague(block.Number(), block.Time()) {
+		ProcessParentBlockHash(block.ParentHash(), vmenv, statedb)
+	}
 
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
@@ -78,7 +86,13 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 		statedb.SetTxContext(tx.Hash(), i)
+		if logger != nil {
+			logger.OnTxStart(tx, msg.From)
+		}
 		receipt, err := applyTransaction(msg, gp, statedb, blockNumber, blockHash, tx, usedGas, vmenv)
+		if logger != nil {
+			logger.OnTxEnd(receipt, err)
+		}
 		if err != nil {
 			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
@@ -108,9 +122,14 @@ func applyTransaction(msg *Message, gp *GasPool, statedb *state.StateDB, blockNu
 	statedb.Finalise(true)
 	*usedGas += result.UsedGas
 
+	return MakeReceipt(zvm, result, statedb, blockNumber, blockHash, tx, *usedGas, root), nil
+}
+
+// MakeReceipt generates the receipt object for a transaction given its execution result.
+func MakeReceipt(zvm *vm.ZVM, result *ExecutionResult, statedb *state.StateDB, blockNumber *big.Int, blockHash common.Hash, tx *types.Transaction, usedGas uint64, root []byte) *types.Receipt {
 	// Create a new receipt for the transaction, storing the intermediate root and gas used
 	// by the tx.
-	receipt := &types.Receipt{Type: tx.Type(), PostState: root, CumulativeGasUsed: *usedGas}
+	receipt := &types.Receipt{Type: tx.Type(), PostState: root, CumulativeGasUsed: usedGas}
 	if result.Failed() {
 		receipt.Status = types.ReceiptStatusFailed
 	} else {
@@ -119,8 +138,13 @@ func applyTransaction(msg *Message, gp *GasPool, statedb *state.StateDB, blockNu
 	receipt.TxHash = tx.Hash()
 	receipt.GasUsed = result.UsedGas
 
+	if tx.Type() == types.BlobTxType {
+		receipt.BlobGasUsed = tx.BlobGas()
+		receipt.BlobGasPrice = zvm.Context.BlobBaseFee
+	}
+
 	// If the transaction created a contract, store the creation address in the receipt.
-	if msg.To == nil {
+	if tx.To() == nil {
 		receipt.ContractAddress = crypto.CreateAddress(zvm.TxContext.Origin, tx.Nonce())
 	}
 
@@ -130,7 +154,7 @@ func applyTransaction(msg *Message, gp *GasPool, statedb *state.StateDB, blockNu
 	receipt.BlockHash = blockHash
 	receipt.BlockNumber = blockNumber
 	receipt.TransactionIndex = uint(statedb.TxIndex())
-	return receipt, err
+	return receipt
 }
 
 // ApplyTransaction attempts to apply a transaction to the given state database
@@ -138,7 +162,7 @@ func applyTransaction(msg *Message, gp *GasPool, statedb *state.StateDB, blockNu
 // for the transaction, gas used and an error if the transaction failed,
 // indicating the block was invalid.
 func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *common.Address, gp *GasPool, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, cfg vm.Config) (*types.Receipt, error) {
-	msg, err := TransactionToMessage(tx, types.MakeSigner(config), header.BaseFee)
+	msg, err := TransactionToMessage(tx, types.MakeSigner(config, header.Number, header.Time), header.BaseFee)
 	if err != nil {
 		return nil, err
 	}
@@ -147,3 +171,65 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 	vmenv := vm.NewZVM(blockContext, vm.TxContext{}, statedb, config, cfg)
 	return applyTransaction(msg, gp, statedb, header.Number, header.Hash(), tx, usedGas, vmenv)
 }
+
+// InstallSystemContracts deploys the system contracts of the forks activated by
+// the given block, in which the parent block is still on the preceding fork. The
+// system contracts of the forks active since the genesis are expected to be
+// allocated in the genesis instead.
+func InstallSystemContracts(config *params.ChainConfig, parent, header *types.Header, statedb *state.StateDB) {
+	if config.IsCancun(header.Number, header.Time) && !config.IsCancun(parent.Number, parent.Time) {
+		installSystemContract(statedb, params.BeaconRootsStorageAddress, params.BeaconRootsCode)
+		installSystemContract(statedb, params.PublicKeyRegistryAddress, params.PublicKeyRegistryCode)
+	}
+	if config.IsPrague(header.Number, header.Time) && !config.IsPrague(parent.Number, parent.Time) {
+		installSystemContract(statedb, params.HistoryStorageAddress, params.HistoryStorageCode)
+	}
+}
+
+// installSystemContract sets the code of the system contract, unless it has
+// been deployed already.
+func installSystemContract(statedb *state.StateDB, addr common.Address, code []byte) {
+	if statedb.GetCodeSize(addr) != 0 {
+		return
+	}
+	statedb.SetCode(addr, code)
+	statedb.SetNonce(addr, 1)
+}
+
+// ProcessBeaconBlockRoot applies the EIP-4788 system call to the beacon block root
+// contract. This method is exported to be used in tests.
+func ProcessBeaconBlockRoot(beaconRoot common.Hash, vmenv *vm.ZVM, statedb *state.StateDB) {
+	// If the beacon roots contract is not deployed, the call below is a no-op.
+	msg := &Message{
+		From:      params.SystemAddress,
+		GasLimit:  30_000_000,
+		GasPrice:  common.Big0,
+		GasFeeCap: common.Big0,
+		GasTipCap: common.Big0,
+		To:        &params.BeaconRootsStorageAddress,
+		Data:      beaconRoot[:],
+	}
+	vmenv.Reset(NewZVMTxContext(msg), statedb)
+	statedb.AddAddressToAccessList(params.BeaconRootsStorageAddress)
+	_, _, _ = vmenv.Call(vm.AccountRef(msg.From), *msg.To, msg.Data, 30_000_000, common.Big0)
+	statedb.Finalise(true)
+}
+
+// ProcessParentBlockHash stores the parent block hash in the history storage
+// contract, making it available beyond the reach of the BLOCKHASH opcode.
+func ProcessParentBlockHash(prevHash common.Hash, vmenv *vm.ZVM, statedb *state.StateDB) {
+	// If the history storage contract is not deployed, the call below is a no-op.
+	msg := &Message{
+		From:      params.SystemAddress,
+		GasLimit:  30_000_000,
+		GasPrice:  common.Big0,
+		GasFeeCap: common.Big0,
+		GasTipCap: common.Big0,
+		To:        &params.HistoryStorageAddress,
+		Data:      prevHash[:],
+	}
+	vmenv.Reset(NewZVMTxContext(msg), statedb)
+	statedb.AddAddressToAccessList(params.HistoryStorageAddress)
+	_, _, _ = vmenv.Call(vm.AccountRef(msg.From), *msg.To, msg.Data, 30_000_000, common.Big0)
+	statedb.Finalise(true)
+}
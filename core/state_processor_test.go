@@ -17,6 +17,7 @@
 package core
 
 import (
+	"bytes"
 	"math"
 	"math/big"
 	"testing"
@@ -27,6 +28,7 @@ import (
 	"github.com/theQRL/go-zond/consensus/beacon"
 	"github.com/theQRL/go-zond/consensus/misc/eip1559"
 	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/state"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto/pqcrypto"
@@ -305,6 +307,197 @@ func TestStateProcessorErrors(t *testing.T) {
 	}
 }
 
+// Tests that the beacon roots contract records the parent beacon block root
+// passed by the system call and serves it back to regular callers.
+func TestProcessBeaconBlockRoot(t *testing.T) {
+	var (
+		statedb, _ = state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		beaconRoot = common.Hash{0xbe, 0xac, 0x02}
+		header     = &types.Header{Number: big.NewInt(1), Time: 12345, GasLimit: params.MaxGasLimit, BaseFee: big.NewInt(0)}
+		contract   = params.BeaconRootsStorageAddress
+		caller     = common.Address{0x11}
+	)
+	statedb.SetCode(contract, params.BeaconRootsCode)
+	statedb.SetNonce(contract, 1)
+
+	vmenv := vm.NewZVM(NewZVMBlockContext(header, nil, &header.Coinbase), vm.TxContext{}, statedb, params.TestChainConfig, vm.Config{})
+	ProcessBeaconBlockRoot(beaconRoot, vmenv, statedb)
+
+	// The timestamp and the root are stored in the ring buffer
+	var (
+		timestamp = common.BigToHash(new(big.Int).SetUint64(header.Time))
+		tsSlot    = common.BigToHash(new(big.Int).SetUint64(header.Time % 8191))
+		rootSlot  = common.BigToHash(new(big.Int).SetUint64(header.Time%8191 + 8191))
+	)
+	if have := statedb.GetState(contract, tsSlot); have != timestamp {
+		t.Fatalf("timestamp mismatch: have %x, want %x", have, timestamp)
+	}
+	if have := statedb.GetState(contract, rootSlot); have != beaconRoot {
+		t.Fatalf("beacon root mismatch: have %x, want %x", have, beaconRoot)
+	}
+	// Regular callers can retrieve the root by timestamp
+	ret, _, err := vmenv.Call(vm.AccountRef(caller), contract, timestamp[:], 100_000, common.Big0)
+	if err != nil {
+		t.Fatalf("failed to query beacon root: %v", err)
+	}
+	if have := common.BytesToHash(ret); have != beaconRoot {
+		t.Fatalf("queried beacon root mismatch: have %x, want %x", have, beaconRoot)
+	}
+	// Unknown timestamps are rejected
+	unknown := common.BigToHash(new(big.Int).SetUint64(header.Time + 1))
+	if _, _, err := vmenv.Call(vm.AccountRef(caller), contract, unknown[:], 100_000, common.Big0); err != vm.ErrExecutionReverted {
+		t.Fatalf("unexpected error for unknown timestamp: have %v, want %v", err, vm.ErrExecutionReverted)
+	}
+}
+
+// Tests that the beacon roots contract is installed by the Cancun activation
+// block on chains not allocating it in the genesis.
+func TestBeaconRootsActivation(t *testing.T) {
+	var (
+		config = *params.TestChainConfig
+		gspec  = &Genesis{Config: &config}
+		cancun = uint64(30)
+	)
+	config.CancunTime = &cancun
+	config.PragueTime = nil
+
+	db := rawdb.NewMemoryDatabase()
+	blockchain, _ := NewBlockChain(db, nil, gspec, beacon.NewFaker(), vm.Config{}, nil)
+	defer blockchain.Stop()
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, beacon.NewFaker(), 5, nil)
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	for _, block := range blocks {
+		statedb, err := blockchain.StateAt(block.Root())
+		if err != nil {
+			t.Fatalf("block %d: failed to retrieve state: %v", block.NumberU64(), err)
+		}
+		code := statedb.GetCode(params.BeaconRootsStorageAddress)
+		if !config.IsCancun(block.Number(), block.Time()) {
+			if len(code) != 0 {
+				t.Fatalf("block %d: beacon roots contract installed before Cancun", block.NumberU64())
+			}
+			continue
+		}
+		if !bytes.Equal(code, params.BeaconRootsCode) {
+			t.Fatalf("block %d: beacon roots contract not installed", block.NumberU64())
+		}
+		// The system call of the activation block is recorded as well
+		slot := common.BigToHash(new(big.Int).SetUint64(block.Time() % 8191))
+		if have, want := statedb.GetState(params.BeaconRootsStorageAddress, slot), common.BigToHash(new(big.Int).SetUint64(block.Time())); have != want {
+			t.Fatalf("block %d: timestamp mismatch: have %x, want %x", block.NumberU64(), have, want)
+		}
+	}
+}
+
+// Tests that the history storage contract records the parent hash of every
+// block once Prague is active, serving hashes beyond the reach of BLOCKHASH.
+func TestProcessParentBlockHash(t *testing.T) {
+	var (
+		config = *params.TestChainConfig
+		gspec  = &Genesis{
+			Config: &config,
+			Alloc: GenesisAlloc{
+				params.HistoryStorageAddress: {Nonce: 1, Code: params.HistoryStorageCode, Balance: common.Big0},
+			},
+		}
+		caller = common.Address{0x11}
+		prague = uint64(30)
+	)
+	// Activate Prague a few blocks after genesis to check the fork gating
+	config.PragueTime = &prague
+
+	db := rawdb.NewMemoryDatabase()
+	blockchain, _ := NewBlockChain(db, nil, gspec, beacon.NewFaker(), vm.Config{}, nil)
+	defer blockchain.Stop()
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, beacon.NewFaker(), 300, nil)
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	statedb, err := blockchain.State()
+	if err != nil {
+		t.Fatalf("failed to retrieve state: %v", err)
+	}
+	head := blockchain.CurrentBlock()
+	vmenv := vm.NewZVM(NewZVMBlockContext(head, blockchain, &head.Coinbase), vm.TxContext{}, statedb, &config, vm.Config{})
+
+	for _, block := range append([]*types.Block{blockchain.Genesis()}, blocks...) {
+		var (
+			number = block.NumberU64()
+			have   = statedb.GetState(params.HistoryStorageAddress, common.BigToHash(new(big.Int).SetUint64(number%params.HistoryServeWindow)))
+			want   = block.Hash()
+		)
+		// Parents of pre-Prague blocks are not recorded
+		if !config.IsPrague(nil, block.Time()+10) || number == head.Number.Uint64() {
+			want = common.Hash{}
+		}
+		if have != want {
+			t.Fatalf("block %d: stored hash mismatch: have %x, want %x", number, have, want)
+		}
+		if want == (common.Hash{}) {
+			continue
+		}
+		// Regular callers can retrieve hashes older than 256 blocks
+		input := common.BigToHash(new(big.Int).SetUint64(number))
+		ret, _, err := vmenv.Call(vm.AccountRef(caller), params.HistoryStorageAddress, input[:], 100_000, common.Big0)
+		if err != nil {
+			t.Fatalf("block %d: failed to query hash: %v", number, err)
+		}
+		if have := common.BytesToHash(ret); have != want {
+			t.Fatalf("block %d: queried hash mismatch: have %x, want %x", number, have, want)
+		}
+	}
+	// Future blocks are rejected
+	future := common.BigToHash(head.Number)
+	if _, _, err := vmenv.Call(vm.AccountRef(caller), params.HistoryStorageAddress, future[:], 100_000, common.Big0); err != vm.ErrExecutionReverted {
+		t.Fatalf("unexpected error for future block: have %v, want %v", err, vm.ErrExecutionReverted)
+	}
+}
+
+// Tests that the history storage contract is installed by the Prague activation
+// block on chains not allocating it in the genesis.
+func TestHistoryStorageActivation(t *testing.T) {
+	var (
+		config = *params.TestChainConfig
+		gspec  = &Genesis{Config: &config}
+		prague = uint64(30)
+	)
+	config.PragueTime = &prague
+
+	db := rawdb.NewMemoryDatabase()
+	blockchain, _ := NewBlockChain(db, nil, gspec, beacon.NewFaker(), vm.Config{}, nil)
+	defer blockchain.Stop()
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, beacon.NewFaker(), 5, nil)
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	for _, block := range blocks {
+		statedb, err := blockchain.StateAt(block.Root())
+		if err != nil {
+			t.Fatalf("block %d: failed to retrieve state: %v", block.NumberU64(), err)
+		}
+		code := statedb.GetCode(params.HistoryStorageAddress)
+		if !config.IsPrague(block.Number(), block.Time()) {
+			if len(code) != 0 {
+				t.Fatalf("block %d: history storage contract installed before Prague", block.NumberU64())
+			}
+			continue
+		}
+		if !bytes.Equal(code, params.HistoryStorageCode) {
+			t.Fatalf("block %d: history storage contract not installed", block.NumberU64())
+		}
+		// The parent hash of the activation block is recorded as well
+		slot := common.BigToHash(new(big.Int).SetUint64((block.NumberU64() - 1) % params.HistoryServeWindow))
+		if have, want := statedb.GetState(params.HistoryStorageAddress, slot), block.ParentHash(); have != want {
+			t.Fatalf("block %d: parent hash mismatch: have %x, want %x", block.NumberU64(), have, want)
+		}
+	}
+}
+
 // GenerateBadBlock constructs a "block" which contains the transactions. The transactions are not expected to be
 // valid, and no proper post-state can be made. But from the perspective of the blockchain, the block is sufficiently
 // valid to be considered for import:
@@ -319,6 +512,21 @@ func GenerateBadBlock(parent *types.Block, engine consensus.Engine, txs types.Tr
 	}
 	header.BaseFee = eip1559.CalcBaseFee(config, parent.Header())
 	header.WithdrawalsHash = &types.EmptyWithdrawalsHash
+	if config.IsCancun(header.Number, header.Time) {
+		var pExcess, pUsed = uint64(0), uint64(0)
+		if parent.ExcessBlobGas() != nil {
+			pExcess = *parent.ExcessBlobGas()
+			pUsed = *parent.BlobGasUsed()
+		}
+		excess := eip1559.CalcExcessBlobGas(pExcess, pUsed)
+		used := uint64(0)
+		for _, tx := range txs {
+			used += tx.BlobGas()
+		}
+		header.ExcessBlobGas = &excess
+		header.BlobGasUsed = &used
+		header.ParentBeaconRoot = new(common.Hash)
+	}
 	var receipts []*types.Receipt
 	// The post-state result doesn't need to be correct (this is a bad block), but we do need something there
 	// Preferably something unique. So let's use a combo of blocknum + txhash
@@ -25,6 +25,8 @@ import (
 	cmath "github.com/theQRL/go-zond/common/math"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto/blobcommit"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/params"
 )
 
@@ -133,10 +135,24 @@ type Message struct {
 	Data       []byte
 	AccessList types.AccessList
 
+	BlobGasFeeCap *big.Int
+	BlobHashes    []common.Hash
+
 	// When SkipAccountChecks is true, the message nonce is not checked against the
 	// account nonce in state. It also disables checking that the sender is an EOA.
 	// This field will be set to true for operations like RPC eth_call.
 	SkipAccountChecks bool
+
+	// PublicKey is the public key carried by the transaction. Since Cancun it
+	// is recorded in the public key registry if the message is sent to the
+	// registry and executes successfully.
+	PublicKey []byte
+
+	// Signature and SignatureHash are set for registered key transactions,
+	// whose signature is checked against the public key registered for the
+	// sender before the message is applied.
+	Signature     []byte
+	SignatureHash common.Hash
 }
 
 // TransactionToMessage converts a transaction into a Message.
@@ -152,11 +168,19 @@ func TransactionToMessage(tx *types.Transaction, s types.Signer, baseFee *big.In
 		Data:              tx.Data(),
 		AccessList:        tx.AccessList(),
 		SkipAccountChecks: false,
+		BlobHashes:        tx.BlobHashes(),
+		BlobGasFeeCap:     tx.BlobGasFeeCap(),
 	}
 	// If baseFee provided, set gasPrice to effectiveGasPrice.
 	if baseFee != nil {
 		msg.GasPrice = cmath.BigMin(msg.GasPrice.Add(msg.GasTipCap, baseFee), msg.GasFeeCap)
 	}
+	if tx.Type() == types.RegisteredKeyTxType {
+		msg.Signature = tx.RawSignatureValue()
+		msg.SignatureHash = s.Hash(tx)
+	} else {
+		msg.PublicKey = tx.RawPublicKeyValue()
+	}
 	var err error
 	msg.From, err = types.Sender(s, tx)
 	return msg, err
@@ -231,6 +255,18 @@ func (st *StateTransition) buyGas() error {
 		balanceCheck = balanceCheck.Mul(balanceCheck, st.msg.GasFeeCap)
 		balanceCheck.Add(balanceCheck, st.msg.Value)
 	}
+	if st.zvm.ChainConfig().IsCancun(st.zvm.Context.BlockNumber, st.zvm.Context.Time) {
+		if blobGas := st.blobGasUsed(); blobGas > 0 {
+			// Check that the user has enough funds to cover blobGasUsed * tx.BlobGasFeeCap
+			blobBalanceCheck := new(big.Int).SetUint64(blobGas)
+			blobBalanceCheck.Mul(blobBalanceCheck, st.msg.BlobGasFeeCap)
+			balanceCheck.Add(balanceCheck, blobBalanceCheck)
+			// Pay for blobGasUsed * actual blob fee
+			blobFee := new(big.Int).SetUint64(blobGas)
+			blobFee.Mul(blobFee, st.zvm.Context.BlobBaseFee)
+			mgval.Add(mgval, blobFee)
+		}
+	}
 	if have, want := st.state.GetBalance(st.msg.From), balanceCheck; have.Cmp(want) < 0 {
 		return fmt.Errorf("%w: address %v have %v want %v", ErrInsufficientFunds, st.msg.From.Hex(), have, want)
 	}
@@ -267,6 +303,18 @@ func (st *StateTransition) preCheck() error {
 				msg.From.Hex(), codeHash)
 		}
 	}
+	// Make sure registered key transactions are signed by the registered key.
+	// The sender of such messages is taken from the transaction unverified,
+	// so the check must never be skipped.
+	if msg.Signature != nil {
+		pubkey := RegisteredPublicKey(st.state, msg.From)
+		if pubkey == nil {
+			return fmt.Errorf("%w: address %v", ErrPublicKeyNotRegistered, msg.From.Hex())
+		}
+		if !pqcrypto.VerifySignature(pubkey, msg.SignatureHash[:], msg.Signature) {
+			return fmt.Errorf("%w: address %v", ErrInvalidSignature, msg.From.Hex())
+		}
+	}
 
 	// Make sure that transaction gasFeeCap is greater than the baseFee (post london)
 	// Skip the checks if gas fields are zero and baseFee was explicitly disabled (eth_call)
@@ -290,7 +338,38 @@ func (st *StateTransition) preCheck() error {
 				msg.From.Hex(), msg.GasFeeCap, st.zvm.Context.BaseFee)
 		}
 	}
-
+	// Check the blob version validity
+	if msg.BlobHashes != nil {
+		// The to field of a blob tx type is mandatory, and a `BlobTx` transaction internally
+		// has it as a non-nillable value, so any msg derived from blob transaction has it non-nil.
+		// However, messages created through RPC (zond_call) don't have this restriction.
+		if msg.To == nil {
+			return ErrBlobTxCreate
+		}
+		if len(msg.BlobHashes) == 0 {
+			return ErrMissingBlobHashes
+		}
+		for i, hash := range msg.BlobHashes {
+			if !blobcommit.IsValidVersionedHash(hash[:]) {
+				return fmt.Errorf("blob %d has invalid hash version", i)
+			}
+		}
+	}
+	// Check that the user is paying at least the current blob fee
+	if st.zvm.ChainConfig().IsCancun(st.zvm.Context.BlockNumber, st.zvm.Context.Time) {
+		if st.blobGasUsed() > 0 {
+			// Skip the checks if gas fields are zero and blobBaseFee was explicitly disabled (zond_call)
+			skipCheck := st.zvm.Config.NoBaseFee && msg.BlobGasFeeCap.BitLen() == 0
+			if !skipCheck {
+				// This will panic if blobBaseFee is nil, but blobBaseFee presence
+				// is verified as part of header validation.
+				if msg.BlobGasFeeCap.Cmp(st.zvm.Context.BlobBaseFee) < 0 {
+					return fmt.Errorf("%w: address %v blobGasFeeCap: %v, blobBaseFee: %v", ErrBlobFeeCapTooLow,
+						msg.From.Hex(), msg.BlobGasFeeCap, st.zvm.Context.BlobBaseFee)
+				}
+			}
+		}
+	}
 	return st.buyGas()
 }
 
@@ -339,6 +418,13 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	registration := rules.IsCancun && IsKeyRegistration(msg.To, msg.PublicKey)
+	if registration {
+		if math.MaxUint64-gas < keyRegistrationGas {
+			return nil, ErrGasUintOverflow
+		}
+		gas += keyRegistrationGas
+	}
 	if st.gasRemaining < gas {
 		return nil, fmt.Errorf("%w: have %d, want %d", ErrIntrinsicGas, st.gasRemaining, gas)
 	}
@@ -356,6 +442,7 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 
 	// Execute the preparatory steps for state transition which includes:
 	// - prepare accessList
+	// - reset transient storage
 	st.state.Prepare(rules, msg.From, st.zvm.Context.Coinbase, msg.To, vm.ActivePrecompiles(rules), msg.AccessList)
 
 	var (
@@ -369,6 +456,11 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 		st.state.SetNonce(msg.From, st.state.GetNonce(sender.Address())+1)
 		ret, st.gasRemaining, vmerr = st.zvm.Call(sender, st.to(), msg.Data, st.gasRemaining, msg.Value)
 	}
+	// Record the public key of the sender if requested, so subsequent
+	// transactions may reference it instead of carrying it.
+	if registration && vmerr == nil {
+		RegisterPublicKey(st.state, msg.PublicKey)
+	}
 
 	// After EIP-3529: refunds are capped to gasUsed / 5
 	st.refundGas(params.RefundQuotientEIP3529)
@@ -412,3 +504,8 @@ func (st *StateTransition) refundGas(refundQuotient uint64) {
 func (st *StateTransition) gasUsed() uint64 {
 	return st.initialGas - st.gasRemaining
 }
+
+// blobGasUsed returns the amount of blob gas used by the message.
+func (st *StateTransition) blobGasUsed() uint64 {
+	return uint64(len(st.msg.BlobHashes) * params.BlobTxBlobGasPerBlob)
+}
@@ -0,0 +1,398 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/theQRL/go-qrllib/dilithium"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/misc/eip1559"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto/blobcommit"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/rlp"
+)
+
+var (
+	emptyBlob       = blobcommit.Blob{}
+	emptyCommitment = blobcommit.BlobToCommitment(&emptyBlob)
+	emptySidecar    = &types.BlobTxSidecar{
+		Blobs:       []blobcommit.Blob{emptyBlob},
+		Commitments: []blobcommit.Commitment{emptyCommitment},
+	}
+	emptyBlobHash = emptySidecar.BlobHashes()[0]
+)
+
+// testBlockChain is a mock of the live chain for testing the pool.
+type testBlockChain struct {
+	config  *params.ChainConfig
+	basefee uint64
+	blobfee uint64
+	statedb *state.StateDB
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig {
+	return bc.config
+}
+
+func (bc *testBlockChain) CurrentBlock() *types.Header {
+	var (
+		gasLimit = uint64(30_000_000)
+		baseFee  = big.NewInt(int64(bc.basefee))
+		excess   uint64
+		used     uint64
+	)
+	parent := &types.Header{
+		Number:   new(big.Int),
+		GasLimit: gasLimit,
+		GasUsed:  gasLimit / bc.config.ElasticityMultiplier(),
+		BaseFee:  baseFee,
+	}
+	// The base fee of the next block stays the same if the parent is exactly
+	// at target usage. Inverting the blob fee is not worth it, so search for
+	// the smallest excess yielding the requested blob fee instead.
+	for eip1559.CalcBlobFee(eip1559.CalcExcessBlobGas(excess, used)).Uint64() < bc.blobfee {
+		excess += params.BlobTxBlobGasPerBlob
+	}
+	parent.ExcessBlobGas = &excess
+	parent.BlobGasUsed = &used
+	return parent
+}
+
+func (bc *testBlockChain) CurrentFinalBlock() *types.Header {
+	return &types.Header{
+		Number: big.NewInt(0),
+	}
+}
+
+func (bc *testBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return nil
+}
+
+func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
+	return bc.statedb, nil
+}
+
+// makeAddressReserver is a utility method to sanity check that accounts are
+// properly reserved by the blobpool (no duplicate reserves or unreserves).
+func makeAddressReserver() txpool.AddressReserver {
+	var (
+		reserved = make(map[common.Address]struct{})
+		lock     sync.Mutex
+	)
+	return func(addr common.Address, reserve bool) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		_, exists := reserved[addr]
+		if reserve {
+			if exists {
+				panic("already reserved")
+			}
+			reserved[addr] = struct{}{}
+			return nil
+		}
+		if !exists {
+			panic("not reserved")
+		}
+		delete(reserved, addr)
+		return nil
+	}
+}
+
+// makeTx is a utility method to construct a random blob transaction and sign
+// it with a valid key, only setting the interesting fields from the perspective
+// of the blob pool.
+func makeTx(nonce uint64, gasTipCap uint64, gasFeeCap uint64, blobFeeCap uint64, key *dilithium.Dilithium) *types.Transaction {
+	blobtx := &types.BlobTx{
+		ChainID:    params.TestChainConfig.ChainID,
+		Nonce:      nonce,
+		GasTipCap:  new(big.Int).SetUint64(gasTipCap),
+		GasFeeCap:  new(big.Int).SetUint64(gasFeeCap),
+		Gas:        21000,
+		BlobFeeCap: new(big.Int).SetUint64(blobFeeCap),
+		BlobHashes: []common.Hash{emptyBlobHash},
+		Value:      new(big.Int),
+		Sidecar:    emptySidecar,
+	}
+	return types.MustSignNewTx(key, types.LatestSigner(params.TestChainConfig), blobtx)
+}
+
+// newTestPool creates a blob pool on top of a fresh state with the given
+// accounts funded.
+func newTestPool(t *testing.T, datadir string, basefee, blobfee uint64, funded ...common.Address) (*BlobPool, *state.StateDB) {
+	t.Helper()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	for _, addr := range funded {
+		statedb.AddBalance(addr, big.NewInt(1_000_000_000_000_000_000))
+	}
+	statedb.Commit(0, true)
+
+	chain := &testBlockChain{
+		config:  params.TestChainConfig,
+		basefee: basefee,
+		blobfee: blobfee,
+		statedb: statedb,
+	}
+	pool := New(Config{Datadir: datadir}, chain)
+	if err := pool.Init(big.NewInt(1), chain.CurrentBlock(), makeAddressReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	return pool, statedb
+}
+
+// verifyPoolInternals iterates over all the transactions in the pool and checks
+// that sort orders, calculated fields, cumulated fields are correct.
+func verifyPoolInternals(t *testing.T, pool *BlobPool) {
+	t.Helper()
+
+	// Verify that the lookup table and the transaction index are in sync
+	seen := make(map[common.Hash]struct{})
+	for addr, txs := range pool.index {
+		for _, tx := range txs {
+			if _, ok := seen[tx.hash]; ok {
+				t.Errorf("duplicate hash #%x in transaction index: address %s, nonce %d", tx.hash, addr, tx.nonce)
+			}
+			seen[tx.hash] = struct{}{}
+		}
+	}
+	for hash, id := range pool.lookup {
+		if _, ok := seen[hash]; !ok {
+			t.Errorf("lookup entry missing from transaction index: hash #%x, id %d", hash, id)
+		}
+		delete(seen, hash)
+	}
+	for hash := range seen {
+		t.Errorf("indexed transaction hash #%x missing from lookup table", hash)
+	}
+	// Verify that transactions are sorted per account and contain no nonce gaps
+	for addr, txs := range pool.index {
+		for i := 1; i < len(txs); i++ {
+			if txs[i].nonce != txs[i-1].nonce+1 {
+				t.Errorf("addr %v, tx %d nonce mismatch: have %d, want %d", addr, i, txs[i].nonce, txs[i-1].nonce+1)
+			}
+		}
+	}
+	// Verify that the eviction heap tracks exactly the pooled accounts
+	if len(pool.index) != len(pool.evict.addrs) {
+		t.Errorf("eviction heap size mismatch: have %d, want %d", len(pool.evict.addrs), len(pool.index))
+	}
+	for addr := range pool.index {
+		if _, ok := pool.evict.index[addr]; !ok {
+			t.Errorf("account %v missing from eviction heap", addr)
+		}
+	}
+	// Verify that the account spending accumulators add up
+	for addr, txs := range pool.index {
+		spent := new(big.Int)
+		for _, tx := range txs {
+			spent.Add(spent, tx.costCap.ToBig())
+		}
+		if have := pool.spent[addr].ToBig(); have.Cmp(spent) != 0 {
+			t.Errorf("addr %v spent mismatch: have %v, want %v", addr, have, spent)
+		}
+	}
+}
+
+// Tests that transactions can be added to the pool and that the pool enforces
+// the basic admission rules: no nonce gaps, proper blob fees and price bumps
+// on replacements.
+func TestAdd(t *testing.T) {
+	key, _ := pqcrypto.GenerateDilithiumKey()
+	addr := common.Address(key.GetAddress())
+
+	pool, _ := newTestPool(t, "", 1, 1, addr)
+	defer pool.Close()
+
+	tests := []struct {
+		tx   *types.Transaction
+		want error
+	}{
+		{tx: makeTx(1, 1, 1, 1, key), want: core.ErrNonceTooHigh},                                          // nonce gap
+		{tx: makeTx(0, 1, 1, 0, key), want: txpool.ErrUnderpriced},                                         // blob fee below protocol minimum
+		{tx: makeTx(0, 1, 1, 1, key), want: nil},                                                           // valid first transaction
+		{tx: makeTx(0, 1, 1, 1, key), want: txpool.ErrAlreadyKnown},                                        // duplicate
+		{tx: makeTx(0, 2, 2, 1, key), want: txpool.ErrReplaceUnderpriced},                                  // blob fee not bumped
+		{tx: makeTx(0, 1, 2, 2, key), want: txpool.ErrReplaceUnderpriced},                                  // tip not bumped
+		{tx: makeTx(0, 2, 2, 2, key), want: nil},                                                           // proper 100% bump
+		{tx: makeTx(1, 2, 2, 2, key), want: nil},                                                           // valid follow-up
+		{tx: makeTx(3, 2, 2, 2, key), want: core.ErrNonceTooHigh},                                          // nonce gap after pooled txs
+		{tx: makeTx(2, 100_000_000_000_000, 100_000_000_000_000, 2, key), want: core.ErrInsufficientFunds}, // overdraft
+	}
+	for i, tt := range tests {
+		errs := pool.Add([]*types.Transaction{tt.tx}, true, false)
+		if !errors.Is(errs[0], tt.want) {
+			t.Errorf("test %d: adding transaction error mismatch: have %v, want %v", i, errs[0], tt.want)
+		}
+		verifyPoolInternals(t, pool)
+	}
+	if pending, queued := pool.Stats(); pending != 2 || queued != 0 {
+		t.Errorf("pool stats mismatch: have %d/%d, want %d/%d", pending, queued, 2, 0)
+	}
+	if nonce := pool.Nonce(addr); nonce != 2 {
+		t.Errorf("pool nonce mismatch: have %d, want %d", nonce, 2)
+	}
+	// Ensure the pool returns the full transaction, sidecar included
+	tx := makeTx(1, 2, 2, 2, key)
+	if got := pool.Get(tx.Hash()); got == nil || got.BlobTxSidecar() == nil {
+		t.Errorf("pooled transaction or its sidecar missing")
+	}
+	if status := pool.Status(tx.Hash()); status != txpool.TxStatusPending {
+		t.Errorf("transaction status mismatch: have %v, want %v", status, txpool.TxStatusPending)
+	}
+}
+
+// Tests that the pending set is filtered by the requested fee caps.
+func TestPendingFilter(t *testing.T) {
+	key1, _ := pqcrypto.GenerateDilithiumKey()
+	key2, _ := pqcrypto.GenerateDilithiumKey()
+	addr1, addr2 := common.Address(key1.GetAddress()), common.Address(key2.GetAddress())
+
+	pool, _ := newTestPool(t, "", 1, 1, addr1, addr2)
+	defer pool.Close()
+
+	errs := pool.Add([]*types.Transaction{
+		makeTx(0, 10, 100, 10, key1),
+		makeTx(1, 10, 100, 1000, key1),
+		makeTx(0, 10, 100, 1000, key2),
+	}, true, false)
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	if pending := pool.Pending(txpool.PendingFilter{OnlyPlainTxs: true}); len(pending) != 0 {
+		t.Errorf("plain transactions returned from blob pool: %v", pending)
+	}
+	pending := pool.Pending(txpool.PendingFilter{})
+	if len(pending[addr1]) != 2 || len(pending[addr2]) != 1 {
+		t.Errorf("unfiltered pending set mismatch: have %d/%d, want %d/%d", len(pending[addr1]), len(pending[addr2]), 2, 1)
+	}
+	if gas := pending[addr1][0].BlobGas; gas != params.BlobTxBlobGasPerBlob {
+		t.Errorf("lazy transaction blob gas mismatch: have %d, want %d", gas, params.BlobTxBlobGasPerBlob)
+	}
+	// A blob fee above the first transaction's cap must hide the entire account
+	pending = pool.Pending(txpool.PendingFilter{BlobFee: big.NewInt(100)})
+	if len(pending[addr1]) != 0 || len(pending[addr2]) != 1 {
+		t.Errorf("blob fee filtered pending set mismatch: have %d/%d, want %d/%d", len(pending[addr1]), len(pending[addr2]), 0, 1)
+	}
+	// A base fee leaving too small a tip must hide everything
+	pending = pool.Pending(txpool.PendingFilter{MinTip: big.NewInt(1), BaseFee: big.NewInt(100)})
+	if len(pending) != 0 {
+		t.Errorf("base fee filtered pending set mismatch: have %d accounts, want %d", len(pending), 0)
+	}
+}
+
+// Tests that pooled transactions are persisted to disk and reindexed when the
+// pool is reopened, dropping anything gapped or already included.
+func TestOpenIndex(t *testing.T) {
+	storage, _ := os.MkdirTemp("", "blobpool-")
+	defer os.RemoveAll(storage)
+
+	key, _ := pqcrypto.GenerateDilithiumKey()
+	addr := common.Address(key.GetAddress())
+
+	pool, _ := newTestPool(t, storage, 1, 1, addr)
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		if err := pool.Add([]*types.Transaction{makeTx(nonce, 1, 1, 1, key)}, true, false)[0]; err != nil {
+			t.Fatalf("failed to add transaction %d: %v", nonce, err)
+		}
+	}
+	pool.Close()
+
+	// Ensure the transactions were actually persisted to disk
+	store, _ := os.ReadDir(filepath.Join(storage, pendingTransactionStore))
+	if len(store) == 0 {
+		t.Fatalf("no blob pool shelves persisted")
+	}
+	// Reopen the pool with the first transaction already included
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.AddBalance(addr, big.NewInt(1_000_000_000_000_000_000))
+	statedb.SetNonce(addr, 1)
+	statedb.Commit(0, true)
+
+	chain := &testBlockChain{
+		config:  params.TestChainConfig,
+		basefee: 1,
+		blobfee: 1,
+		statedb: statedb,
+	}
+	pool = New(Config{Datadir: storage}, chain)
+	if err := pool.Init(big.NewInt(1), chain.CurrentBlock(), makeAddressReserver()); err != nil {
+		t.Fatalf("failed to reopen blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	verifyPoolInternals(t, pool)
+	if txs := pool.index[addr]; len(txs) != 2 || txs[0].nonce != 1 || txs[1].nonce != 2 {
+		t.Errorf("reindexed transactions mismatch: have %d txs", len(txs))
+	}
+	if pool.Has(makeTx(0, 1, 1, 1, key).Hash()) {
+		t.Errorf("included transaction retained after reopen")
+	}
+	// Ensure the stored size matches the individual entries
+	var stored uint64
+	for _, txs := range pool.index {
+		for _, tx := range txs {
+			stored += uint64(tx.size)
+		}
+	}
+	if pool.stored != stored {
+		t.Errorf("pool storage mismatch: have %d, want %d", pool.stored, stored)
+	}
+}
+
+// Tests that pooled transactions are stored with their sidecar, but announced
+// without it.
+func TestAnnounceWithoutSidecar(t *testing.T) {
+	key, _ := pqcrypto.GenerateDilithiumKey()
+
+	pool, _ := newTestPool(t, "", 1, 1, common.Address(key.GetAddress()))
+	defer pool.Close()
+
+	events := make(chan core.NewTxsEvent, 1)
+	sub := pool.SubscribeTransactions(events)
+	defer sub.Unsubscribe()
+
+	tx := makeTx(0, 1, 1, 1, key)
+	if err := pool.Add([]*types.Transaction{tx}, true, false)[0]; err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	ev := <-events
+	if len(ev.Txs) != 1 || ev.Txs[0].Hash() != tx.Hash() {
+		t.Fatalf("announced transactions mismatch")
+	}
+	if ev.Txs[0].BlobTxSidecar() != nil {
+		t.Errorf("announced transaction carries sidecar")
+	}
+	blob, _ := rlp.EncodeToBytes(pool.Get(tx.Hash()))
+	if uint64(len(blob)) < blobSize {
+		t.Errorf("stored transaction too small to contain its blob: %d bytes", len(blob))
+	}
+}
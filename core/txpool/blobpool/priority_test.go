@@ -0,0 +1,76 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+// Tests that the priority fees are calculated correctly as the log2 of the fee
+// jumps needed to go from the base fee to the tx's fee cap.
+func TestPriorityCalculation(t *testing.T) {
+	tests := []struct {
+		basefee uint64
+		txfee   uint64
+		result  int
+	}{
+		{basefee: 7, txfee: 10, result: 2},                          // 3.02 jumps, 4 ceil, 2 log2
+		{basefee: 17_200_000_000, txfee: 17_200_000_000, result: 0}, // 0 jumps, special case 0 log2
+		{basefee: 9_853_941_692, txfee: 11_085_092_510, result: 0},  // 0.99 jumps, 1 ceil, 0 log2
+		{basefee: 11_544_106_391, txfee: 10_356_781_100, result: 0}, // -0.92 jumps, -1 floor, 0 log2
+		{basefee: 17_200_000_000, txfee: 7, result: -7},             // -183.57 jumps, -184 floor, -7 log2
+		{basefee: 7, txfee: 17_200_000_000, result: 7},              // 183.57 jumps, 184 ceil, 7 log2
+	}
+	for i, tt := range tests {
+		var (
+			baseJumps = dynamicFeeJumps(uint256.NewInt(tt.basefee))
+			feeJumps  = dynamicFeeJumps(uint256.NewInt(tt.txfee))
+		)
+		if prio := evictionPriority1D(baseJumps, feeJumps); prio != tt.result {
+			t.Errorf("test %d priority mismatch: have %d, want %d", i, prio, tt.result)
+		}
+	}
+}
+
+// Tests that the eviction priority is the minimum of the two dimensions.
+func TestPriorityCombination(t *testing.T) {
+	var (
+		low  = dynamicFeeJumps(uint256.NewInt(7))
+		high = dynamicFeeJumps(uint256.NewInt(17_200_000_000))
+	)
+	if prio := evictionPriority(low, high, low, low); prio != 0 {
+		t.Errorf("blob fee dimension ignored: have %d, want %d", prio, 0)
+	}
+	if prio := evictionPriority(high, low, low, high); prio != -7 {
+		t.Errorf("base fee dimension ignored: have %d, want %d", prio, -7)
+	}
+}
+
+// Benchmarks how many dynamic fee jump values can be done.
+func BenchmarkDynamicFeeJumpCalculation(b *testing.B) {
+	fees := make([]*uint256.Int, b.N)
+	for i := 0; i < b.N; i++ {
+		fees[i] = uint256.NewInt(uint64(i) + 1)
+	}
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dynamicFeeJumps(fees[i])
+	}
+}
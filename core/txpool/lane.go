@@ -0,0 +1,65 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/core/types"
+)
+
+// Lane is a priority lane of the transaction pool. The transactions belonging
+// to a lane are admitted within the slot and byte budgets of the lane, evicting
+// only each other, instead of competing for room with the rest of the pool.
+// The miner may additionally reserve gas for the lane when building blocks.
+type Lane struct {
+	Name    string           // Name of the lane, used for logging and metrics
+	Senders []common.Address // Accounts whose transactions belong to the lane
+	Targets []LaneTarget     // Calls whose transactions belong to the lane
+
+	Slots uint64 // Maximum number of transaction slots used by the lane
+	Bytes uint64 // Maximum total size in bytes of the transactions of the lane
+	Gas   uint64 // Gas reserved for the lane in every block built by the miner
+}
+
+// LaneTarget matches the transactions calling a contract, optionally limited to
+// a single method of it.
+type LaneTarget struct {
+	To       common.Address // Recipient of the matched transactions
+	Selector hexutil.Bytes  // Method selector of the matched calls, any if empty
+}
+
+// Match reports whether the transaction sent by the given account belongs to
+// the lane.
+func (l *Lane) Match(from common.Address, tx *types.Transaction) bool {
+	if slices.Contains(l.Senders, from) {
+		return true
+	}
+	to := tx.To()
+	if to == nil {
+		return false
+	}
+	for _, target := range l.Targets {
+		if target.To == *to && bytes.HasPrefix(tx.Data(), target.Selector) {
+			return true
+		}
+	}
+	return false
+}
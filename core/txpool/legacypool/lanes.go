@@ -0,0 +1,178 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/types"
+)
+
+// laneSet tracks the transactions of the priority lanes in the pool, along with
+// the slots and bytes used by each lane to enforce its budget. The transactions
+// of the lanes are neither local nor remote, they're only tracked here.
+//
+// Note, the set is not thread safe, it relies on the lock of the lookup.
+type laneSet struct {
+	lanes  []txpool.Lane
+	signer types.Signer
+
+	txs   []map[common.Hash]*types.Transaction // Transactions tracked by each lane
+	slots []int                                // Number of slots used by each lane
+	bytes []int                                // Total size of the transactions of each lane
+}
+
+// newLaneSet creates the tracker of the given priority lanes.
+func newLaneSet(lanes []txpool.Lane, signer types.Signer) *laneSet {
+	set := &laneSet{
+		lanes:  lanes,
+		signer: signer,
+		txs:    make([]map[common.Hash]*types.Transaction, len(lanes)),
+		slots:  make([]int, len(lanes)),
+		bytes:  make([]int, len(lanes)),
+	}
+	for i := range lanes {
+		set.txs[i] = make(map[common.Hash]*types.Transaction)
+	}
+	return set
+}
+
+// match returns the index of the first lane the transaction belongs to, or -1
+// if it doesn't belong to any.
+func (s *laneSet) match(tx *types.Transaction) int {
+	if len(s.lanes) == 0 {
+		return -1
+	}
+	from, _ := types.Sender(s.signer, tx) // already validated
+	for i := range s.lanes {
+		if s.lanes[i].Match(from, tx) {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasSender reports whether the account is allowlisted as a sender by any lane.
+// The transactions of such accounts are exempt from the per-account limits.
+func (s *laneSet) hasSender(addr common.Address) bool {
+	for i := range s.lanes {
+		if slices.Contains(s.lanes[i].Senders, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// add tracks the transaction in its lane, if any. It returns whether the
+// transaction belongs to a lane.
+func (s *laneSet) add(tx *types.Transaction) bool {
+	lane := s.match(tx)
+	if lane < 0 {
+		return false
+	}
+	s.txs[lane][tx.Hash()] = tx
+	s.slots[lane] += numSlots(tx)
+	s.bytes[lane] += int(tx.Size())
+	return true
+}
+
+// get returns the transaction if it's tracked by any lane, or nil if not found.
+func (s *laneSet) get(hash common.Hash) *types.Transaction {
+	for _, txs := range s.txs {
+		if tx := txs[hash]; tx != nil {
+			return tx
+		}
+	}
+	return nil
+}
+
+// remove stops tracking the transaction in its lane, if any.
+func (s *laneSet) remove(tx *types.Transaction) {
+	for lane, txs := range s.txs {
+		if _, ok := txs[tx.Hash()]; ok {
+			delete(txs, tx.Hash())
+			s.slots[lane] -= numSlots(tx)
+			s.bytes[lane] -= int(tx.Size())
+			return
+		}
+	}
+}
+
+// count returns the number of transactions tracked by all the lanes.
+func (s *laneSet) count() int {
+	var count int
+	for _, txs := range s.txs {
+		count += len(txs)
+	}
+	return count
+}
+
+// flatten returns the transactions tracked by all the lanes.
+func (s *laneSet) flatten() types.Transactions {
+	txs := make(types.Transactions, 0, s.count())
+	for _, lane := range s.txs {
+		for _, tx := range lane {
+			txs = append(txs, tx)
+		}
+	}
+	return txs
+}
+
+// usage returns the number of slots and bytes used by all the lanes.
+func (s *laneSet) usage() (slots int, size int) {
+	for lane := range s.lanes {
+		slots += s.slots[lane]
+		size += s.bytes[lane]
+	}
+	return slots, size
+}
+
+// overflow returns the number of slots and bytes that need to be freed up in
+// the lane to make room for the given transaction.
+func (s *laneSet) overflow(lane int, tx *types.Transaction) (slots int, size int) {
+	slots = s.slots[lane] + numSlots(tx) - int(s.lanes[lane].Slots)
+	size = s.bytes[lane] + int(tx.Size()) - int(s.lanes[lane].Bytes)
+	return slots, size
+}
+
+// cheapest returns the transactions of the lane priced below the given one,
+// excluding the ones of the given sender, sorted from the cheapest.
+func (s *laneSet) cheapest(lane int, from common.Address, tx *types.Transaction) types.Transactions {
+	var txs types.Transactions
+	for _, ltx := range s.txs[lane] {
+		if sender, _ := types.Sender(s.signer, ltx); sender == from {
+			continue
+		}
+		if laneCmp(ltx, tx) < 0 {
+			txs = append(txs, ltx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool { return laneCmp(txs[i], txs[j]) < 0 })
+	return txs
+}
+
+// laneCmp compares the price of two transactions of a lane, by fee cap first
+// and by tip cap second.
+func laneCmp(a, b *types.Transaction) int {
+	if c := a.GasFeeCapCmp(b); c != 0 {
+		return c
+	}
+	return a.GasTipCapCmp(b)
+}
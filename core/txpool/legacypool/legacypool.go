@@ -37,6 +37,7 @@ import (
 	"github.com/theQRL/go-zond/log"
 	"github.com/theQRL/go-zond/metrics"
 	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/zonddb"
 	"golang.org/x/exp/maps"
 )
 
@@ -58,6 +59,10 @@ var (
 	// ErrTxPoolOverflow is returned if the transaction pool is full and can't accept
 	// another remote transaction.
 	ErrTxPoolOverflow = errors.New("txpool is full")
+
+	// ErrLaneOverflow is returned if the priority lane of a transaction is full
+	// and no cheaper transaction of the lane can be evicted to make room for it.
+	ErrLaneOverflow = errors.New("txpool lane is full")
 )
 
 var (
@@ -99,6 +104,15 @@ var (
 	queuedGauge  = metrics.NewRegisteredGauge("txpool/queued", nil)
 	localGauge   = metrics.NewRegisteredGauge("txpool/local", nil)
 	slotsGauge   = metrics.NewRegisteredGauge("txpool/slots", nil)
+	bytesGauge   = metrics.NewRegisteredGauge("txpool/bytes", nil)
+
+	// discardBytesMeter counts the bytes freed up by discarding underpriced
+	// transactions when the pool is full.
+	discardBytesMeter = metrics.NewRegisteredMeter("txpool/discard/bytes", nil)
+
+	// laneEvictionMeter counts the transactions evicted from the priority lanes
+	// to make room for better priced ones of the same lane.
+	laneEvictionMeter = metrics.NewRegisteredMeter("txpool/lane/eviction", nil)
 
 	reheapTimer = metrics.NewRegisteredTimer("txpool/reheap", nil)
 )
@@ -121,10 +135,8 @@ type BlockChain interface {
 
 // Config are the configuration parameters of the transaction pool.
 type Config struct {
-	Locals    []common.Address // Addresses that should be treated by default as local
-	NoLocals  bool             // Whether local transaction handling should be disabled
-	Journal   string           // Journal of local transactions to survive node restarts
-	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
+	Locals   []common.Address // Addresses that should be treated by default as local
+	NoLocals bool             // Whether local transaction handling should be disabled
 
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
@@ -133,15 +145,17 @@ type Config struct {
 	GlobalSlots  uint64 // Maximum number of executable transaction slots for all accounts
 	AccountQueue uint64 // Maximum number of non-executable transaction slots permitted per account
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
+	GlobalBytes  uint64 // Maximum total size in bytes of the transactions for all accounts
+
+	Lanes []txpool.Lane // Priority lanes with budgets of their own, outside of the global limits
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+
+	Simulate bool // Whether to execute the pending transactions to predict their outcome
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
 var DefaultConfig = Config{
-	Journal:   "transactions.rlp",
-	Rejournal: time.Hour,
-
 	PriceLimit: 1,
 	PriceBump:  10,
 
@@ -149,6 +163,7 @@ var DefaultConfig = Config{
 	GlobalSlots:  4096 + 1024, // urgent + floating queue capacity with 4:1 ratio
 	AccountQueue: 64,
 	GlobalQueue:  1024,
+	GlobalBytes:  64 * 1024 * 1024,
 
 	Lifetime: 3 * time.Hour,
 }
@@ -157,10 +172,6 @@ var DefaultConfig = Config{
 // unreasonable or unworkable.
 func (config *Config) sanitize() Config {
 	conf := *config
-	if conf.Rejournal < time.Second {
-		log.Warn("Sanitizing invalid txpool journal time", "provided", conf.Rejournal, "updated", time.Second)
-		conf.Rejournal = time.Second
-	}
 	if conf.PriceLimit < 1 {
 		log.Warn("Sanitizing invalid txpool price limit", "provided", conf.PriceLimit, "updated", DefaultConfig.PriceLimit)
 		conf.PriceLimit = DefaultConfig.PriceLimit
@@ -185,6 +196,26 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool global queue", "provided", conf.GlobalQueue, "updated", DefaultConfig.GlobalQueue)
 		conf.GlobalQueue = DefaultConfig.GlobalQueue
 	}
+	if conf.GlobalBytes < txMaxSize {
+		log.Warn("Sanitizing invalid txpool global bytes", "provided", conf.GlobalBytes, "updated", DefaultConfig.GlobalBytes)
+		conf.GlobalBytes = DefaultConfig.GlobalBytes
+	}
+	conf.Lanes = make([]txpool.Lane, 0, len(config.Lanes))
+	for _, lane := range config.Lanes {
+		if len(lane.Senders) == 0 && len(lane.Targets) == 0 {
+			log.Warn("Dropping txpool lane without matchers", "lane", lane.Name)
+			continue
+		}
+		if lane.Slots < 1 {
+			log.Warn("Sanitizing invalid txpool lane slots", "lane", lane.Name, "provided", lane.Slots, "updated", conf.AccountSlots)
+			lane.Slots = conf.AccountSlots
+		}
+		if lane.Bytes < txMaxSize {
+			log.Warn("Sanitizing invalid txpool lane bytes", "lane", lane.Name, "provided", lane.Bytes, "updated", txMaxSize)
+			lane.Bytes = txMaxSize
+		}
+		conf.Lanes = append(conf.Lanes, lane)
+	}
 	if conf.Lifetime < 1 {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
 		conf.Lifetime = DefaultConfig.Lifetime
@@ -212,8 +243,8 @@ type LegacyPool struct {
 	currentState  *state.StateDB               // Current state in the blockchain head
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
 
-	locals  *accountSet // Set of local transaction to exempt from eviction rules
-	journal *journal    // Journal of local transaction to back up to disk
+	locals *accountSet // Set of local transaction to exempt from eviction rules
+	store  *txStore    // Store of local transactions to back up to disk
 
 	reserve txpool.AddressReserver       // Address reserver to ensure exclusivity across subpools
 	pending map[common.Address]*list     // All currently processable transactions
@@ -238,8 +269,9 @@ type txpoolResetRequest struct {
 }
 
 // New creates a new transaction pool to gather, sort and filter inbound
-// transactions from the network.
-func New(config Config, chain BlockChain) *LegacyPool {
+// transactions from the network. The local transactions are persisted into
+// the given database, if any.
+func New(config Config, chain BlockChain, db zonddb.KeyValueStore) *LegacyPool {
 	// Sanitize the input to ensure no vulnerable gas prices are set
 	config = (&config).sanitize()
 
@@ -265,10 +297,11 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		log.Info("Setting new local account", "address", addr)
 		pool.locals.add(addr)
 	}
+	pool.all.lanes = newLaneSet(config.Lanes, pool.signer)
 	pool.priced = newPricedList(pool.all)
 
-	if !config.NoLocals && config.Journal != "" {
-		pool.journal = newTxJournal(config.Journal)
+	if !config.NoLocals && db != nil {
+		pool.store = newTxStore(db)
 	}
 	return pool
 }
@@ -277,7 +310,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 // pool.
 func (pool *LegacyPool) Filter(tx *types.Transaction) bool {
 	switch tx.Type() {
-	case types.DynamicFeeTxType:
+	case types.DynamicFeeTxType, types.RegisteredKeyTxType:
 		return true
 	default:
 		return false
@@ -285,7 +318,7 @@ func (pool *LegacyPool) Filter(tx *types.Transaction) bool {
 }
 
 // Init sets the gas price needed to keep a transaction in the pool and the chain
-// head to allow balance / nonce checks. The transaction journal will be loaded
+// head to allow balance / nonce checks. The local transactions will be loaded
 // from disk and filtered based on the provided starting settings. The internal
 // goroutines will be spun up and the pool deemed operational afterwards.
 func (pool *LegacyPool) Init(gasTip *big.Int, head *types.Header, reserve txpool.AddressReserver) error {
@@ -310,18 +343,13 @@ func (pool *LegacyPool) Init(gasTip *big.Int, head *types.Header, reserve txpool
 	pool.pendingNonces = newNoncer(statedb)
 
 	// Start the reorg loop early, so it can handle requests generated during
-	// journal loading.
+	// local transaction loading.
 	pool.wg.Add(1)
 	go pool.scheduleReorgLoop()
 
-	// If local transactions and journaling is enabled, load from disk
-	if pool.journal != nil {
-		if err := pool.journal.load(pool.addLocals); err != nil {
-			log.Warn("Failed to load transaction journal", "err", err)
-		}
-		if err := pool.journal.rotate(pool.local()); err != nil {
-			log.Warn("Failed to rotate transaction journal", "err", err)
-		}
+	// If local transactions and persistence is enabled, load from disk
+	if pool.store != nil {
+		pool.store.load(pool.addLocals)
 	}
 	pool.wg.Add(1)
 	go pool.loop()
@@ -338,13 +366,11 @@ func (pool *LegacyPool) loop() {
 		prevPending, prevQueued, prevStales int
 
 		// Start the stats reporting and transaction eviction tickers
-		report  = time.NewTicker(statsReportInterval)
-		evict   = time.NewTicker(evictionInterval)
-		journal = time.NewTicker(pool.config.Rejournal)
+		report = time.NewTicker(statsReportInterval)
+		evict  = time.NewTicker(evictionInterval)
 	)
 	defer report.Stop()
 	defer evict.Stop()
-	defer journal.Stop()
 
 	// Notify tests that the init phase is done
 	close(pool.initDoneCh)
@@ -370,8 +396,8 @@ func (pool *LegacyPool) loop() {
 		case <-evict.C:
 			pool.mu.Lock()
 			for addr := range pool.queue {
-				// Skip local transactions from the eviction mechanism
-				if pool.locals.contains(addr) {
+				// Skip local and lane sender transactions from the eviction mechanism
+				if pool.locals.contains(addr) || pool.all.lanes.hasSender(addr) {
 					continue
 				}
 				// Any non-locals old enough should be removed
@@ -384,16 +410,6 @@ func (pool *LegacyPool) loop() {
 				}
 			}
 			pool.mu.Unlock()
-
-		// Handle local transaction journal rotation
-		case <-journal.C:
-			if pool.journal != nil {
-				pool.mu.Lock()
-				if err := pool.journal.rotate(pool.local()); err != nil {
-					log.Warn("Failed to rotate local tx journal", "err", err)
-				}
-				pool.mu.Unlock()
-			}
 		}
 	}
 }
@@ -404,8 +420,10 @@ func (pool *LegacyPool) Close() error {
 	close(pool.reorgShutdownCh)
 	pool.wg.Wait()
 
-	if pool.journal != nil {
-		pool.journal.close()
+	if pool.store != nil {
+		pool.mu.Lock()
+		pool.store.sync(pool.local())
+		pool.mu.Unlock()
 	}
 	log.Info("Transaction pool stopped")
 	return nil
@@ -521,6 +539,11 @@ func (pool *LegacyPool) ContentFrom(addr common.Address) ([]*types.Transaction,
 // The transactions can also be pre-filtered by the dynamic fee components to
 // reduce allocations and load on downstream subsystems.
 func (pool *LegacyPool) Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
+	// If only blob transactions are requested, this pool is unsuitable as it
+	// contains none, don't even bother.
+	if filter.OnlyBlobTxs {
+		return nil
+	}
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
@@ -566,6 +589,11 @@ func (pool *LegacyPool) Pending(filter txpool.PendingFilter) map[common.Address]
 	return pending
 }
 
+// Lanes returns the sanitized priority lanes of the pool.
+func (pool *LegacyPool) Lanes() []txpool.Lane {
+	return pool.config.Lanes // immutable after construction, no lock needed
+}
+
 // Locals retrieves the accounts currently considered local by the pool.
 func (pool *LegacyPool) Locals() []common.Address {
 	pool.mu.Lock()
@@ -574,6 +602,38 @@ func (pool *LegacyPool) Locals() []common.Address {
 	return pool.locals.flatten()
 }
 
+// LocalTransactions retrieves all currently known local transactions, grouped
+// by origin account and sorted by nonce. These are the transactions persisted
+// to survive node restarts.
+func (pool *LegacyPool) LocalTransactions() map[common.Address]types.Transactions {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	return pool.local()
+}
+
+// DropLocal removes a local transaction from the pool and from disk. The later
+// transactions of the same account are moved to the queue, as they can't be
+// executed any more. It returns whether the transaction was found.
+func (pool *LegacyPool) DropLocal(hash common.Hash) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	tx := pool.all.Get(hash)
+	if tx == nil {
+		return false
+	}
+	from, _ := types.Sender(pool.signer, tx) // already validated during insertion
+	if !pool.locals.contains(from) {
+		return false
+	}
+	pool.removeTx(hash, true, true)
+	if pool.store != nil {
+		pool.store.remove(from, tx)
+	}
+	return true
+}
+
 // local retrieves all currently known local transactions, grouped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -598,7 +658,8 @@ func (pool *LegacyPool) validateTxBasics(tx *types.Transaction, local bool) erro
 	opts := &txpool.ValidationOptions{
 		Config: pool.chainconfig,
 		Accept: 0 |
-			1<<types.DynamicFeeTxType,
+			1<<types.DynamicFeeTxType |
+			1<<types.RegisteredKeyTxType,
 		MaxSize: txMaxSize,
 		MinTip:  pool.gasTip.Load(),
 	}
@@ -699,8 +760,15 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 			}
 		}()
 	}
-	// If the transaction pool is full, discard underpriced transactions
-	if uint64(pool.all.Slots()+numSlots(tx)) > pool.config.GlobalSlots+pool.config.GlobalQueue {
+	// Transactions of the priority lanes are exempt from the global limits and
+	// the pricing eviction, they only compete with the rest of their lane.
+	lane := pool.all.Lane(tx)
+	if lane >= 0 {
+		if err := pool.discardLane(lane, from, tx); err != nil {
+			return false, err
+		}
+	} else if slots, size := pool.overflow(tx); slots > 0 || size > 0 {
+		// If the transaction pool is full, discard underpriced transactions
 		// If the new transaction is underpriced, don't accept it
 		if !isLocal && pool.priced.Underpriced(tx) {
 			log.Trace("Discarding underpriced transaction", "hash", hash, "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
@@ -720,7 +788,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 		// New transaction is better than our worse ones, make room for it.
 		// If it's a local transaction, forcibly discard all available transactions.
 		// Otherwise if we can't make enough room for new one, abort the operation.
-		drop, success := pool.priced.Discard(pool.all.Slots()-int(pool.config.GlobalSlots+pool.config.GlobalQueue)+numSlots(tx), isLocal)
+		drop, success := pool.priced.Discard(slots, size, isLocal)
 
 		// Special case, we still can't make the room for the new remote one.
 		if !isLocal && !success {
@@ -753,6 +821,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 		for _, tx := range drop {
 			log.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
 			underpricedTxMeter.Mark(1)
+			discardBytesMeter.Mark(int64(tx.Size()))
 
 			sender, _ := types.Sender(pool.signer, tx)
 			dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
@@ -777,7 +846,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 		}
 		pool.all.Add(tx, isLocal)
 		pool.priced.Put(tx, isLocal)
-		pool.journalTx(from, tx)
+		pool.storeTx(from, tx)
 		pool.queueTxEvent(tx)
 		log.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
@@ -790,7 +859,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 	if err != nil {
 		return false, err
 	}
-	// Mark local addresses and journal local transactions
+	// Mark local addresses and persist local transactions
 	if local && !pool.locals.contains(from) {
 		log.Info("Setting new local account", "address", from)
 		pool.locals.add(from)
@@ -799,12 +868,57 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 	if isLocal {
 		localGauge.Inc(1)
 	}
-	pool.journalTx(from, tx)
+	pool.storeTx(from, tx)
 
 	log.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replaced, nil
 }
 
+// overflow returns the number of slots and bytes that need to be freed up in
+// the pool to make room for the given transaction. Non-positive values mean
+// the transaction fits within the respective limit.
+//
+// The transactions of the priority lanes are not accounted for, they're only
+// subject to the budgets of their lanes.
+func (pool *LegacyPool) overflow(tx *types.Transaction) (slots int, size int) {
+	laneSlots, laneSize := pool.all.LaneUsage()
+	slots = pool.all.Slots() - laneSlots + numSlots(tx) - int(pool.config.GlobalSlots+pool.config.GlobalQueue)
+	size = pool.all.Bytes() - laneSize + int(tx.Size()) - int(pool.config.GlobalBytes)
+	return slots, size
+}
+
+// discardLane makes room for the given transaction in its priority lane if the
+// lane is full, by evicting the cheaper transactions of the other accounts in
+// the lane. If not enough room can be made, nothing is evicted.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) discardLane(lane int, from common.Address, tx *types.Transaction) error {
+	slots, size := pool.all.LaneOverflow(lane, tx)
+	if slots <= 0 && size <= 0 {
+		return nil
+	}
+	var drop types.Transactions
+	for _, dropTx := range pool.all.LaneCheapest(lane, from, tx) {
+		if slots <= 0 && size <= 0 {
+			break
+		}
+		drop = append(drop, dropTx)
+		slots -= numSlots(dropTx)
+		size -= int(dropTx.Size())
+	}
+	if slots > 0 || size > 0 {
+		log.Trace("Discarding transaction overflowing its lane", "hash", tx.Hash(), "lane", pool.config.Lanes[lane].Name)
+		overflowedTxMeter.Mark(1)
+		return ErrLaneOverflow
+	}
+	for _, dropTx := range drop {
+		log.Trace("Evicting transaction from its lane", "hash", dropTx.Hash(), "lane", pool.config.Lanes[lane].Name)
+		laneEvictionMeter.Mark(1)
+		pool.changesSinceReorg += pool.removeTx(dropTx.Hash(), false, true)
+	}
+	return nil
+}
+
 // isGapped reports whether the given transaction is immediately executable.
 func (pool *LegacyPool) isGapped(from common.Address, tx *types.Transaction) bool {
 	// Short circuit if transaction falls within the scope of the pending list
@@ -869,16 +983,14 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 	return old != nil, nil
 }
 
-// journalTx adds the specified transaction to the local disk journal if it is
-// deemed to have been sent from a local account.
-func (pool *LegacyPool) journalTx(from common.Address, tx *types.Transaction) {
-	// Only journal if it's enabled and the transaction is local
-	if pool.journal == nil || !pool.locals.contains(from) {
+// storeTx adds the specified transaction to the local transaction store if it
+// is deemed to have been sent from a local account.
+func (pool *LegacyPool) storeTx(from common.Address, tx *types.Transaction) {
+	// Only persist if it's enabled and the transaction is local
+	if pool.store == nil || !pool.locals.contains(from) {
 		return
 	}
-	if err := pool.journal.insert(tx); err != nil {
-		log.Warn("Failed to journal local transaction", "err", err)
-	}
+	pool.store.insert(from, tx)
 }
 
 // promoteTx adds a transaction to the pending (processable) list of transactions
@@ -966,6 +1078,11 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 	// Do not treat as local if local transactions have been disabled
 	local = local && !pool.config.NoLocals
 
+	// Verify the signatures of the batch in parallel, the individual validations
+	// below will pick up the cached results
+	if len(txs) > 1 {
+		core.SenderVerifier.VerifyBatch(pool.signer, txs).Wait()
+	}
 	// Filter out known ones without obtaining the pool lock or recovering signatures
 	var (
 		errs = make([]error, len(txs))
@@ -1296,6 +1413,11 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 			nonces[addr] = highestPending.Nonce() + 1
 		}
 		pool.pendingNonces.setAll(nonces)
+
+		// Drop the included and invalidated local transactions from disk
+		if pool.store != nil {
+			pool.store.sync(pool.local())
+		}
 	}
 	// Ensure pool.queue and pool.pending sizes stay within the configured limits.
 	pool.truncatePending()
@@ -1417,7 +1539,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 
 	// Inject any transactions discarded due to reorgs
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
-	core.SenderCacher.Recover(pool.signer, reinject)
+	core.SenderVerifier.VerifyBatch(pool.signer, reinject)
 	pool.addTxsLocked(reinject, false)
 }
 
@@ -1491,14 +1613,32 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 	return promoted
 }
 
+// laneCounts returns the number of transactions of the priority lanes in the
+// pending and in the queued sets.
+func (pool *LegacyPool) laneCounts() (pending uint64, queued uint64) {
+	for _, tx := range pool.all.LaneTxs() {
+		from, _ := types.Sender(pool.signer, tx) // already validated
+		if list := pool.pending[from]; list != nil && list.Contains(tx.Nonce()) {
+			pending++
+		} else {
+			queued++
+		}
+	}
+	return pending, queued
+}
+
 // truncatePending removes transactions from the pending queue if the pool is above the
 // pending limit. The algorithm tries to reduce transaction counts by an approximately
 // equal number for all for accounts with many pending transactions.
 func (pool *LegacyPool) truncatePending() {
+	// The transactions of the priority lanes are only subject to the budgets of
+	// their lanes, leave them out of the global limit.
+	lanes, _ := pool.laneCounts()
 	pending := uint64(0)
 	for _, list := range pool.pending {
 		pending += uint64(list.Len())
 	}
+	pending -= lanes
 	if pending <= pool.config.GlobalSlots {
 		return
 	}
@@ -1508,7 +1648,7 @@ func (pool *LegacyPool) truncatePending() {
 	spammers := prque.New[int64, common.Address](nil)
 	for addr, list := range pool.pending {
 		// Only evict transactions from high rollers
-		if !pool.locals.contains(addr) && uint64(list.Len()) > pool.config.AccountSlots {
+		if !pool.locals.contains(addr) && !pool.all.lanes.hasSender(addr) && uint64(list.Len()) > pool.config.AccountSlots {
 			spammers.Push(addr, int64(list.Len()))
 		}
 	}
@@ -1533,6 +1673,9 @@ func (pool *LegacyPool) truncatePending() {
 					for _, tx := range caps {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
+						if !pool.all.InLane(hash) {
+							pending--
+						}
 						pool.all.Remove(hash)
 
 						// Update the account nonce to the dropped transaction
@@ -1544,7 +1687,6 @@ func (pool *LegacyPool) truncatePending() {
 					if pool.locals.contains(offenders[i]) {
 						localGauge.Dec(int64(len(caps)))
 					}
-					pending--
 				}
 			}
 		}
@@ -1560,6 +1702,9 @@ func (pool *LegacyPool) truncatePending() {
 				for _, tx := range caps {
 					// Drop the transaction from the global pools too
 					hash := tx.Hash()
+					if !pool.all.InLane(hash) {
+						pending--
+					}
 					pool.all.Remove(hash)
 
 					// Update the account nonce to the dropped transaction
@@ -1571,7 +1716,6 @@ func (pool *LegacyPool) truncatePending() {
 				if pool.locals.contains(addr) {
 					localGauge.Dec(int64(len(caps)))
 				}
-				pending--
 			}
 		}
 	}
@@ -1580,10 +1724,14 @@ func (pool *LegacyPool) truncatePending() {
 
 // truncateQueue drops the oldest transactions in the queue if the pool is above the global queue limit.
 func (pool *LegacyPool) truncateQueue() {
+	// The transactions of the priority lanes are only subject to the budgets of
+	// their lanes, leave them out of the global limit.
+	_, lanes := pool.laneCounts()
 	queued := uint64(0)
 	for _, list := range pool.queue {
 		queued += uint64(list.Len())
 	}
+	queued -= lanes
 	if queued <= pool.config.GlobalQueue {
 		return
 	}
@@ -1591,7 +1739,7 @@ func (pool *LegacyPool) truncateQueue() {
 	// Sort all accounts with queued transactions by heartbeat
 	addresses := make(addressesByHeartbeat, 0, len(pool.queue))
 	for addr := range pool.queue {
-		if !pool.locals.contains(addr) { // don't drop locals
+		if !pool.locals.contains(addr) && !pool.all.lanes.hasSender(addr) { // don't drop locals and lane senders
 			addresses = append(addresses, addressByHeartbeat{addr, pool.beats[addr]})
 		}
 	}
@@ -1604,18 +1752,13 @@ func (pool *LegacyPool) truncateQueue() {
 
 		addresses = addresses[:len(addresses)-1]
 
-		// Drop all transactions if they are less than the overflow
-		if size := uint64(list.Len()); size <= drop {
-			for _, tx := range list.Flatten() {
-				pool.removeTx(tx.Hash(), true, true)
-			}
-			drop -= size
-			queuedRateLimitMeter.Mark(int64(size))
-			continue
-		}
-		// Otherwise drop only last few transactions
+		// Drop the last few transactions, or all of them if they are less than
+		// the overflow, keeping the ones of the priority lanes
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
+			if pool.all.InLane(txs[i].Hash()) {
+				continue
+			}
 			pool.removeTx(txs[i].Hash(), true, true)
 			drop--
 			queuedRateLimitMeter.Mark(1)
@@ -1775,9 +1918,11 @@ func (as *accountSet) merge(other *accountSet) {
 // to build upper-level structure.
 type lookup struct {
 	slots   int
+	bytes   int
 	lock    sync.RWMutex
 	locals  map[common.Hash]*types.Transaction
 	remotes map[common.Hash]*types.Transaction
+	lanes   *laneSet // Transactions of the priority lanes, in neither of the sets above
 }
 
 // newLookup returns a new lookup structure.
@@ -1790,7 +1935,8 @@ func newLookup() *lookup {
 
 // Range calls f on each key and value present in the map. The callback passed
 // should return the indicator whether the iteration needs to be continued.
-// Callers need to specify which set (or both) to be iterated.
+// Callers need to specify which set (or both) to be iterated. The transactions
+// of the priority lanes are in neither set.
 func (t *lookup) Range(f func(hash common.Hash, tx *types.Transaction, local bool) bool, local bool, remote bool) {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
@@ -1819,7 +1965,13 @@ func (t *lookup) Get(hash common.Hash) *types.Transaction {
 	if tx := t.locals[hash]; tx != nil {
 		return tx
 	}
-	return t.remotes[hash]
+	if tx := t.remotes[hash]; tx != nil {
+		return tx
+	}
+	if t.lanes != nil {
+		return t.lanes.get(hash)
+	}
+	return nil
 }
 
 // GetLocal returns a transaction if it exists in the lookup, or nil if not found.
@@ -1843,7 +1995,11 @@ func (t *lookup) Count() int {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
 
-	return len(t.locals) + len(t.remotes)
+	count := len(t.locals) + len(t.remotes)
+	if t.lanes != nil {
+		count += t.lanes.count()
+	}
+	return count
 }
 
 // LocalCount returns the current number of local transactions in the lookup.
@@ -1870,6 +2026,14 @@ func (t *lookup) Slots() int {
 	return t.slots
 }
 
+// Bytes returns the current total size of the transactions in the lookup.
+func (t *lookup) Bytes() int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.bytes
+}
+
 // Add adds a transaction to the lookup.
 func (t *lookup) Add(tx *types.Transaction, local bool) {
 	t.lock.Lock()
@@ -1878,9 +2042,15 @@ func (t *lookup) Add(tx *types.Transaction, local bool) {
 	t.slots += numSlots(tx)
 	slotsGauge.Update(int64(t.slots))
 
-	if local {
+	t.bytes += int(tx.Size())
+	bytesGauge.Update(int64(t.bytes))
+
+	switch {
+	case t.lanes != nil && t.lanes.add(tx):
+		// Transaction of a priority lane, tracked by the lane only
+	case local:
 		t.locals[tx.Hash()] = tx
-	} else {
+	default:
 		t.remotes[tx.Hash()] = tx
 	}
 }
@@ -1894,6 +2064,11 @@ func (t *lookup) Remove(hash common.Hash) {
 	if !ok {
 		tx, ok = t.remotes[hash]
 	}
+	if !ok && t.lanes != nil {
+		if tx = t.lanes.get(hash); tx != nil {
+			ok = true
+		}
+	}
 	if !ok {
 		log.Error("No transaction found to be deleted", "hash", hash)
 		return
@@ -1901,8 +2076,72 @@ func (t *lookup) Remove(hash common.Hash) {
 	t.slots -= numSlots(tx)
 	slotsGauge.Update(int64(t.slots))
 
+	t.bytes -= int(tx.Size())
+	bytesGauge.Update(int64(t.bytes))
+
 	delete(t.locals, hash)
 	delete(t.remotes, hash)
+
+	if t.lanes != nil {
+		t.lanes.remove(tx)
+	}
+}
+
+// Lane returns the index of the priority lane the transaction belongs to, or
+// -1 if it doesn't belong to any.
+func (t *lookup) Lane(tx *types.Transaction) int {
+	if t.lanes == nil {
+		return -1
+	}
+	return t.lanes.match(tx) // lane configuration is immutable, no lock needed
+}
+
+// InLane reports whether the transaction is tracked by a priority lane.
+func (t *lookup) InLane(hash common.Hash) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.lanes != nil && t.lanes.get(hash) != nil
+}
+
+// LaneTxs returns the transactions of all the priority lanes.
+func (t *lookup) LaneTxs() types.Transactions {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.lanes == nil {
+		return nil
+	}
+	return t.lanes.flatten()
+}
+
+// LaneUsage returns the number of slots and bytes used by all the priority lanes.
+func (t *lookup) LaneUsage() (slots int, size int) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.lanes == nil {
+		return 0, 0
+	}
+	return t.lanes.usage()
+}
+
+// LaneOverflow returns the number of slots and bytes that need to be freed up
+// in the given priority lane to make room for the transaction.
+func (t *lookup) LaneOverflow(lane int, tx *types.Transaction) (slots int, size int) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.lanes.overflow(lane, tx)
+}
+
+// LaneCheapest returns the transactions of the given priority lane priced below
+// the transaction, excluding the ones of its sender, sorted from the cheapest.
+func (t *lookup) LaneCheapest(lane int, from common.Address, tx *types.Transaction) types.Transactions {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.lanes.cheapest(lane, from, tx)
 }
 
 // RemoteToLocals migrates the transactions belongs to the given locals to locals
@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -38,6 +39,7 @@ import (
 	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/event"
 	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/rlp"
 	"github.com/theQRL/go-zond/trie"
 )
 
@@ -52,7 +54,6 @@ var (
 
 func init() {
 	testTxPoolConfig = DefaultConfig
-	testTxPoolConfig.Journal = ""
 
 	cpy := *params.TestChainConfig
 	eip1559Config = &cpy
@@ -165,7 +166,7 @@ func setupPoolWithConfig(config *params.ChainConfig) (*LegacyPool, *dilithium.Di
 	blockchain := newTestBlockChain(config, 10000000, statedb, new(event.Feed))
 
 	key, _ := crypto.GenerateDilithiumKey()
-	pool := New(testTxPoolConfig, blockchain)
+	pool := New(testTxPoolConfig, blockchain, nil)
 	if err := pool.Init(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver()); err != nil {
 		panic(err)
 	}
@@ -281,7 +282,7 @@ func TestStateChangeDuringReset(t *testing.T) {
 	tx0 := transaction(0, 100000, key)
 	tx1 := transaction(1, 100000, key)
 
-	pool := New(testTxPoolConfig, blockchain)
+	pool := New(testTxPoolConfig, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
@@ -357,6 +358,58 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// Tests that registered key transactions are only accepted once the public key
+// of the sender is present in the registry.
+func TestRegisteredKeyTransactions(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	from := common.Address(key.GetAddress())
+	testAddBalance(pool, from, big.NewInt(0xffffffffffffff))
+
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	tx := types.MustSignNewTx(key, signer, &types.RegisteredKeyTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     0,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       100000,
+		To:        &common.Address{},
+		Value:     big.NewInt(100),
+		From:      from,
+	})
+	if err, want := pool.addRemote(tx), core.ErrPublicKeyNotRegistered; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	// Registering the key by a transaction to the registry has to be paid for
+	registry := params.PublicKeyRegistryAddress
+	reg := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     0,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       100000,
+		To:        &registry,
+		Value:     big.NewInt(0),
+	})
+	if err, want := pool.addRemote(reg), core.ErrIntrinsicGas; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	pk := key.GetPK()
+	pool.mu.Lock()
+	core.RegisterPublicKey(pool.currentState, pk[:])
+	pool.mu.Unlock()
+
+	if err := pool.addRemoteSync(tx); err != nil {
+		t.Errorf("failed to add registered key transaction: %v", err)
+	}
+	if pending, _ := pool.Stats(); pending != 1 {
+		t.Errorf("pending transactions mismatched: have %d, want %d", pending, 1)
+	}
+}
+
 func TestQueue(t *testing.T) {
 	t.Parallel()
 
@@ -708,7 +761,7 @@ func TestPostponing(t *testing.T) {
 	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
 
-	pool := New(testTxPoolConfig, blockchain)
+	pool := New(testTxPoolConfig, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
@@ -925,7 +978,7 @@ func testQueueGlobalLimiting(t *testing.T, nolocals bool) {
 	config.NoLocals = nolocals
 	config.GlobalQueue = config.AccountQueue*3 - 1 // reduce the queue limits to shorten test time (-1 to make it non divisible)
 
-	pool := New(config, blockchain)
+	pool := New(config, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
@@ -1019,7 +1072,7 @@ func testQueueTimeLimiting(t *testing.T, nolocals bool) {
 	config.Lifetime = time.Second
 	config.NoLocals = nolocals
 
-	pool := New(config, blockchain)
+	pool := New(config, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
@@ -1204,7 +1257,7 @@ func TestPendingGlobalLimiting(t *testing.T) {
 	config := testTxPoolConfig
 	config.GlobalSlots = config.AccountSlots * 10
 
-	pool := New(config, blockchain)
+	pool := New(config, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
@@ -1309,7 +1362,7 @@ func TestCapClearsFromAll(t *testing.T) {
 	config.AccountQueue = 2
 	config.GlobalSlots = 8
 
-	pool := New(config, blockchain)
+	pool := New(config, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
@@ -1342,7 +1395,7 @@ func TestPendingMinimumAllowance(t *testing.T) {
 	config := testTxPoolConfig
 	config.GlobalSlots = 1
 
-	pool := New(config, blockchain)
+	pool := New(config, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
@@ -1385,7 +1438,7 @@ func TestMinGasPriceEnforced(t *testing.T) {
 
 	txPoolConfig := DefaultConfig
 	txPoolConfig.NoLocals = true
-	pool := New(txPoolConfig, blockchain)
+	pool := New(txPoolConfig, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(txPoolConfig.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
@@ -1556,7 +1609,7 @@ func TestRepricingKeepsLocals(t *testing.T) {
 	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := newTestBlockChain(eip1559Config, 1000000, statedb, new(event.Feed))
 
-	pool := New(testTxPoolConfig, blockchain)
+	pool := New(testTxPoolConfig, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
@@ -1621,7 +1674,7 @@ func TestStableUnderpricing(t *testing.T) {
 	config.GlobalSlots = 128
 	config.GlobalQueue = 0
 
-	pool := New(config, blockchain)
+	pool := New(config, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
@@ -1785,6 +1838,217 @@ func TestUnderpricingDynamicFee(t *testing.T) {
 	}
 }
 
+// Tests that the pool is limited by the total size of its transactions too, and
+// that oversized transactions are ranked by the fee they pay per byte.
+func TestUnderpricingBytes(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupPoolWithConfig(eip1559Config)
+	defer pool.Close()
+
+	// Create a number of test accounts and fund them
+	keys := make([]*dilithium.Dilithium, 7)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = dilithium.New()
+		testAddBalance(pool, common.Address(keys[i].GetAddress()), big.NewInt(1000000000))
+	}
+	// Fill the pool up to its byte limit with small transactions
+	txs := types.Transactions{}
+	for i := 0; i < 5; i++ {
+		txs = append(txs, dynamicFeeTx(0, 100000, big.NewInt(2), big.NewInt(2), keys[i]))
+	}
+	pool.config.GlobalBytes = 5 * txs[0].Size()
+
+	for i, err := range pool.addRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	// A large transaction paying the same price per gas, but a lower fee per
+	// byte than the pooled ones must be rejected
+	tx := dynamicFeeDataTx(0, 350000, big.NewInt(2), big.NewInt(2), keys[5], 20000)
+	if err := pool.addRemote(tx); !errors.Is(err, txpool.ErrUnderpriced) {
+		t.Fatalf("adding underpriced large transaction error mismatch: have %v, want %v", err, txpool.ErrUnderpriced)
+	}
+	// A large transaction paying more per byte makes room for itself
+	tx = dynamicFeeDataTx(0, 350000, big.NewInt(4), big.NewInt(4), keys[6], 20000)
+	if err := pool.addRemoteSync(tx); err != nil {
+		t.Fatalf("failed to add well priced large transaction: %v", err)
+	}
+	if pool.Get(tx.Hash()) == nil {
+		t.Fatalf("large transaction missing from the pool")
+	}
+	if have, limit := pool.all.Bytes(), int(pool.config.GlobalBytes); have > limit {
+		t.Fatalf("pool size exceeds limit: have %d, limit %d", have, limit)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the transactions of the priority lanes are admitted within the
+// budgets of their lanes, even if the pool is full, and that they are only
+// evicted by better priced transactions of the same lane.
+func TestLanes(t *testing.T) {
+	t.Parallel()
+
+	// Create a number of test accounts and fund them
+	keys := make([]*dilithium.Dilithium, 7)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = dilithium.New()
+	}
+	var (
+		selector = []byte{0xde, 0xad, 0xbe, 0xef}
+		contract = common.BytesToAddress([]byte{0xc0, 0xde})
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(eip1559Config, 10000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.GlobalSlots = 1
+	config.GlobalQueue = 1
+	config.Lanes = []txpool.Lane{
+		{Name: "hot", Senders: []common.Address{keys[2].GetAddress()}, Slots: 1},
+		{Name: "calls", Targets: []txpool.LaneTarget{{To: contract, Selector: selector}}, Slots: 1},
+	}
+	pool := New(config, blockchain, nil)
+	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	for _, key := range keys {
+		testAddBalance(pool, key.GetAddress(), big.NewInt(1000000000))
+	}
+	call := func(key *dilithium.Dilithium, price int64, data []byte) *types.Transaction {
+		return types.MustSignNewTx(key, types.LatestSignerForChainID(params.TestChainConfig.ChainID), &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			GasTipCap: big.NewInt(price),
+			GasFeeCap: big.NewInt(price),
+			Gas:       100000,
+			To:        &contract,
+			Data:      data,
+		})
+	}
+	// Fill the pool up to its slot limit with well priced transactions
+	txs := types.Transactions{
+		dynamicFeeTx(0, 100000, big.NewInt(10), big.NewInt(10), keys[0]),
+		dynamicFeeTx(0, 100000, big.NewInt(10), big.NewInt(10), keys[1]),
+	}
+	for i, err := range pool.addRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	// Cheap transactions outside of the lanes are rejected, but the ones of the
+	// lanes are admitted within the lane budgets
+	if err := pool.addRemoteSync(call(keys[3], 1, append(common.CopyBytes(selector[:3]), 0x00))); !errors.Is(err, txpool.ErrUnderpriced) {
+		t.Fatalf("adding underpriced transaction error mismatch: have %v, want %v", err, txpool.ErrUnderpriced)
+	}
+	hot := dynamicFeeTx(0, 100000, big.NewInt(1), big.NewInt(1), keys[2])
+	if err := pool.addRemoteSync(hot); err != nil {
+		t.Fatalf("failed to add sender lane transaction: %v", err)
+	}
+	cheap := call(keys[4], 2, append(common.CopyBytes(selector), 0x01))
+	if err := pool.addRemoteSync(cheap); err != nil {
+		t.Fatalf("failed to add call lane transaction: %v", err)
+	}
+	// Once a lane is full, it only makes room for better priced transactions
+	// of the same lane
+	if err := pool.addRemoteSync(call(keys[5], 1, selector)); !errors.Is(err, ErrLaneOverflow) {
+		t.Fatalf("adding underpriced lane transaction error mismatch: have %v, want %v", err, ErrLaneOverflow)
+	}
+	better := call(keys[6], 3, selector)
+	if err := pool.addRemoteSync(better); err != nil {
+		t.Fatalf("failed to add better priced lane transaction: %v", err)
+	}
+	for _, tx := range append(txs, hot, better) {
+		if pool.Get(tx.Hash()) == nil {
+			t.Errorf("transaction %x missing from the pool", tx.Hash())
+		}
+	}
+	if pool.Get(cheap.Hash()) != nil {
+		t.Errorf("evicted lane transaction still in the pool")
+	}
+	if slots, _ := pool.all.LaneUsage(); slots != 2 {
+		t.Errorf("lane slots mismatch: have %d, want %d", slots, 2)
+	}
+	// The lane transactions are neither local nor remote
+	for _, tx := range []*types.Transaction{hot, better} {
+		if pool.all.GetLocal(tx.Hash()) != nil || pool.all.GetRemote(tx.Hash()) != nil || !pool.all.InLane(tx.Hash()) {
+			t.Errorf("lane transaction %x not tracked by its lane only", tx.Hash())
+		}
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the transactions of the priority lanes are not counted against the
+// global pending and queue limits when the pool is truncated.
+func TestLaneTruncation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		selector = []byte{0xde, 0xad, 0xbe, 0xef}
+		contract = common.BytesToAddress([]byte{0xc0, 0xde})
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(eip1559Config, 10000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.AccountSlots = 1
+	config.GlobalSlots = 2
+	config.GlobalQueue = 1
+	config.Lanes = []txpool.Lane{
+		{Name: "calls", Targets: []txpool.LaneTarget{{To: contract, Selector: selector}}, Slots: 2},
+	}
+	pool := New(config, blockchain, nil)
+	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	pendingKey, _ := crypto.GenerateDilithiumKey()
+	queuedKey, _ := crypto.GenerateDilithiumKey()
+	testAddBalance(pool, pendingKey.GetAddress(), big.NewInt(1000000000))
+	testAddBalance(pool, queuedKey.GetAddress(), big.NewInt(1000000000))
+
+	call := func(key *dilithium.Dilithium, nonce uint64) *types.Transaction {
+		return types.MustSignNewTx(key, types.LatestSignerForChainID(params.TestChainConfig.ChainID), &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(1),
+			Gas:       100000,
+			To:        &contract,
+			Data:      selector,
+		})
+	}
+	// Fill the pending and queued limits with plain transactions, and add one
+	// lane transaction on top of each of them
+	txs := types.Transactions{
+		transaction(0, 100000, pendingKey),
+		transaction(1, 100000, pendingKey),
+		call(pendingKey, 2),
+		call(queuedKey, 1),
+		transaction(2, 100000, queuedKey),
+	}
+	for i, err := range pool.addRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	pending, queued := pool.Stats()
+	if pending != 3 || queued != 2 {
+		t.Fatalf("pool stats mismatched: have %d/%d, want %d/%d", pending, queued, 3, 2)
+	}
+	for i, tx := range txs {
+		if pool.Get(tx.Hash()) == nil {
+			t.Errorf("transaction %d truncated from the pool", i)
+		}
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Tests whether highest fee cap transaction is retained after a batch of high effective
 // tip transactions are added and vice versa
 func TestDualHeapEviction(t *testing.T) {
@@ -1850,7 +2114,7 @@ func TestDeduplication(t *testing.T) {
 	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
 
-	pool := New(testTxPoolConfig, blockchain)
+	pool := New(testTxPoolConfig, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
@@ -2018,36 +2282,23 @@ func TestReplacementDynamicFee(t *testing.T) {
 	}
 }
 
-// Tests that local transactions are journaled to disk, but remote transactions
+// Tests that local transactions are persisted to disk, but remote transactions
 // get discarded between restarts.
-func TestJournaling(t *testing.T)         { testJournaling(t, false) }
-func TestJournalingNoLocals(t *testing.T) { testJournaling(t, true) }
+func TestLocalStore(t *testing.T)         { testLocalStore(t, false) }
+func TestLocalStoreNoLocals(t *testing.T) { testLocalStore(t, true) }
 
-func testJournaling(t *testing.T, nolocals bool) {
+func testLocalStore(t *testing.T, nolocals bool) {
 	t.Parallel()
 
-	// Create a temporary file for the journal
-	file, err := os.CreateTemp("", "")
-	if err != nil {
-		t.Fatalf("failed to create temporary journal: %v", err)
-	}
-	journal := file.Name()
-	defer os.Remove(journal)
-
-	// Clean up the temporary file, we only need the path for now
-	file.Close()
-	os.Remove(journal)
-
-	// Create the original pool to inject transaction into the journal
+	// Create the original pool to inject transaction into the store
+	db := rawdb.NewMemoryDatabase()
 	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
 
 	config := testTxPoolConfig
 	config.NoLocals = nolocals
-	config.Journal = journal
-	config.Rejournal = time.Second
 
-	pool := New(config, blockchain)
+	pool := New(config, blockchain, db)
 	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 
 	// Create two test accounts to ensure remotes expire but locals do not
@@ -2080,12 +2331,24 @@ func testJournaling(t *testing.T, nolocals bool) {
 	if err := validatePoolInternals(pool); err != nil {
 		t.Fatalf("pool internal state corrupted: %v", err)
 	}
+	// Ensure the local transactions are persisted right away, without waiting
+	// for the pool to be closed
+	stored := rawdb.ReadLocalTransactions(db)
+	if nolocals {
+		if len(stored) != 0 {
+			t.Fatalf("stored accounts mismatched: have %d, want %d", len(stored), 0)
+		}
+	} else {
+		if len(stored) != 1 || len(stored[local.GetAddress()]) != 3 {
+			t.Fatalf("stored transactions mismatched: have %v, want %d of %x", stored, 3, local.GetAddress())
+		}
+	}
 	// Terminate the old pool, bump the local nonce, create a new pool and ensure relevant transaction survive
 	pool.Close()
 	statedb.SetNonce(local.GetAddress(), 1)
 	blockchain = newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
 
-	pool = New(config, blockchain)
+	pool = New(config, blockchain, db)
 	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 
 	pending, queued = pool.Stats()
@@ -2107,12 +2370,14 @@ func testJournaling(t *testing.T, nolocals bool) {
 	// Bump the nonce temporarily and ensure the newly invalidated transaction is removed
 	statedb.SetNonce(local.GetAddress(), 2)
 	<-pool.requestReset(nil, nil)
-	time.Sleep(2 * config.Rejournal)
+	if stored := rawdb.ReadLocalTransactions(db); !nolocals && len(stored[local.GetAddress()]) != 1 {
+		t.Fatalf("stored transactions mismatched: have %d, want %d", len(stored[local.GetAddress()]), 1)
+	}
 	pool.Close()
 
 	statedb.SetNonce(local.GetAddress(), 1)
 	blockchain = newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
-	pool = New(config, blockchain)
+	pool = New(config, blockchain, db)
 	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 
 	pending, queued = pool.Stats()
@@ -2131,9 +2396,121 @@ func testJournaling(t *testing.T, nolocals bool) {
 	if err := validatePoolInternals(pool); err != nil {
 		t.Fatalf("pool internal state corrupted: %v", err)
 	}
+	// Drop the remaining local transaction and ensure it's gone from disk too
+	if !nolocals {
+		txs := pool.LocalTransactions()[local.GetAddress()]
+		if len(txs) != 1 {
+			t.Fatalf("local transactions mismatched: have %d, want %d", len(txs), 1)
+		}
+		if !pool.DropLocal(txs[0].Hash()) {
+			t.Fatalf("failed to drop local transaction")
+		}
+		if stored := rawdb.ReadLocalTransactions(db); len(stored) != 0 {
+			t.Fatalf("stored accounts mismatched: have %d, want %d", len(stored), 0)
+		}
+	}
 	pool.Close()
 }
 
+// Tests that dropping a local transaction removes it from the pool and from
+// disk, and demotes the later transactions of the account.
+func TestDropLocal(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	pool := New(testTxPoolConfig, blockchain, db)
+	pool.Init(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	local, _ := crypto.GenerateDilithiumKey()
+	remote, _ := crypto.GenerateDilithiumKey()
+	testAddBalance(pool, local.GetAddress(), big.NewInt(1000000000))
+	testAddBalance(pool, remote.GetAddress(), big.NewInt(1000000000))
+
+	txs := []*types.Transaction{transaction(0, 100000, local), transaction(1, 100000, local), transaction(2, 100000, local)}
+	for _, err := range pool.addLocals(txs) {
+		if err != nil {
+			t.Fatalf("failed to add local transaction: %v", err)
+		}
+	}
+	remoteTx := transaction(0, 100000, remote)
+	if err := pool.addRemoteSync(remoteTx); err != nil {
+		t.Fatalf("failed to add remote transaction: %v", err)
+	}
+	// Only the tracked local transactions can be dropped
+	if pool.DropLocal(remoteTx.Hash()) {
+		t.Fatalf("remote transaction dropped")
+	}
+	if pool.DropLocal(common.Hash{0x01}) {
+		t.Fatalf("unknown transaction dropped")
+	}
+	if !pool.DropLocal(txs[1].Hash()) {
+		t.Fatalf("failed to drop local transaction")
+	}
+	if pool.Get(txs[1].Hash()) != nil {
+		t.Fatalf("dropped transaction still in the pool")
+	}
+	// The transaction after the gap is moved to the queue, but kept on disk
+	pending, queued := pool.Stats()
+	if pending != 2 || queued != 1 {
+		t.Fatalf("pool stats mismatched: have %d/%d, want %d/%d", pending, queued, 2, 1)
+	}
+	stored := rawdb.ReadLocalTransactions(db)[local.GetAddress()]
+	if len(stored) != 2 || stored[0].Hash() != txs[0].Hash() || stored[1].Hash() != txs[2].Hash() {
+		t.Fatalf("stored transactions mismatched: have %d, want %d", len(stored), 2)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the transactions of a legacy journal are imported as locals into
+// the database, and that the journal is deleted afterwards.
+func TestImportJournal(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	pool := New(testTxPoolConfig, blockchain, db)
+	pool.Init(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	key, _ := crypto.GenerateDilithiumKey()
+	testAddBalance(pool, key.GetAddress(), big.NewInt(1000000000))
+
+	// Write a journal with two transactions in the legacy format
+	path := filepath.Join(t.TempDir(), LegacyJournal)
+	var journal []byte
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		enc, _ := rlp.EncodeToBytes(transaction(nonce, 100000, key))
+		journal = append(journal, enc...)
+	}
+	if err := os.WriteFile(path, journal, 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+	if err := pool.ImportJournal(path); err != nil {
+		t.Fatalf("failed to import journal: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("journal not deleted: %v", err)
+	}
+	if pending, _ := pool.Stats(); pending != 2 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 2)
+	}
+	if stored := rawdb.ReadLocalTransactions(db)[key.GetAddress()]; len(stored) != 2 {
+		t.Fatalf("stored transactions mismatched: have %d, want %d", len(stored), 2)
+	}
+	// A missing journal is not an error
+	if err := pool.ImportJournal(path); err != nil {
+		t.Fatalf("failed to skip missing journal: %v", err)
+	}
+}
+
 // TestStatusCheck tests that the pool can correctly retrieve the
 // pending status of individual transactions.
 func TestStatusCheck(t *testing.T) {
@@ -2143,7 +2520,7 @@ func TestStatusCheck(t *testing.T) {
 	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
 
-	pool := New(testTxPoolConfig, blockchain)
+	pool := New(testTxPoolConfig, blockchain, nil)
 	pool.Init(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
 	defer pool.Close()
 
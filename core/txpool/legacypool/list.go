@@ -28,6 +28,7 @@ import (
 
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/params"
 )
 
 // nonceHeap is a heap.Interface implementation over 64bit unsigned integers for
@@ -462,10 +463,41 @@ func (l *list) subTotalCost(txs []*types.Transaction) {
 	}
 }
 
+// txByteGas is the amount of gas a byte of transaction data is valued at when
+// ranking transactions for eviction. It matches the cost of a non-zero byte of
+// calldata, so that transactions occupying more pool space than gas they pay
+// for are ranked by the fee they pay per byte instead of per gas.
+const txByteGas = params.TxDataNonZeroGasEIP2028
+
+// pricingUnits returns the amount of resources a transaction is ranked against
+// for eviction: the larger of its gas limit and its size valued in gas.
+func pricingUnits(tx *types.Transaction) uint64 {
+	if units := tx.Size() * txByteGas; units > tx.Gas() {
+		return units
+	}
+	return tx.Gas()
+}
+
+// weightedCmp compares the per gas prices of two transactions, scaled by the
+// ratio of gas to pricing units of each. For transactions limited by gas it is
+// the plain price comparison, for oversized ones it compares the fee per byte.
+func weightedCmp(a *types.Transaction, aPrice *big.Int, b *types.Transaction, bPrice *big.Int) int {
+	x := new(big.Int).Mul(aPrice, new(big.Int).SetUint64(a.Gas()))
+	x.Mul(x, new(big.Int).SetUint64(pricingUnits(b)))
+
+	y := new(big.Int).Mul(bPrice, new(big.Int).SetUint64(b.Gas()))
+	y.Mul(y, new(big.Int).SetUint64(pricingUnits(a)))
+
+	return x.Cmp(y)
+}
+
 // priceHeap is a heap.Interface implementation over transactions for retrieving
 // price-sorted transactions to discard when the pool fills up. If baseFee is set
 // then the heap is sorted based on the effective tip based on the given base fee.
 // If baseFee is nil then the sorting is based on gasFeeCap.
+//
+// Prices are weighted by the size of the transactions, see weightedCmp, so the
+// eviction accounts for the pool space consumed as well as for the gas paid.
 type priceHeap struct {
 	baseFee *big.Int // heap should always be re-sorted after baseFee is changed
 	list    []*types.Transaction
@@ -488,16 +520,16 @@ func (h *priceHeap) Less(i, j int) bool {
 func (h *priceHeap) cmp(a, b *types.Transaction) int {
 	if h.baseFee != nil {
 		// Compare effective tips if baseFee is specified
-		if c := a.EffectiveGasTipCmp(b, h.baseFee); c != 0 {
+		if c := weightedCmp(a, a.EffectiveGasTipValue(h.baseFee), b, b.EffectiveGasTipValue(h.baseFee)); c != 0 {
 			return c
 		}
 	}
 	// Compare fee caps if baseFee is not specified or effective tips are equal
-	if c := a.GasFeeCapCmp(b); c != 0 {
+	if c := weightedCmp(a, a.GasFeeCap(), b, b.GasFeeCap()); c != 0 {
 		return c
 	}
 	// Compare tips if effective tips and fee caps are equal
-	return a.GasTipCapCmp(b)
+	return weightedCmp(a, a.GasTipCap(), b, b.GasTipCap())
 }
 
 func (h *priceHeap) Push(x interface{}) {
@@ -549,7 +581,9 @@ func newPricedList(all *lookup) *pricedList {
 
 // Put inserts a new transaction into the heap.
 func (l *pricedList) Put(tx *types.Transaction, local bool) {
-	if local {
+	// Local transactions and the ones of the priority lanes are never evicted
+	// by price, don't track them.
+	if local || l.all.InLane(tx.Hash()) {
 		return
 	}
 	// Insert every new transaction to the urgent heap first; Discard will balance the heaps
@@ -602,13 +636,14 @@ func (l *pricedList) underpricedFor(h *priceHeap, tx *types.Transaction) bool {
 }
 
 // Discard finds a number of most underpriced transactions, removes them from the
-// priced list and returns them for further removal from the entire pool.
-// If noPending is set to true, we will only consider the floating list
+// priced list and returns them for further removal from the entire pool. The
+// transactions are discarded until both the requested number of slots and bytes
+// are freed up.
 //
 // Note local transaction won't be considered for eviction.
-func (l *pricedList) Discard(slots int, force bool) (types.Transactions, bool) {
-	drop := make(types.Transactions, 0, slots) // Remote underpriced transactions to drop
-	for slots > 0 {
+func (l *pricedList) Discard(slots int, size int, force bool) (types.Transactions, bool) {
+	drop := make(types.Transactions, 0, max(slots, 1)) // Remote underpriced transactions to drop
+	for slots > 0 || size > 0 {
 		if len(l.urgent.list)*floatingRatio > len(l.floating.list)*urgentRatio {
 			// Discard stale transactions if found during cleanup
 			tx := heap.Pop(&l.urgent).(*types.Transaction)
@@ -632,10 +667,11 @@ func (l *pricedList) Discard(slots int, force bool) (types.Transactions, bool) {
 			// Non stale transaction found, discard it
 			drop = append(drop, tx)
 			slots -= numSlots(tx)
+			size -= int(tx.Size())
 		}
 	}
 	// If we still can't make enough room for the new transaction
-	if slots > 0 && !force {
+	if (slots > 0 || size > 0) && !force {
 		for _, tx := range drop {
 			heap.Push(&l.urgent, tx)
 		}
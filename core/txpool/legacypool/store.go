@@ -0,0 +1,146 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/zonddb"
+)
+
+// txStore is a persistent store of locally created transactions with the aim
+// of allowing non-executed ones to survive node restarts. The transactions are
+// kept in the key-value database of the node, keyed by sender and nonce, and
+// every change is written out as soon as it's made, so nothing accepted by the
+// pool is lost on a crash.
+//
+// Note, the store is not thread safe, it relies on the pool lock.
+type txStore struct {
+	db  zonddb.KeyValueStore
+	txs map[common.Address]map[uint64]common.Hash // Hashes of the stored transactions by sender and nonce
+}
+
+// newTxStore creates a new local transaction store on top of the given database.
+func newTxStore(db zonddb.KeyValueStore) *txStore {
+	return &txStore{
+		db:  db,
+		txs: make(map[common.Address]map[uint64]common.Hash),
+	}
+}
+
+// load reads the stored transactions from the database and injects them into
+// the pool, account by account in nonce order. The transactions rejected by the
+// pool are dropped from the store.
+func (store *txStore) load(add func([]*types.Transaction) []error) {
+	var (
+		stored = rawdb.ReadLocalTransactions(store.db)
+		total  int
+		batch  types.Transactions
+		owners []common.Address
+	)
+	for addr, txs := range stored {
+		nonces := make(map[uint64]common.Hash, len(txs))
+		for _, tx := range txs {
+			nonces[tx.Nonce()] = tx.Hash()
+		}
+		store.txs[addr] = nonces
+		total += len(txs)
+	}
+	// Create a method to load a limited batch of transactions, dropping the
+	// failed ones from the store. Then use this method to load all the stored
+	// transactions in small-ish batches.
+	var dropped int
+	loadBatch := func() {
+		for i, err := range add(batch) {
+			if err != nil {
+				log.Debug("Failed to add stored transaction", "hash", batch[i].Hash(), "err", err)
+				store.remove(owners[i], batch[i])
+				dropped++
+			}
+		}
+		batch, owners = batch[:0], owners[:0]
+	}
+	for addr, txs := range stored {
+		for _, tx := range txs {
+			batch, owners = append(batch, tx), append(owners, addr)
+			if batch.Len() >= 1024 {
+				loadBatch()
+			}
+		}
+	}
+	if batch.Len() > 0 {
+		loadBatch()
+	}
+	log.Info("Loaded local transactions", "transactions", total, "dropped", dropped)
+}
+
+// insert adds the specified transaction of the given sender to the store,
+// replacing any previous one with the same nonce.
+func (store *txStore) insert(from common.Address, tx *types.Transaction) {
+	nonces := store.txs[from]
+	if nonces == nil {
+		nonces = make(map[uint64]common.Hash)
+		store.txs[from] = nonces
+	}
+	if hash, ok := nonces[tx.Nonce()]; ok && hash == tx.Hash() {
+		return
+	}
+	rawdb.WriteLocalTransaction(store.db, from, tx)
+	nonces[tx.Nonce()] = tx.Hash()
+}
+
+// remove deletes the specified transaction of the given sender from the store,
+// if it's the one stored for its nonce.
+func (store *txStore) remove(from common.Address, tx *types.Transaction) {
+	nonces := store.txs[from]
+	if hash, ok := nonces[tx.Nonce()]; !ok || hash != tx.Hash() {
+		return
+	}
+	rawdb.DeleteLocalTransaction(store.db, from, tx.Nonce())
+	if delete(nonces, tx.Nonce()); len(nonces) == 0 {
+		delete(store.txs, from)
+	}
+}
+
+// sync drops all the stored transactions which are not tracked by the pool any
+// more, such as the included or the evicted ones.
+func (store *txStore) sync(all map[common.Address]types.Transactions) {
+	batch := store.db.NewBatch()
+	for addr, nonces := range store.txs {
+		tracked := make(map[common.Hash]struct{}, len(all[addr]))
+		for _, tx := range all[addr] {
+			tracked[tx.Hash()] = struct{}{}
+		}
+		for nonce, hash := range nonces {
+			if _, ok := tracked[hash]; !ok {
+				rawdb.DeleteLocalTransaction(batch, addr, nonce)
+				delete(nonces, nonce)
+			}
+		}
+		if len(nonces) == 0 {
+			delete(store.txs, addr)
+		}
+	}
+	if batch.ValueSize() == 0 {
+		return
+	}
+	if err := batch.Write(); err != nil {
+		log.Warn("Failed to sync local transactions", "err", err)
+	}
+}
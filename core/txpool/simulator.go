@@ -0,0 +1,316 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/misc/eip1559"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/event"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/metrics"
+	"github.com/theQRL/go-zond/params"
+)
+
+const (
+	// simulationGasBlocks is the number of blocks worth of gas up to which the
+	// pending transactions are simulated on top of a head. The transactions
+	// beyond it can't be included in the next block anyway and are not
+	// predicted.
+	simulationGasBlocks = 2
+
+	// simulationMaxTxs is the maximum number of pending transactions simulated
+	// on top of a head.
+	simulationMaxTxs = 4096
+)
+
+var (
+	simulatedMeter = metrics.NewRegisteredMeter("txpool/simulation/executed", nil)
+	revertedMeter  = metrics.NewRegisteredMeter("txpool/simulation/reverted", nil)
+	invalidMeter   = metrics.NewRegisteredMeter("txpool/simulation/invalid", nil)
+)
+
+// SimulatorChain defines the minimal set of methods needed to execute the
+// pending transactions of the pool on top of the chain head.
+type SimulatorChain interface {
+	BlockChain
+	core.ChainContext
+
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
+
+	// StateAt returns a state database for a given root hash (generally the head).
+	StateAt(root common.Hash) (*state.StateDB, error)
+}
+
+// Prediction is the outcome of a pending transaction predicted by executing it
+// on top of the chain head, after the pending transactions of the same account
+// with lower nonces and the ones of the other accounts simulated before it.
+type Prediction struct {
+	Number   uint64 // Number of the block the transaction was simulated in
+	Reverted bool   // Whether the execution of the transaction reverted
+	GasUsed  uint64 // Gas used by the transaction, zero if it's not executable
+	Error    string // Revert reason or the reason the transaction is not executable
+}
+
+// Executable reports whether the transaction could be included in the block it
+// was simulated in.
+func (p *Prediction) Executable() bool {
+	return p.Reverted || p.Error == ""
+}
+
+// simulator executes the pending transactions of the pool against the pending
+// state whenever the pool is reset to a new head, and the newly promoted ones
+// on top of the previously simulated transactions in between.
+type simulator struct {
+	chain SimulatorChain
+	pool  *TxPool
+
+	predictions map[common.Hash]*Prediction // Predicted outcomes of the pending transactions
+	lock        sync.RWMutex                // Lock protecting the predictions
+
+	heads chan *types.Header // Notification channel of the finished pool resets
+	quit  chan struct{}      // Termination channel to stop the simulation loop
+	wg    sync.WaitGroup
+}
+
+// newSimulator creates a pending transaction simulator for the given pool.
+func newSimulator(chain SimulatorChain, pool *TxPool) *simulator {
+	return &simulator{
+		chain:       chain,
+		pool:        pool,
+		predictions: make(map[common.Hash]*Prediction),
+		heads:       make(chan *types.Header, 1),
+		quit:        make(chan struct{}),
+	}
+}
+
+// start launches the simulation loop and simulates the transactions already
+// in the pool on top of the current head.
+func (s *simulator) start() {
+	s.wg.Add(1)
+	go s.loop()
+
+	s.reset(s.chain.CurrentBlock())
+}
+
+// reset notifies the simulator of the pool being reset to a new head. If the
+// previous notification was not yet consumed, it's replaced by the new one.
+func (s *simulator) reset(head *types.Header) {
+	for {
+		select {
+		case s.heads <- head:
+			return
+		default:
+		}
+		select {
+		case <-s.heads:
+		default:
+		}
+	}
+}
+
+// close stops the simulation loop and waits for it to finish.
+func (s *simulator) close() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+// prediction returns the predicted outcome of the pending transaction with the
+// given hash, nil if it was not simulated.
+func (s *simulator) prediction(hash common.Hash) *Prediction {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.predictions[hash]
+}
+
+// loop is the simulator's main event loop, re-simulating the whole pending set
+// on new heads and extending the simulation with the promoted transactions.
+func (s *simulator) loop() {
+	defer s.wg.Done()
+
+	var (
+		txsCh = make(chan core.NewTxsEvent, 1024)
+		txSub event.Subscription
+		env   *simulation
+	)
+	txSub = s.pool.SubscribeTransactions(txsCh)
+	defer txSub.Unsubscribe()
+
+	for {
+		select {
+		case head := <-s.heads:
+			env = s.simulate(head)
+
+		case ev := <-txsCh:
+			if env == nil {
+				continue
+			}
+			predictions := make(map[common.Hash]*Prediction, len(ev.Txs))
+			for _, tx := range ev.Txs {
+				if env.full() {
+					break
+				}
+				from, err := types.Sender(env.signer, tx)
+				if err != nil || env.state.GetNonce(from) != tx.Nonce() {
+					continue
+				}
+				predictions[tx.Hash()] = env.apply(tx)
+			}
+			s.lock.Lock()
+			for hash, prediction := range predictions {
+				s.predictions[hash] = prediction
+			}
+			s.lock.Unlock()
+
+		case <-txSub.Err():
+			return
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// simulate executes the pending transactions of the pool on top of the given
+// head, up to the simulation budget, and replaces the predictions with their
+// outcomes. The simulation environment is returned for extending it with the
+// subsequent transactions.
+func (s *simulator) simulate(head *types.Header) *simulation {
+	statedb, err := s.chain.StateAt(head.Root)
+	if err != nil {
+		log.Warn("Failed to retrieve state for transaction simulation", "number", head.Number, "hash", head.Hash(), "err", err)
+		return nil
+	}
+	env := newSimulation(s.chain, head, statedb)
+
+	predictions := make(map[common.Hash]*Prediction)
+	for _, txs := range s.pool.Pending(PendingFilter{}) {
+		for _, ltx := range txs {
+			if env.full() {
+				break
+			}
+			tx := ltx.Resolve()
+			if tx == nil {
+				break
+			}
+			prediction := env.apply(tx)
+			predictions[tx.Hash()] = prediction
+
+			// The subsequent transactions of a non-executable one can't be
+			// executed either, stop with the account.
+			if !prediction.Executable() {
+				break
+			}
+		}
+	}
+	s.lock.Lock()
+	s.predictions = predictions
+	s.lock.Unlock()
+
+	return env
+}
+
+// simulation is the pending block the transactions are simulated in.
+type simulation struct {
+	config *params.ChainConfig
+	header *types.Header
+	signer types.Signer
+	state  *state.StateDB
+	ctx    vm.BlockContext
+
+	gasUsed uint64 // Total gas used by the simulated transactions
+	count   int    // Number of simulated transactions
+}
+
+// newSimulation creates the pending block on top of the given head.
+func newSimulation(chain SimulatorChain, head *types.Header, statedb *state.StateDB) *simulation {
+	config := chain.Config()
+	header := &types.Header{
+		ParentHash: head.Hash(),
+		Number:     new(big.Int).Add(head.Number, common.Big1),
+		GasLimit:   head.GasLimit,
+		Time:       head.Time + 1,
+		Random:     head.Random,
+		BaseFee:    eip1559.CalcBaseFee(config, head),
+	}
+	// The blob transactions are priced by the blob fee of the pending block
+	if config.IsCancun(header.Number, header.Time) {
+		var excessBlobGas uint64
+		if head.ExcessBlobGas != nil {
+			excessBlobGas = eip1559.CalcExcessBlobGas(*head.ExcessBlobGas, *head.BlobGasUsed)
+		}
+		header.BlobGasUsed = new(uint64)
+		header.ExcessBlobGas = &excessBlobGas
+	}
+	return &simulation{
+		config: config,
+		header: header,
+		signer: types.MakeSigner(config, header.Number, header.Time),
+		state:  statedb,
+		ctx:    core.NewZVMBlockContext(header, chain, &common.Address{}),
+	}
+}
+
+// full reports whether the simulation budget is used up.
+func (env *simulation) full() bool {
+	return env.count >= simulationMaxTxs || env.gasUsed >= simulationGasBlocks*env.header.GasLimit
+}
+
+// apply executes the transaction on top of the simulation state and predicts
+// its outcome. Each transaction is given the whole block gas limit, as its
+// position in the block to be mined is not known.
+func (env *simulation) apply(tx *types.Transaction) *Prediction {
+	prediction := &Prediction{Number: env.header.Number.Uint64()}
+	env.count++
+
+	msg, err := core.TransactionToMessage(tx, env.signer, env.header.BaseFee)
+	if err != nil {
+		invalidMeter.Mark(1)
+		prediction.Error = err.Error()
+		return prediction
+	}
+	var (
+		snap = env.state.Snapshot()
+		zvm  = vm.NewZVM(env.ctx, core.NewZVMTxContext(msg), env.state, env.config, vm.Config{})
+	)
+	env.state.SetTxContext(tx.Hash(), 0)
+	result, err := core.ApplyMessage(zvm, msg, new(core.GasPool).AddGas(env.header.GasLimit))
+	if err != nil {
+		env.state.RevertToSnapshot(snap)
+		invalidMeter.Mark(1)
+		prediction.Error = err.Error()
+		return prediction
+	}
+	env.state.Finalise(true)
+	simulatedMeter.Mark(1)
+
+	prediction.GasUsed = result.UsedGas
+	env.gasUsed += result.UsedGas
+	if result.Failed() {
+		revertedMeter.Mark(1)
+		prediction.Reverted = true
+		prediction.Error = result.Err.Error()
+	}
+	return prediction
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/txpool/blobpool"
+	"github.com/theQRL/go-zond/core/txpool/legacypool"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/blobcommit"
+	"github.com/theQRL/go-zond/params"
+)
+
+var (
+	testKey, _ = crypto.GenerateDilithiumKey()
+	blobKey, _ = crypto.GenerateDilithiumKey()
+)
+
+// newTestPool creates a transaction pool with a legacy and a blob subpool on top
+// of a fresh Cancun chain, and enables the pending transaction simulation.
+func newTestPool(t *testing.T, gasLimit uint64) *txpool.TxPool {
+	t.Helper()
+
+	gspec := &core.Genesis{
+		Config:   params.TestChainConfig,
+		GasLimit: gasLimit,
+		Alloc: core.GenesisAlloc{
+			testKey.GetAddress(): {Balance: big.NewInt(params.Ether)},
+			blobKey.GetAddress(): {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	t.Cleanup(chain.Stop)
+
+	subpools := []txpool.SubPool{
+		legacypool.New(legacypool.DefaultConfig, chain, nil),
+		blobpool.New(blobpool.Config{Datadir: t.TempDir()}, chain),
+	}
+	pool, err := txpool.New(big.NewInt(1), chain, subpools)
+	if err != nil {
+		t.Fatalf("failed to create transaction pool: %v", err)
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	pool.EnableSimulation(chain)
+	return pool
+}
+
+// transfer creates a value transfer from the test account.
+func transfer(nonce uint64) *types.Transaction {
+	return types.MustSignNewTx(testKey, types.LatestSigner(params.TestChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     nonce,
+		To:        &common.Address{0x01},
+		Value:     big.NewInt(1),
+		Gas:       params.TxGas,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(10 * params.GWei),
+	})
+}
+
+// waitPrediction waits for the transaction to be simulated.
+func waitPrediction(t *testing.T, pool *txpool.TxPool, hash common.Hash) *txpool.Prediction {
+	t.Helper()
+
+	for i := 0; i < 100; i++ {
+		if prediction := pool.Prediction(hash); prediction != nil {
+			return prediction
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("transaction %x not simulated", hash)
+	return nil
+}
+
+// Tests that the blob transactions are simulated with the blob fee of the
+// pending block.
+func TestSimulateBlobTx(t *testing.T) {
+	pool := newTestPool(t, params.GenesisGasLimit)
+
+	blob := blobcommit.Blob{}
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       []blobcommit.Blob{blob},
+		Commitments: []blobcommit.Commitment{blobcommit.BlobToCommitment(&blob)},
+	}
+	blobTx := types.MustSignNewTx(blobKey, types.LatestSigner(params.TestChainConfig), &types.BlobTx{
+		ChainID:    params.TestChainConfig.ChainID,
+		To:         common.Address{0x01},
+		Value:      new(big.Int),
+		Gas:        params.TxGas,
+		GasTipCap:  big.NewInt(params.GWei),
+		GasFeeCap:  big.NewInt(10 * params.GWei),
+		BlobFeeCap: big.NewInt(params.GWei),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+	txs := []*types.Transaction{transfer(0), blobTx}
+	for i, err := range pool.Add(txs, false, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	for i, tx := range txs {
+		prediction := waitPrediction(t, pool, tx.Hash())
+		if !prediction.Executable() || prediction.Reverted || prediction.GasUsed != params.TxGas {
+			t.Errorf("transaction %d prediction mismatch: %+v", i, prediction)
+		}
+	}
+}
+
+// Tests that the pending transactions are only simulated up to the gas budget
+// of the simulation.
+func TestSimulationBudget(t *testing.T) {
+	// The budget covers two blocks of three transfers each
+	pool := newTestPool(t, 3*params.TxGas)
+
+	txs := make([]*types.Transaction, 8)
+	for i := range txs {
+		txs[i] = transfer(uint64(i))
+	}
+	for i, err := range pool.Add(txs, false, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	waitPrediction(t, pool, txs[5].Hash())
+
+	// Leave some time to the simulator to go over its budget, if it would
+	time.Sleep(50 * time.Millisecond)
+	for i, tx := range txs {
+		if simulated := pool.Prediction(tx.Hash()) != nil; simulated != (i < 6) {
+			t.Errorf("transaction %d simulation mismatch: have %v, want %v", i, simulated, i < 6)
+		}
+	}
+}
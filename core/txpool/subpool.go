@@ -38,7 +38,8 @@ type LazyTransaction struct {
 	GasFeeCap *big.Int  // Maximum fee per gas the transaction may consume
 	GasTipCap *big.Int  // Maximum miner tip per gas the transaction can pay
 
-	Gas uint64 // Amount of gas required by the transaction
+	Gas     uint64 // Amount of gas required by the transaction
+	BlobGas uint64 // Amount of blob gas required by the transaction
 }
 
 // Resolve retrieves the full transaction belonging to a lazy handle if it is still
@@ -77,6 +78,10 @@ type AddressReserver func(addr common.Address, reserve bool) error
 type PendingFilter struct {
 	MinTip  *big.Int // Minimum miner tip required to include a transaction
 	BaseFee *big.Int // Minimum 1559 basefee needed to include a transaction
+	BlobFee *big.Int // Minimum blob basefee needed to include a blob transaction
+
+	OnlyPlainTxs bool // Return only plain ZVM transactions (peer-join announces, block space filling)
+	OnlyBlobTxs  bool // Return only blob transactions (block blob-space filling)
 }
 
 // SubPool represents a specialized transaction pool that lives on its own (e.g.
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/core"
@@ -75,6 +76,8 @@ type TxPool struct {
 	term chan struct{}           // Termination channel to detect a closed pool
 
 	sync chan chan error // Testing / simulator channel to block until internal reset is done
+
+	simulator atomic.Pointer[simulator] // Pending transaction simulator, nil if disabled
 }
 
 // New creates a new transaction pool to gather, sort and filter inbound
@@ -154,6 +157,10 @@ func (p *TxPool) reserver(id int, subpool SubPool) AddressReserver {
 func (p *TxPool) Close() error {
 	var errs []error
 
+	// Stop the pending transaction simulation if enabled
+	if sim := p.simulator.Load(); sim != nil {
+		sim.close()
+	}
 	// Terminate the reset loop and wait for it to finish
 	errc := make(chan error)
 	p.quit <- errc
@@ -250,6 +257,11 @@ func (p *TxPool) loop(head *types.Header, chain BlockChain) {
 			oldHead = head
 			<-resetBusy
 
+			// Re-simulate the pending transactions on top of the new head
+			if sim := p.simulator.Load(); sim != nil {
+				sim.reset(head)
+			}
+
 			// If someone is waiting for a reset to finish, notify them, unless
 			// the forced op is still pending. In that case, wait another round
 			// of resets.
@@ -274,6 +286,26 @@ func (p *TxPool) loop(head *types.Header, chain BlockChain) {
 	errc <- nil
 }
 
+// EnableSimulation starts executing the pending transactions against the
+// pending state, predicting their outcome and gas use. It's a noop if the
+// simulation is already enabled.
+func (p *TxPool) EnableSimulation(chain SimulatorChain) {
+	sim := newSimulator(chain, p)
+	if p.simulator.CompareAndSwap(nil, sim) {
+		sim.start()
+	}
+}
+
+// Prediction returns the predicted outcome of the pending transaction with the
+// given hash. Nil is returned if the simulation is disabled or the transaction
+// was not simulated yet.
+func (p *TxPool) Prediction(hash common.Hash) *Prediction {
+	if sim := p.simulator.Load(); sim != nil {
+		return sim.prediction(hash)
+	}
+	return nil
+}
+
 // SetGasTip updates the minimum gas tip required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (p *TxPool) SetGasTip(tip *big.Int) {
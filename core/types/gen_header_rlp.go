@@ -29,7 +29,10 @@ func (obj *Header) EncodeRLP(_w io.Writer) error {
 	w.WriteBytes(obj.Random[:])
 	_tmp1 := obj.BaseFee != nil
 	_tmp2 := obj.WithdrawalsHash != nil
-	if _tmp1 || _tmp2  {
+	_tmp3 := obj.BlobGasUsed != nil
+	_tmp4 := obj.ExcessBlobGas != nil
+	_tmp5 := obj.ParentBeaconRoot != nil
+	if _tmp1 || _tmp2 || _tmp3 || _tmp4 || _tmp5 {
 		if obj.BaseFee == nil {
 			w.Write(rlp.EmptyString)
 		} else {
@@ -39,13 +42,34 @@ func (obj *Header) EncodeRLP(_w io.Writer) error {
 			w.WriteBigInt(obj.BaseFee)
 		}
 	}
-	if _tmp2  {
+	if _tmp2 || _tmp3 || _tmp4 || _tmp5 {
 		if obj.WithdrawalsHash == nil {
 			w.Write([]byte{0x80})
 		} else {
 			w.WriteBytes(obj.WithdrawalsHash[:])
 		}
 	}
+	if _tmp3 || _tmp4 || _tmp5 {
+		if obj.BlobGasUsed == nil {
+			w.Write([]byte{0x80})
+		} else {
+			w.WriteUint64((*obj.BlobGasUsed))
+		}
+	}
+	if _tmp4 || _tmp5 {
+		if obj.ExcessBlobGas == nil {
+			w.Write([]byte{0x80})
+		} else {
+			w.WriteUint64((*obj.ExcessBlobGas))
+		}
+	}
+	if _tmp5 {
+		if obj.ParentBeaconRoot == nil {
+			w.Write([]byte{0x80})
+		} else {
+			w.WriteBytes(obj.ParentBeaconRoot[:])
+		}
+	}
 	w.ListEnd(_tmp0)
 	return w.Flush()
 }
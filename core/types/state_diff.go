@@ -0,0 +1,45 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"github.com/theQRL/go-zond/common"
+)
+
+// StateDiff is the set of accounts and storage slots mutated by the state
+// transition of a block, along with their values before and after it.
+type StateDiff struct {
+	Accounts []*AccountDiff // Mutated accounts, sorted by address
+}
+
+// AccountDiff is the change of a single account in a state transition. The
+// account data is in 'slim RLP' format, empty if the account was not present.
+type AccountDiff struct {
+	Address common.Address
+	Prev    []byte
+	Post    []byte
+	Storage []*StorageDiff // Mutated storage slots, sorted by slot hash
+}
+
+// StorageDiff is the change of a single storage slot in a state transition.
+// The slot values are in prefix-zero trimmed RLP format, empty if the slot was
+// not present.
+type StorageDiff struct {
+	Hash common.Hash // Hash of the slot key, the preimage is not tracked
+	Prev []byte
+	Post []byte
+}
@@ -37,8 +37,15 @@ type sigCache struct {
 }
 
 // MakeSigner returns a Signer based on the given chain config and block number.
-func MakeSigner(config *params.ChainConfig) Signer {
-	return NewShanghaiSigner(config.ChainID)
+func MakeSigner(config *params.ChainConfig, blockNumber *big.Int, blockTime uint64) Signer {
+	var signer Signer
+	switch {
+	case config.IsCancun(blockNumber, blockTime):
+		signer = NewCancunSigner(config.ChainID)
+	default:
+		signer = NewShanghaiSigner(config.ChainID)
+	}
+	return signer
 }
 
 // LatestSigner returns the 'most permissive' Signer available for the given chain
@@ -49,6 +56,9 @@ func MakeSigner(config *params.ChainConfig) Signer {
 // Use this in transaction-handling code where the current block number is unknown. If you
 // have the current block number available, use MakeSigner instead.
 func LatestSigner(config *params.ChainConfig) Signer {
+	if config.CancunTime != nil {
+		return NewCancunSigner(config.ChainID)
+	}
 	return NewShanghaiSigner(config.ChainID)
 }
 
@@ -60,7 +70,7 @@ func LatestSigner(config *params.ChainConfig) Signer {
 // configuration are unknown. If you have a ChainConfig, use LatestSigner instead.
 // If you have a ChainConfig and know the current block number, use MakeSigner instead.
 func LatestSignerForChainID(chainID *big.Int) Signer {
-	return NewShanghaiSigner(chainID)
+	return NewCancunSigner(chainID)
 }
 
 // SignTx signs the transaction using the given dilithium signer and private key.
@@ -129,6 +139,20 @@ func Sender(signer Signer, tx *Transaction) (common.Address, error) {
 	return addr, nil
 }
 
+// VerifySignature checks that the signature of the transaction was produced by
+// the public key embedded in it. Registered key transactions don't carry their
+// public key, their signature is checked against the key registry in state.
+func VerifySignature(signer Signer, tx *Transaction) error {
+	if tx.Type() == RegisteredKeyTxType {
+		return nil
+	}
+	hash := signer.Hash(tx)
+	if !pqcrypto.VerifySignature(tx.RawPublicKeyValue(), hash[:], tx.RawSignatureValue()) {
+		return ErrInvalidSig
+	}
+	return nil
+}
+
 // Signer encapsulates transaction signature handling. The name of this type is slightly
 // misleading because Signers don't actually sign, they're just for validating and
 // processing of signatures.
@@ -152,6 +176,106 @@ type Signer interface {
 	Equal(Signer) bool
 }
 
+type CancunSigner struct {
+	ShanghaiSigner
+}
+
+// NewCancunSigner returns a signer that accepts
+// - registered key transactions
+// - blob transactions
+// - all the transaction types accepted by the Shanghai signer
+func NewCancunSigner(chainId *big.Int) Signer {
+	return CancunSigner{ShanghaiSigner{chainId}}
+}
+
+// Sender returns the sender of the transaction.
+//
+// For registered key transactions this is the address embedded in the
+// transaction, which is only a claim: the signer has no access to the state
+// holding the public key registry, so the signature is not verified here.
+// Callers must not trust the returned address before the signature has been
+// checked against the key registered for it, which is done by the transaction
+// pool on admission and by the state transition before execution.
+func (s CancunSigner) Sender(tx *Transaction) (common.Address, error) {
+	switch txdata := tx.inner.(type) {
+	case *RegisteredKeyTx:
+		if txdata.ChainID.Cmp(s.ChainId) != 0 {
+			return common.Address{}, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, txdata.ChainID, s.ChainId)
+		}
+		if len(txdata.Signature) != pqcrypto.DilithiumSignatureLength || txdata.From == (common.Address{}) {
+			return common.Address{}, ErrInvalidSig
+		}
+		return txdata.From, nil
+	case *BlobTx:
+		if txdata.ChainID.Cmp(s.ChainId) != 0 {
+			return common.Address{}, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, txdata.ChainID, s.ChainId)
+		}
+		return pqcrypto.DilithiumPKToAddress(txdata.PublicKey), nil
+	default:
+		return s.ShanghaiSigner.Sender(tx)
+	}
+}
+
+func (s CancunSigner) Equal(s2 Signer) bool {
+	x, ok := s2.(CancunSigner)
+	return ok && x.ChainId.Cmp(s.ChainId) == 0
+}
+
+func (s CancunSigner) SignatureAndPublicKeyValues(tx *Transaction, sig, pk []byte) (Signature, PublicKey []byte, err error) {
+	if tx.Type() != RegisteredKeyTxType && tx.Type() != BlobTxType {
+		return s.ShanghaiSigner.SignatureAndPublicKeyValues(tx, sig, pk)
+	}
+	// Check that chain ID of tx matches the signer. We also accept ID zero here,
+	// because it indicates that the chain ID was not specified in the tx.
+	chainID := tx.inner.chainID()
+	if chainID.Sign() != 0 && chainID.Cmp(s.ChainId) != 0 {
+		return nil, nil, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, chainID, s.ChainId)
+	}
+	Signature = decodeSignature(sig)
+	PublicKey = decodePublicKey(pk)
+	return Signature, PublicKey, nil
+}
+
+// Hash returns the hash to be signed by the sender.
+// It does not uniquely identify the transaction.
+func (s CancunSigner) Hash(tx *Transaction) common.Hash {
+	switch txdata := tx.inner.(type) {
+	case *RegisteredKeyTx:
+		return prefixedRlpHash(
+			tx.Type(),
+			[]interface{}{
+				s.ChainId,
+				tx.Nonce(),
+				tx.GasTipCap(),
+				tx.GasFeeCap(),
+				tx.Gas(),
+				tx.To(),
+				tx.Value(),
+				tx.Data(),
+				tx.AccessList(),
+				txdata.From,
+			})
+	case *BlobTx:
+		return prefixedRlpHash(
+			tx.Type(),
+			[]interface{}{
+				s.ChainId,
+				tx.Nonce(),
+				tx.GasTipCap(),
+				tx.GasFeeCap(),
+				tx.Gas(),
+				tx.To(),
+				tx.Value(),
+				tx.Data(),
+				tx.AccessList(),
+				txdata.BlobFeeCap,
+				txdata.BlobHashes,
+			})
+	default:
+		return s.ShanghaiSigner.Hash(tx)
+	}
+}
+
 type ShanghaiSigner struct {
 	ChainId *big.Int
 }
@@ -169,6 +293,9 @@ func (s ShanghaiSigner) ChainID() *big.Int {
 }
 
 func (s ShanghaiSigner) Sender(tx *Transaction) (common.Address, error) {
+	if tx.Type() != DynamicFeeTxType {
+		return common.Address{}, ErrTxTypeNotSupported
+	}
 	if tx.ChainId().Cmp(s.ChainId) != 0 {
 		return common.Address{}, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, tx.ChainId(), s.ChainId)
 	}
@@ -181,6 +308,9 @@ func (s ShanghaiSigner) Equal(s2 Signer) bool {
 }
 
 func (s ShanghaiSigner) SignatureAndPublicKeyValues(tx *Transaction, sig, pk []byte) (Signature, PublicKey []byte, err error) {
+	if tx.Type() != DynamicFeeTxType {
+		return nil, nil, ErrTxTypeNotSupported
+	}
 	// Check that chain ID of tx matches the signer. We also accept ID zero here,
 	// because it indicates that the chain ID was not specified in the tx.
 	chainID := tx.inner.chainID()
@@ -23,6 +23,7 @@ import (
 
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/rlp"
 )
 
@@ -111,3 +112,63 @@ func TestChainId(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+func TestRegisteredKeySigning(t *testing.T) {
+	key, _ := crypto.GenerateDilithiumKey()
+	addr := common.Address(key.GetAddress())
+
+	signer := NewCancunSigner(big.NewInt(18))
+	tx, err := SignTx(NewTx(&RegisteredKeyTx{Nonce: 0, To: &addr, Value: new(big.Int), Gas: 0, GasFeeCap: new(big.Int), GasTipCap: new(big.Int), From: addr}), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk := tx.RawPublicKeyValue(); pk != nil {
+		t.Errorf("expected no embedded public key, got %d bytes", len(pk))
+	}
+	from, err := Sender(signer, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+	pk := key.GetPK()
+	hash := signer.Hash(tx)
+	if !pqcrypto.VerifySignature(pk[:], hash[:], tx.RawSignatureValue()) {
+		t.Error("signature does not verify against the signing key")
+	}
+	// Registered key transactions are not valid before Cancun
+	if _, err := NewShanghaiSigner(big.NewInt(18)).Sender(tx); !errors.Is(err, ErrTxTypeNotSupported) {
+		t.Errorf("expected %v, got %v", ErrTxTypeNotSupported, err)
+	}
+	// Malformed signatures are rejected without looking up the registry
+	malformed := NewTx(&RegisteredKeyTx{ChainID: big.NewInt(18), Nonce: 0, To: &addr, Value: new(big.Int), Gas: 0, GasFeeCap: new(big.Int), GasTipCap: new(big.Int), From: addr})
+	malformed.inner.(*RegisteredKeyTx).Signature = tx.RawSignatureValue()[:64]
+	if _, err := signer.Sender(malformed); !errors.Is(err, ErrInvalidSig) {
+		t.Errorf("expected %v, got %v", ErrInvalidSig, err)
+	}
+	// The sender is part of the signed payload
+	other := NewTx(&RegisteredKeyTx{ChainID: big.NewInt(18), Nonce: 0, To: &addr, Value: new(big.Int), Gas: 0, GasFeeCap: new(big.Int), GasTipCap: new(big.Int), From: common.Address{1}})
+	if signer.Hash(other) == hash {
+		t.Error("signing hash does not commit to the sender")
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, _ := crypto.GenerateDilithiumKey()
+	addr := common.Address(key.GetAddress())
+
+	signer := NewShanghaiSigner(big.NewInt(18))
+	tx, err := SignTx(NewTx(&DynamicFeeTx{Nonce: 0, To: &addr, Value: new(big.Int), Gas: 0, GasFeeCap: new(big.Int), Data: nil}), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySignature(signer, tx); err != nil {
+		t.Fatalf("failed to verify valid signature: %v", err)
+	}
+	// Tampering with the signed payload must invalidate the signature
+	tampered := NewTx(&DynamicFeeTx{ChainID: big.NewInt(18), Nonce: 1, To: &addr, Value: new(big.Int), Gas: 0, GasFeeCap: new(big.Int), Data: nil, Signature: tx.RawSignatureValue(), PublicKey: tx.RawPublicKeyValue()})
+	if err := VerifySignature(signer, tampered); !errors.Is(err, ErrInvalidSig) {
+		t.Errorf("expected %v, got %v", ErrInvalidSig, err)
+	}
+}
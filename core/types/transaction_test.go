@@ -294,14 +294,14 @@ func TestTransactionCoding(t *testing.T) {
 		t.Fatalf("could not generate key: %v", err)
 	}
 	var (
-		signer       = NewShanghaiSigner(common.Big1)
+		signer       = NewCancunSigner(common.Big1)
 		addr, _      = common.NewAddressFromString("Z0000000000000000000000000000000000000001")
 		recipient, _ = common.NewAddressFromString("Z095e7baea6a6c7c4c2dfeb977efac326af552d87")
 		accesses     = AccessList{{Address: addr, StorageKeys: []common.Hash{{0}}}}
 	)
 	for i := uint64(0); i < 500; i++ {
 		var txdata TxData
-		switch i % 5 {
+		switch i % 6 {
 		case 0:
 			// Dynamic fee tx.
 			txdata = &DynamicFeeTx{
@@ -354,6 +354,19 @@ func TestTransactionCoding(t *testing.T) {
 				GasTipCap:  big.NewInt(0),
 				AccessList: accesses,
 			}
+		case 5:
+			// Registered key tx with access list.
+			txdata = &RegisteredKeyTx{
+				ChainID:    big.NewInt(1),
+				Nonce:      i,
+				To:         &recipient,
+				Gas:        123457,
+				GasFeeCap:  big.NewInt(2),
+				GasTipCap:  big.NewInt(0),
+				AccessList: accesses,
+				Data:       []byte("abcdef"),
+				From:       key.GetAddress(),
+			}
 		}
 		tx, err := SignNewTx(key, signer, txdata)
 		if err != nil {
@@ -470,3 +483,44 @@ func TestTransactionSizes(t *testing.T) {
 		}
 	}
 }
+
+// Tests that registered key transactions are smaller than their dynamic fee
+// counterparts by the size of the public key.
+func TestRegisteredKeyTxSize(t *testing.T) {
+	signer := NewCancunSigner(big.NewInt(123))
+	key, _ := pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	to, _ := common.NewAddressFromString("Z0000000000000000000000000000000000000001")
+
+	full, err := SignNewTx(key, signer, &DynamicFeeTx{
+		ChainID:   big.NewInt(123),
+		Nonce:     1,
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+		GasTipCap: big.NewInt(500),
+		GasFeeCap: big.NewInt(500),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	compact, err := SignNewTx(key, signer, &RegisteredKeyTx{
+		ChainID:   big.NewInt(123),
+		Nonce:     1,
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+		GasTipCap: big.NewInt(500),
+		GasFeeCap: big.NewInt(500),
+		From:      key.GetAddress(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin, _ := compact.MarshalBinary()
+	if have, want := int(compact.Size()), len(bin); have != want {
+		t.Errorf("size wrong, have %d want %d", have, want)
+	}
+	if saved := int(full.Size()) - int(compact.Size()); saved < pqcrypto.DilithiumPublicKeyLength-common.AddressLength {
+		t.Errorf("registered key tx too large: full %d, compact %d", full.Size(), compact.Size())
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/rlp"
+)
+
+// RegisteredKeyTx represents a dynamic fee transaction whose sender is
+// identified by address instead of by an embedded public key. The public key
+// used to check the signature is looked up in the public key registry kept in
+// state, which is populated by transactions carrying the full public key of
+// their sender to the registry contract.
+//
+// The From field is not authenticated by the signer, see CancunSigner.Sender.
+type RegisteredKeyTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int // a.k.a. maxPriorityFeePerGas
+	GasFeeCap  *big.Int // a.k.a. maxFeePerGas
+	Gas        uint64
+	To         *common.Address `rlp:"nil"` // nil means contract creation
+	Value      *big.Int
+	Data       []byte
+	AccessList AccessList
+	From       common.Address // sender, whose public key must be registered
+
+	// Signature value
+	Signature []byte
+}
+
+// copy creates a deep copy of the transaction data and initializes all fields.
+func (tx *RegisteredKeyTx) copy() TxData {
+	cpy := &RegisteredKeyTx{
+		Nonce: tx.Nonce,
+		To:    copyAddressPtr(tx.To),
+		Data:  common.CopyBytes(tx.Data),
+		Gas:   tx.Gas,
+		From:  tx.From,
+		// These are copied below.
+		AccessList: make(AccessList, len(tx.AccessList)),
+		Value:      new(big.Int),
+		ChainID:    new(big.Int),
+		GasTipCap:  new(big.Int),
+		GasFeeCap:  new(big.Int),
+		Signature:  make([]byte, pqcrypto.DilithiumSignatureLength),
+	}
+	copy(cpy.AccessList, tx.AccessList)
+	if tx.Value != nil {
+		cpy.Value.Set(tx.Value)
+	}
+	if tx.ChainID != nil {
+		cpy.ChainID.Set(tx.ChainID)
+	}
+	if tx.GasTipCap != nil {
+		cpy.GasTipCap.Set(tx.GasTipCap)
+	}
+	if tx.GasFeeCap != nil {
+		cpy.GasFeeCap.Set(tx.GasFeeCap)
+	}
+	if tx.Signature != nil {
+		copy(cpy.Signature[:pqcrypto.DilithiumSignatureLength], tx.Signature)
+	}
+	return cpy
+}
+
+// accessors for innerTx.
+func (tx *RegisteredKeyTx) txType() byte           { return RegisteredKeyTxType }
+func (tx *RegisteredKeyTx) chainID() *big.Int      { return tx.ChainID }
+func (tx *RegisteredKeyTx) accessList() AccessList { return tx.AccessList }
+func (tx *RegisteredKeyTx) data() []byte           { return tx.Data }
+func (tx *RegisteredKeyTx) gas() uint64            { return tx.Gas }
+func (tx *RegisteredKeyTx) gasFeeCap() *big.Int    { return tx.GasFeeCap }
+func (tx *RegisteredKeyTx) gasTipCap() *big.Int    { return tx.GasTipCap }
+func (tx *RegisteredKeyTx) gasPrice() *big.Int     { return tx.GasFeeCap }
+func (tx *RegisteredKeyTx) value() *big.Int        { return tx.Value }
+func (tx *RegisteredKeyTx) nonce() uint64          { return tx.Nonce }
+func (tx *RegisteredKeyTx) to() *common.Address    { return tx.To }
+
+func (tx *RegisteredKeyTx) effectiveGasPrice(dst *big.Int, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return dst.Set(tx.GasFeeCap)
+	}
+	tip := dst.Sub(tx.GasFeeCap, baseFee)
+	if tip.Cmp(tx.GasTipCap) > 0 {
+		tip.Set(tx.GasTipCap)
+	}
+	return tip.Add(tip, baseFee)
+}
+
+func (tx *RegisteredKeyTx) rawSignatureValue() (signature []byte) {
+	return tx.Signature
+}
+
+// rawPublicKeyValue returns nil, the public key is not part of the transaction.
+func (tx *RegisteredKeyTx) rawPublicKeyValue() (publicKey []byte) {
+	return nil
+}
+
+// setSignatureAndPublicKeyValues sets the signature, the public key is not
+// embedded in the transaction.
+func (tx *RegisteredKeyTx) setSignatureAndPublicKeyValues(chainID *big.Int, signature, publicKey []byte) {
+	tx.ChainID, tx.Signature = chainID, signature
+}
+
+func (tx *RegisteredKeyTx) encode(b *bytes.Buffer) error {
+	return rlp.Encode(b, tx)
+}
+
+func (tx *RegisteredKeyTx) decode(input []byte) error {
+	return rlp.DecodeBytes(input, tx)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/math"
 	"github.com/theQRL/go-zond/crypto/bn256"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/params"
 )
 
@@ -51,7 +52,21 @@ var PrecompiledContractsBerlin = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{8}): &bn256PairingIstanbul{},
 }
 
+// PrecompiledContractsCancun contains the default set of pre-compiled Zond
+// contracts used in the Cancun release.
+var PrecompiledContractsCancun = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}): &depositroot{},
+	common.BytesToAddress([]byte{2}): &sha256hash{},
+	common.BytesToAddress([]byte{4}): &dataCopy{},
+	common.BytesToAddress([]byte{5}): &bigModExp{eip2565: true},
+	common.BytesToAddress([]byte{6}): &bn256AddIstanbul{},
+	common.BytesToAddress([]byte{7}): &bn256ScalarMulIstanbul{},
+	common.BytesToAddress([]byte{8}): &bn256PairingIstanbul{},
+	common.BytesToAddress([]byte{9}): &dilithiumVerify{},
+}
+
 var (
+	PrecompiledAddressesCancun []common.Address
 	PrecompiledAddressesBerlin []common.Address
 )
 
@@ -59,11 +74,19 @@ func init() {
 	for k := range PrecompiledContractsBerlin {
 		PrecompiledAddressesBerlin = append(PrecompiledAddressesBerlin, k)
 	}
+	for k := range PrecompiledContractsCancun {
+		PrecompiledAddressesCancun = append(PrecompiledAddressesCancun, k)
+	}
 }
 
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules params.Rules) []common.Address {
-	return PrecompiledAddressesBerlin
+	switch {
+	case rules.IsCancun:
+		return PrecompiledAddressesCancun
+	default:
+		return PrecompiledAddressesBerlin
+	}
 }
 
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
@@ -81,6 +104,49 @@ func RunPrecompiledContract(p PrecompiledContract, input []byte, suppliedGas uin
 	return output, suppliedGas, err
 }
 
+// dilithiumVerify implements a native Dilithium signature verification.
+type dilithiumVerify struct{}
+
+// dilithiumVerifyHeaderLength is the length of the input preceding the message,
+// made up of the message length, the signature and the public key.
+const dilithiumVerifyHeaderLength = 32 + pqcrypto.DilithiumSignatureLength + pqcrypto.DilithiumPublicKeyLength
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+//
+// The gas is made up of a base price for the verification and a per-word price
+// for hashing the message, which is signed as is rather than as a digest.
+func (c *dilithiumVerify) RequiredGas(input []byte) uint64 {
+	var size uint64
+	if len(input) > dilithiumVerifyHeaderLength {
+		size = uint64(len(input) - dilithiumVerifyHeaderLength)
+	}
+	return (size+31)/32*params.DilithiumVerifyPerWordGas + params.DilithiumVerifyBaseGas
+}
+
+// Run verifies a Dilithium signature. The input is expected to be the length
+// of the message as a 32 byte big endian word, followed by the signature, the
+// public key of the signer and the signed message of the given length. If the
+// signature is valid, a single 32 byte word set to 1 is returned, otherwise a
+// zero word. Malformed inputs are treated as invalid signatures.
+func (c *dilithiumVerify) Run(input []byte) ([]byte, error) {
+	if len(input) < dilithiumVerifyHeaderLength {
+		return false32Byte, nil
+	}
+	size := new(big.Int).SetBytes(input[:32])
+	if !size.IsUint64() || size.Uint64() != uint64(len(input)-dilithiumVerifyHeaderLength) {
+		return false32Byte, nil
+	}
+	var (
+		signature = input[32 : 32+pqcrypto.DilithiumSignatureLength]
+		pubkey    = input[32+pqcrypto.DilithiumSignatureLength : dilithiumVerifyHeaderLength]
+		message   = input[dilithiumVerifyHeaderLength:]
+	)
+	if !pqcrypto.VerifyMessage(pubkey, message, signature) {
+		return false32Byte, nil
+	}
+	return true32Byte, nil
+}
+
 type depositroot struct{}
 
 // TODO(now.youtrack.cloud/issue/TGZ-5)
@@ -57,6 +57,7 @@ var allPrecompiles = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{6}):    &bn256AddIstanbul{},
 	common.BytesToAddress([]byte{7}):    &bn256ScalarMulIstanbul{},
 	common.BytesToAddress([]byte{8}):    &bn256PairingIstanbul{},
+	common.BytesToAddress([]byte{9}):    &dilithiumVerify{},
 }
 
 func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
@@ -245,6 +246,15 @@ func BenchmarkPrecompiledBn256Pairing(b *testing.B) {
 	benchJson("bn256Pairing", "Z0000000000000000000000000000000000000008", b)
 }
 
+// Tests the Dilithium signature verification precompile against valid and
+// tampered signatures.
+func TestPrecompiledDilithiumVerify(t *testing.T) {
+	testJson("dilithiumVerify", "Z0000000000000000000000000000000000000009", t)
+}
+func BenchmarkPrecompiledDilithiumVerify(b *testing.B) {
+	benchJson("dilithiumVerify", "Z0000000000000000000000000000000000000009", b)
+}
+
 func TestPrecompiledDepositroot(t *testing.T) {
 	testJson("depositroot", "Z0000000000000000000000000000000000000001", t)
 }
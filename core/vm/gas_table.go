@@ -60,6 +60,7 @@ func memoryGasCost(mem *Memory, newMemSize uint64) (uint64, error) {
 // CODECOPY (stack position 2)
 // EXTCODECOPY (stack position 3)
 // RETURNDATACOPY (stack position 2)
+// MCOPY (stack position 2)
 func memoryCopierGas(stackpos int) gasFunc {
 	return func(zvm *ZVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
 		// Gas for expanding the memory
@@ -89,6 +90,7 @@ var (
 	gasCodeCopy       = memoryCopierGas(2)
 	gasExtCodeCopy    = memoryCopierGas(3)
 	gasReturnDataCopy = memoryCopierGas(2)
+	gasMcopy          = memoryCopierGas(2)
 )
 
 func makeGasLog(n uint64) gasFunc {
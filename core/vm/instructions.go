@@ -500,6 +500,18 @@ func opMstore(pc *uint64, interpreter *ZVMInterpreter, scope *ScopeContext) ([]b
 	return nil, nil
 }
 
+func opMcopy(pc *uint64, interpreter *ZVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		dst    = scope.Stack.pop()
+		src    = scope.Stack.pop()
+		length = scope.Stack.pop()
+	)
+	// These values are checked for overflow during memory expansion calculation
+	// (the memorySize function on the opcode).
+	scope.Memory.Copy(dst.Uint64(), src.Uint64(), length.Uint64())
+	return nil, nil
+}
+
 func opMstore8(pc *uint64, interpreter *ZVMInterpreter, scope *ScopeContext) ([]byte, error) {
 	off, val := scope.Stack.pop(), scope.Stack.pop()
 	scope.Memory.store[off.Uint64()] = byte(val.Uint64())
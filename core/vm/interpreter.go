@@ -54,7 +54,13 @@ type ZVMInterpreter struct {
 // NewZVMInterpreter returns a new instance of the Interpreter.
 func NewZVMInterpreter(zvm *ZVM) *ZVMInterpreter {
 	// If jump table was not initialised we set the default one.
-	table := &shanghaiInstructionSet
+	var table *JumpTable
+	switch {
+	case zvm.chainRules.IsCancun:
+		table = &cancunInstructionSet
+	default:
+		table = &shanghaiInstructionSet
+	}
 	var extraEips []int
 	if len(zvm.Config.ExtraEips) > 0 {
 		// Deep-copy jumptable to prevent modification of opcodes in other tables
@@ -44,7 +44,10 @@ type operation struct {
 	memorySize memorySizeFunc
 }
 
-var shanghaiInstructionSet = newShanghaiInstructionSet()
+var (
+	shanghaiInstructionSet = newShanghaiInstructionSet()
+	cancunInstructionSet   = newCancunInstructionSet()
+)
 
 // JumpTable contains the ZVM opcodes supported at a given fork.
 type JumpTable [256]*operation
@@ -67,8 +70,19 @@ func validate(jt JumpTable) JumpTable {
 	return jt
 }
 
-// newFrontierInstructionSet returns the frontier instructions
-// that can be executed during the frontier phase.
+// newCancunInstructionSet returns the shanghai instructions plus the
+// ones introduced in the Cancun fork.
+func newCancunInstructionSet() JumpTable {
+	instructionSet := newShanghaiInstructionSet()
+	enable1153(&instructionSet) // EIP-1153 "Transient Storage"
+	enable5656(&instructionSet) // EIP-5656 (MCOPY opcode)
+	enable4844(&instructionSet) // EIP-4844 (BLOBHASH opcode)
+	enable7516(&instructionSet) // EIP-7516 (BLOBBASEFEE opcode)
+	return validate(instructionSet)
+}
+
+// newShanghaiInstructionSet returns the genesis instructions that can be
+// executed prior to the Cancun fork.
 func newShanghaiInstructionSet() JumpTable {
 	tbl := JumpTable{
 		STOP: {
@@ -23,6 +23,10 @@ import (
 // LookupInstructionSet returns the instructionset for the fork configured by
 // the rules.
 func LookupInstructionSet(rules params.Rules) (JumpTable, error) {
+	switch {
+	case rules.IsCancun:
+		return newCancunInstructionSet(), nil
+	}
 	return newShanghaiInstructionSet(), nil
 }
 
@@ -38,7 +38,13 @@ type (
 )
 
 func (zvm *ZVM) precompile(addr common.Address) (PrecompiledContract, bool) {
-	precompiles := PrecompiledContractsBerlin
+	var precompiles map[common.Address]PrecompiledContract
+	switch {
+	case zvm.chainRules.IsCancun:
+		precompiles = PrecompiledContractsCancun
+	default:
+		precompiles = PrecompiledContractsBerlin
+	}
 	p, ok := precompiles[addr]
 	return p, ok
 }
@@ -60,6 +66,7 @@ type BlockContext struct {
 	BlockNumber *big.Int       // Provides information for NUMBER
 	Time        uint64         // Provides information for TIME
 	BaseFee     *big.Int       // Provides information for BASEFEE
+	BlobBaseFee *big.Int       // Provides information for BLOBBASEFEE
 	Random      *common.Hash   // Provides information for PREVRANDAO
 }
 
@@ -67,8 +74,9 @@ type BlockContext struct {
 // All fields can change between transactions.
 type TxContext struct {
 	// Message information
-	Origin   common.Address // Provides information for ORIGIN
-	GasPrice *big.Int       // Provides information for GASPRICE
+	Origin     common.Address // Provides information for ORIGIN
+	GasPrice   *big.Int       // Provides information for GASPRICE
+	BlobHashes []common.Hash  // Provides information for BLOBHASH
 }
 
 // ZVM is the Zond Virtual Machine base object and provides
@@ -21,6 +21,7 @@ import (
 
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/consensus"
+	"github.com/theQRL/go-zond/consensus/misc/eip1559"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 )
@@ -40,6 +41,7 @@ func NewZVMBlockContext(header *types.Header, chain ChainContext, author *common
 	var (
 		beneficiary common.Address
 		baseFee     *big.Int
+		blobBaseFee *big.Int
 	)
 
 	// If we don't have an explicit author (i.e. not mining), extract from the header
@@ -51,6 +53,9 @@ func NewZVMBlockContext(header *types.Header, chain ChainContext, author *common
 	if header.BaseFee != nil {
 		baseFee = new(big.Int).Set(header.BaseFee)
 	}
+	if header.ExcessBlobGas != nil {
+		blobBaseFee = eip1559.CalcBlobFee(*header.ExcessBlobGas)
+	}
 
 	return vm.BlockContext{
 		CanTransfer: CanTransfer,
@@ -60,6 +65,7 @@ func NewZVMBlockContext(header *types.Header, chain ChainContext, author *common
 		BlockNumber: new(big.Int).Set(header.Number),
 		Time:        header.Time,
 		BaseFee:     baseFee,
+		BlobBaseFee: blobBaseFee,
 		GasLimit:    header.GasLimit,
 		Random:      &header.Random,
 	}
@@ -68,8 +74,9 @@ func NewZVMBlockContext(header *types.Header, chain ChainContext, author *common
 // NewZVMTxContext creates a new transaction context for a single transaction.
 func NewZVMTxContext(msg *Message) vm.TxContext {
 	return vm.TxContext{
-		Origin:   msg.From,
-		GasPrice: new(big.Int).Set(msg.GasPrice),
+		Origin:     msg.From,
+		GasPrice:   new(big.Int).Set(msg.GasPrice),
+		BlobHashes: msg.BlobHashes,
 	}
 }
 
@@ -0,0 +1,105 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package blobcommit implements the commitment scheme binding blob transactions
+// to the data blobs they carry.
+//
+// Polynomial commitments over pairing-friendly curves are not quantum resistant,
+// so blobs are committed to with a plain SHA-256 hash instead. Verifying such a
+// commitment requires the whole blob, hence no proofs are carried alongside.
+package blobcommit
+
+import (
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"reflect"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
+)
+
+// BlobSize is the size of a data blob in bytes.
+const BlobSize = 131072
+
+// VersionedHashVersion is the version byte of the versioned hashes derived
+// from blob commitments.
+const VersionedHashVersion = 0x01
+
+var (
+	blobT       = reflect.TypeOf(Blob{})
+	commitmentT = reflect.TypeOf(Commitment{})
+)
+
+// ErrCommitmentMismatch is returned if a blob doesn't match its commitment.
+var ErrCommitmentMismatch = errors.New("blob commitment mismatch")
+
+// Blob represents a data blob.
+type Blob [BlobSize]byte
+
+// UnmarshalJSON parses a blob in hex syntax.
+func (b *Blob) UnmarshalJSON(input []byte) error {
+	return hexutil.UnmarshalFixedJSON(blobT, input, b[:])
+}
+
+// MarshalText returns the hex representation of b.
+func (b *Blob) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(b[:]).MarshalText()
+}
+
+// Commitment is the commitment to a data blob.
+type Commitment [32]byte
+
+// UnmarshalJSON parses a commitment in hex syntax.
+func (c *Commitment) UnmarshalJSON(input []byte) error {
+	return hexutil.UnmarshalFixedJSON(commitmentT, input, c[:])
+}
+
+// MarshalText returns the hex representation of c.
+func (c Commitment) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(c[:]).MarshalText()
+}
+
+// BlobToCommitment creates the commitment to a data blob.
+func BlobToCommitment(blob *Blob) Commitment {
+	return sha256.Sum256(blob[:])
+}
+
+// VerifyBlob checks that a data blob matches the given commitment.
+func VerifyBlob(blob *Blob, commitment Commitment) error {
+	if BlobToCommitment(blob) != commitment {
+		return ErrCommitmentMismatch
+	}
+	return nil
+}
+
+// CalcBlobHashV1 calculates the 'versioned blob hash' of a commitment.
+// The given hasher must be a sha256 hash instance, otherwise the result will be invalid!
+func CalcBlobHashV1(hasher hash.Hash, commit *Commitment) (vh common.Hash) {
+	if hasher.Size() != 32 {
+		panic("wrong hash size")
+	}
+	hasher.Reset()
+	hasher.Write(commit[:])
+	hasher.Sum(vh[:0])
+	vh[0] = VersionedHashVersion
+	return vh
+}
+
+// IsValidVersionedHash checks that h is a structurally-valid versioned blob hash.
+func IsValidVersionedHash(h []byte) bool {
+	return len(h) == 32 && h[0] == VersionedHashVersion
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobcommit
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func randBlob() *Blob {
+	var blob Blob
+	rand.Read(blob[:])
+	return &blob
+}
+
+func TestBlobCommitment(t *testing.T) {
+	blob := randBlob()
+	commitment := BlobToCommitment(blob)
+	if err := VerifyBlob(blob, commitment); err != nil {
+		t.Fatalf("failed to verify blob: %v", err)
+	}
+	blob[0] ^= 0xff
+	if err := VerifyBlob(blob, commitment); !errors.Is(err, ErrCommitmentMismatch) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrCommitmentMismatch)
+	}
+	vh := CalcBlobHashV1(sha256.New(), &commitment)
+	if !IsValidVersionedHash(vh[:]) {
+		t.Fatalf("invalid versioned hash: %x", vh)
+	}
+}
+
+func TestBlobJSON(t *testing.T) {
+	blob := randBlob()
+	enc, err := json.Marshal(blob)
+	if err != nil {
+		t.Fatalf("failed to encode blob: %v", err)
+	}
+	var dec Blob
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatalf("failed to decode blob: %v", err)
+	}
+	if dec != *blob {
+		t.Fatalf("blob mismatch after round trip")
+	}
+}
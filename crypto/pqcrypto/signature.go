@@ -16,3 +16,26 @@ func Sign(digestHash []byte, d *dilithium.Dilithium) ([]byte, error) {
 	}
 	return signature[:], nil
 }
+
+// VerifySignature checks that the given public key created signature over digest.
+func VerifySignature(pubkey, digestHash, signature []byte) bool {
+	if len(digestHash) != DigestLength {
+		return false
+	}
+	return VerifyMessage(pubkey, digestHash, signature)
+}
+
+// VerifyMessage checks that the given public key created signature over the
+// message, which may be of arbitrary length.
+func VerifyMessage(pubkey, message, signature []byte) bool {
+	if len(signature) != DilithiumSignatureLength || len(pubkey) != DilithiumPublicKeyLength {
+		return false
+	}
+	var (
+		sig [DilithiumSignatureLength]uint8
+		pk  [DilithiumPublicKeyLength]uint8
+	)
+	copy(sig[:], signature)
+	copy(pk[:], pubkey)
+	return dilithium.Verify(message, sig, &pk)
+}
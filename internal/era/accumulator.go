@@ -0,0 +1,54 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/crypto"
+)
+
+// ComputeAccumulator calculates the accumulator root of the given block hashes:
+// the root of the binary keccak merkle tree over the hashes, padded with empty
+// leaves up to MaxEraBatchSize, mixed in with the number of hashes.
+func ComputeAccumulator(hashes []common.Hash) (common.Hash, error) {
+	if len(hashes) == 0 {
+		return common.Hash{}, fmt.Errorf("no block hashes to accumulate")
+	}
+	if len(hashes) > MaxEraBatchSize {
+		return common.Hash{}, fmt.Errorf("too many block hashes: have %d, max %d", len(hashes), MaxEraBatchSize)
+	}
+	var (
+		level = append([]common.Hash{}, hashes...)
+		empty common.Hash // Root of an empty subtree at the current depth
+	)
+	for width := MaxEraBatchSize; width > 1; width /= 2 {
+		if len(level)%2 == 1 {
+			level = append(level, empty)
+		}
+		next := make([]common.Hash, len(level)/2)
+		for i := range next {
+			next[i] = crypto.Keccak256Hash(level[2*i][:], level[2*i+1][:])
+		}
+		level, empty = next, crypto.Keccak256Hash(empty[:], empty[:])
+	}
+	var length [32]byte
+	binary.BigEndian.PutUint64(length[24:], uint64(len(hashes)))
+	return crypto.Keccak256Hash(level[0][:], length[:]), nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/snappy"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/rlp"
+)
+
+// Builder writes an era file from consecutive blocks.
+type Builder struct {
+	w       *entryWriter
+	start   uint64
+	hashes  []common.Hash
+	offsets []uint64
+}
+
+// NewBuilder creates an era file builder writing to w.
+func NewBuilder(w io.Writer) *Builder {
+	return &Builder{w: newEntryWriter(w)}
+}
+
+// Add appends the block along with its receipts to the era file.
+func (b *Builder) Add(block *types.Block, receipts types.Receipts) error {
+	header, err := rlp.EncodeToBytes(block.Header())
+	if err != nil {
+		return err
+	}
+	body, err := rlp.EncodeToBytes(block.Body())
+	if err != nil {
+		return err
+	}
+	storage := make([]*types.ReceiptForStorage, len(receipts))
+	for i, receipt := range receipts {
+		storage[i] = (*types.ReceiptForStorage)(receipt)
+	}
+	encoded, err := rlp.EncodeToBytes(storage)
+	if err != nil {
+		return err
+	}
+	return b.AddRLP(header, body, encoded, block.NumberU64(), block.Hash())
+}
+
+// AddRLP appends the RLP encoded header, body and storage receipts of the block
+// with the given number and hash to the era file.
+func (b *Builder) AddRLP(header, body, receipts []byte, number uint64, hash common.Hash) error {
+	if len(b.hashes) == 0 {
+		if _, err := b.w.Write(TypeVersion, nil); err != nil {
+			return err
+		}
+		b.start = number
+	} else if number != b.start+uint64(len(b.hashes)) {
+		return fmt.Errorf("non-contiguous block: have %d, want %d", number, b.start+uint64(len(b.hashes)))
+	}
+	if len(b.hashes) >= MaxEraBatchSize {
+		return fmt.Errorf("exceeding maximum batch size of %d blocks", MaxEraBatchSize)
+	}
+	b.offsets = append(b.offsets, b.w.written)
+	for _, item := range []struct {
+		typ  uint16
+		data []byte
+	}{
+		{TypeCompressedHeader, header},
+		{TypeCompressedBody, body},
+		{TypeCompressedReceipts, receipts},
+	} {
+		if _, err := b.w.Write(item.typ, snappy.Encode(nil, item.data)); err != nil {
+			return err
+		}
+	}
+	b.hashes = append(b.hashes, hash)
+	return nil
+}
+
+// Finalize writes the accumulator and the block index, completing the era file.
+// The accumulator root is returned.
+func (b *Builder) Finalize() (common.Hash, error) {
+	root, err := ComputeAccumulator(b.hashes)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if _, err := b.w.Write(TypeAccumulator, root[:]); err != nil {
+		return common.Hash{}, err
+	}
+	index := make([]byte, 16+8*len(b.offsets))
+	binary.LittleEndian.PutUint64(index, b.start)
+	for i, off := range b.offsets {
+		binary.LittleEndian.PutUint64(index[8+8*i:], off)
+	}
+	binary.LittleEndian.PutUint64(index[8+8*len(b.offsets):], uint64(len(b.offsets)))
+	if _, err := b.w.Write(TypeBlockIndex, index); err != nil {
+		return common.Hash{}, err
+	}
+	return root, nil
+}
+
+// ChainReader is the chain access needed for exporting era files.
+type ChainReader interface {
+	GetBlockByNumber(number uint64) *types.Block
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+}
+
+// Export writes the blocks of the given epoch up to, but excluding, the limit
+// into an era file in dir and returns its path. The file is only moved in place
+// once completely written.
+func Export(chain ChainReader, dir, network string, epoch, limit uint64) (string, error) {
+	var (
+		first = epoch * MaxEraBatchSize
+		last  = min(first+MaxEraBatchSize, limit)
+	)
+	if first >= last {
+		return "", fmt.Errorf("no blocks to export in epoch %d", epoch)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(dir, fmt.Sprintf("%s-%05d-*.era.tmp", network, epoch))
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+	builder := NewBuilder(f)
+	for number := first; number < last; number++ {
+		block := chain.GetBlockByNumber(number)
+		if block == nil {
+			return "", fmt.Errorf("block %d not found", number)
+		}
+		receipts := chain.GetReceiptsByHash(block.Hash())
+		if receipts == nil && len(block.Transactions()) > 0 {
+			return "", fmt.Errorf("receipts of block %d not found", number)
+		}
+		if err := builder.Add(block, receipts); err != nil {
+			return "", err
+		}
+	}
+	root, err := builder.Finalize()
+	if err != nil {
+		return "", err
+	}
+	if err := f.Sync(); err != nil {
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, Filename(network, int(epoch), root))
+	if err := os.Rename(f.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// headerSize is the size of the header preceding every e2store entry: a two
+// byte type, a four byte data length and two reserved bytes, little endian.
+const headerSize = 8
+
+// Entry is a single type-length-value record of an e2store file.
+type Entry struct {
+	Type  uint16
+	Value []byte
+}
+
+// entryWriter writes e2store entries to an underlying writer, keeping track
+// of the number of bytes written for locating the entries afterwards.
+type entryWriter struct {
+	w       io.Writer
+	written uint64
+}
+
+// newEntryWriter creates an e2store entry writer on top of w.
+func newEntryWriter(w io.Writer) *entryWriter {
+	return &entryWriter{w: w}
+}
+
+// Write writes a single entry and returns the number of bytes written.
+func (w *entryWriter) Write(typ uint16, value []byte) (int, error) {
+	if uint64(len(value)) > uint64(^uint32(0)) {
+		return 0, fmt.Errorf("entry value too large: %d bytes", len(value))
+	}
+	var header [headerSize]byte
+	binary.LittleEndian.PutUint16(header[:2], typ)
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(value)))
+
+	n, err := w.w.Write(header[:])
+	w.written += uint64(n)
+	if err != nil {
+		return n, err
+	}
+	m, err := w.w.Write(value)
+	w.written += uint64(m)
+	return n + m, err
+}
+
+// entryReader reads e2store entries at arbitrary offsets.
+type entryReader struct {
+	r io.ReaderAt
+}
+
+// readHeaderAt reads the type and value length of the entry at the given
+// offset.
+func (r *entryReader) readHeaderAt(off int64) (uint16, uint32, error) {
+	var header [headerSize]byte
+	if _, err := r.r.ReadAt(header[:], off); err != nil {
+		return 0, 0, err
+	}
+	if header[6] != 0 || header[7] != 0 {
+		return 0, 0, errors.New("reserved bytes of entry header are not zero")
+	}
+	return binary.LittleEndian.Uint16(header[:2]), binary.LittleEndian.Uint32(header[2:6]), nil
+}
+
+// ReadAt reads the entry at the given offset and returns it along with its
+// total size, header included.
+func (r *entryReader) ReadAt(off int64) (*Entry, int64, error) {
+	typ, length, err := r.readHeaderAt(off)
+	if err != nil {
+		return nil, 0, err
+	}
+	value := make([]byte, length)
+	if _, err := r.r.ReadAt(value, off+headerSize); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+	return &Entry{Type: typ, Value: value}, headerSize + int64(length), nil
+}
+
+// readTypedAt reads the entry at the given offset, failing if it's not of the
+// expected type.
+func (r *entryReader) readTypedAt(off int64, typ uint16) ([]byte, int64, error) {
+	entry, n, err := r.ReadAt(off)
+	if err != nil {
+		return nil, 0, err
+	}
+	if entry.Type != typ {
+		return nil, 0, fmt.Errorf("unexpected entry type at offset %d: have %#04x, want %#04x", off, entry.Type, typ)
+	}
+	return entry.Value, n, nil
+}
@@ -0,0 +1,354 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package era implements the era archive format, storing a batch of consecutive
+// blocks along with their receipts in a self-verifying e2store file.
+//
+// An era file is laid out as the sequence of entries below, with the blocks in
+// ascending order:
+//
+//	Version | (CompressedHeader | CompressedBody | CompressedReceipts)* | Accumulator | BlockIndex
+//
+// The headers, bodies and receipts are snappy compressed RLP encodings, the
+// receipts being in their storage format. The accumulator is the merkle root of
+// the contained block hashes (see ComputeAccumulator) and the block index holds
+// the number of the first block, the offsets of the header entries and the
+// number of blocks, in 8 byte little endian values.
+package era
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/rlp"
+	"github.com/theQRL/go-zond/trie"
+)
+
+// Entry types of an era file.
+const (
+	TypeVersion            uint16 = 0x3265
+	TypeCompressedHeader   uint16 = 0x03
+	TypeCompressedBody     uint16 = 0x04
+	TypeCompressedReceipts uint16 = 0x05
+	TypeAccumulator        uint16 = 0x07
+	TypeBlockIndex         uint16 = 0x3266
+)
+
+// MaxEraBatchSize is the maximum number of blocks stored in an era file. The
+// era files of a chain are aligned to it, the n-th file (epoch) containing the
+// blocks from n*MaxEraBatchSize on.
+const MaxEraBatchSize = 8192
+
+// Filename returns the name of the era file of the given network and epoch with
+// the given accumulator root.
+func Filename(network string, epoch int, root common.Hash) string {
+	return fmt.Sprintf("%s-%05d-%s.era", network, epoch, common.Bytes2Hex(root[:4]))
+}
+
+// NetworkName returns the network name used in the era filenames of the chain
+// with the given id.
+func NetworkName(chainID *big.Int) string {
+	if name := params.NetworkNames[chainID.String()]; name != "" {
+		return name
+	}
+	return chainID.String()
+}
+
+// ReadDir returns the era files of the given network in the directory, ordered
+// by epoch. It fails if there are gaps between the epochs or an epoch is stored
+// in multiple files.
+func ReadDir(dir, network string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read directory %s: %w", dir, err)
+	}
+	var (
+		epochs = make(map[int]string)
+		next   = -1
+	)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".era" {
+			continue
+		}
+		parts := strings.Split(strings.TrimSuffix(entry.Name(), ".era"), "-")
+		if len(parts) != 3 || parts[0] != network {
+			continue
+		}
+		var epoch int
+		if _, err := fmt.Sscanf(parts[1], "%05d", &epoch); err != nil {
+			return nil, fmt.Errorf("malformed era filename %s: %w", entry.Name(), err)
+		}
+		if prev, ok := epochs[epoch]; ok {
+			return nil, fmt.Errorf("duplicate era files for epoch %d: %s, %s", epoch, prev, entry.Name())
+		}
+		epochs[epoch] = entry.Name()
+		if next == -1 || epoch < next {
+			next = epoch
+		}
+	}
+	files := make([]string, 0, len(epochs))
+	for epoch := range epochs {
+		files = append(files, epochs[epoch])
+	}
+	sort.Strings(files)
+	for i, file := range files {
+		if file != epochs[next+i] {
+			return nil, fmt.Errorf("missing era file for epoch %d", next+i)
+		}
+	}
+	return files, nil
+}
+
+// Era is a reader of a single era file.
+type Era struct {
+	f       *os.File
+	r       *entryReader
+	start   uint64  // Number of the first block
+	offsets []int64 // Offsets of the header entries of the blocks
+	index   int64   // Offset of the block index entry
+}
+
+// Open opens the era file with the given path.
+func Open(path string) (*Era, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	e, err := newEra(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("invalid era file %s: %w", path, err)
+	}
+	return e, nil
+}
+
+// newEra parses the block index of the era file.
+func newEra(f *os.File) (*Era, error) {
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := stat.Size()
+	if size < 2*headerSize+24 {
+		return nil, errors.New("file too short")
+	}
+	r := &entryReader{r: f}
+	if _, err := readVersion(r); err != nil {
+		return nil, err
+	}
+	// The block count is at the very end of the file, which determines the
+	// position of the block index.
+	var buf [8]byte
+	if _, err := f.ReadAt(buf[:], size-8); err != nil {
+		return nil, err
+	}
+	count := binary.LittleEndian.Uint64(buf[:])
+	if count == 0 || count > MaxEraBatchSize {
+		return nil, fmt.Errorf("invalid block count %d", count)
+	}
+	index := size - headerSize - 16 - 8*int64(count)
+	value, _, err := r.readTypedAt(index, TypeBlockIndex)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(value)) != size-index-headerSize {
+		return nil, errors.New("block index is not the last entry")
+	}
+	e := &Era{
+		f:       f,
+		r:       r,
+		start:   binary.LittleEndian.Uint64(value[:8]),
+		offsets: make([]int64, count),
+		index:   index,
+	}
+	for i := range e.offsets {
+		off := binary.LittleEndian.Uint64(value[8+8*i:])
+		if off >= uint64(index) {
+			return nil, fmt.Errorf("block offset %d out of range", off)
+		}
+		e.offsets[i] = int64(off)
+	}
+	return e, nil
+}
+
+// readVersion reads the version entry at the beginning of the file.
+func readVersion(r *entryReader) (int64, error) {
+	value, n, err := r.readTypedAt(0, TypeVersion)
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 0 {
+		return 0, errors.New("version entry is not empty")
+	}
+	return n, nil
+}
+
+// Close closes the era file.
+func (e *Era) Close() error {
+	return e.f.Close()
+}
+
+// Start returns the number of the first block in the era file.
+func (e *Era) Start() uint64 {
+	return e.start
+}
+
+// Count returns the number of blocks in the era file.
+func (e *Era) Count() uint64 {
+	return uint64(len(e.offsets))
+}
+
+// Accumulator returns the accumulator root stored in the era file.
+func (e *Era) Accumulator() (common.Hash, error) {
+	value, _, err := e.r.readTypedAt(e.index-headerSize-common.HashLength, TypeAccumulator)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(value) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid accumulator length %d", len(value))
+	}
+	return common.BytesToHash(value), nil
+}
+
+// readBlock reads the decompressed header, body and receipts encodings of the
+// block with the given number.
+func (e *Era) readBlock(number uint64) (header, body, receipts []byte, err error) {
+	if number < e.start || number-e.start >= e.Count() {
+		return nil, nil, nil, fmt.Errorf("block %d out of range [%d, %d)", number, e.start, e.start+e.Count())
+	}
+	off := e.offsets[number-e.start]
+	for _, item := range []struct {
+		typ uint16
+		out *[]byte
+	}{
+		{TypeCompressedHeader, &header},
+		{TypeCompressedBody, &body},
+		{TypeCompressedReceipts, &receipts},
+	} {
+		value, n, err := e.r.readTypedAt(off, item.typ)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if *item.out, err = snappy.Decode(nil, value); err != nil {
+			return nil, nil, nil, err
+		}
+		off += n
+	}
+	return header, body, receipts, nil
+}
+
+// GetBlockByNumber returns the block with the given number.
+func (e *Era) GetBlockByNumber(number uint64) (*types.Block, error) {
+	block, _, err := e.GetBlockWithReceiptsByNumber(number)
+	return block, err
+}
+
+// GetBlockWithReceiptsByNumber returns the block with the given number along
+// with its receipts. Only the consensus fields of the receipts are populated.
+func (e *Era) GetBlockWithReceiptsByNumber(number uint64) (*types.Block, types.Receipts, error) {
+	headerRLP, bodyRLP, receiptsRLP, err := e.readBlock(number)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		header   types.Header
+		body     types.Body
+		storage  []*types.ReceiptForStorage
+		receipts types.Receipts
+	)
+	if err := rlp.DecodeBytes(headerRLP, &header); err != nil {
+		return nil, nil, fmt.Errorf("invalid header of block %d: %w", number, err)
+	}
+	if err := rlp.DecodeBytes(bodyRLP, &body); err != nil {
+		return nil, nil, fmt.Errorf("invalid body of block %d: %w", number, err)
+	}
+	if err := rlp.DecodeBytes(receiptsRLP, &storage); err != nil {
+		return nil, nil, fmt.Errorf("invalid receipts of block %d: %w", number, err)
+	}
+	if len(storage) != len(body.Transactions) {
+		return nil, nil, fmt.Errorf("receipt count mismatch in block %d: have %d, want %d", number, len(storage), len(body.Transactions))
+	}
+	for i, receipt := range storage {
+		receipt.Type = body.Transactions[i].Type()
+		receipt.Bloom = types.CreateBloom(types.Receipts{(*types.Receipt)(receipt)})
+		receipts = append(receipts, (*types.Receipt)(receipt))
+	}
+	return types.NewBlockWithHeader(&header).WithBody(body), receipts, nil
+}
+
+// Verify checks the integrity of the era file: the blocks are consecutive and
+// linked by their parent hashes, the bodies and receipts match the roots in
+// the headers, and the accumulator root matches the block hashes.
+func (e *Era) Verify() error {
+	var (
+		hashes = make([]common.Hash, 0, e.Count())
+		hasher = trie.NewStackTrie(nil)
+	)
+	for number := e.start; number < e.start+e.Count(); number++ {
+		block, receipts, err := e.GetBlockWithReceiptsByNumber(number)
+		if err != nil {
+			return err
+		}
+		if block.NumberU64() != number {
+			return fmt.Errorf("block number mismatch: have %d, want %d", block.NumberU64(), number)
+		}
+		if len(hashes) > 0 && block.ParentHash() != hashes[len(hashes)-1] {
+			return fmt.Errorf("block %d not linked to its parent", number)
+		}
+		if hash := types.DeriveSha(block.Transactions(), hasher); hash != block.TxHash() {
+			return fmt.Errorf("transaction root mismatch in block %d: have %x, want %x", number, hash, block.TxHash())
+		}
+		if want := block.Header().WithdrawalsHash; want != nil {
+			if block.Withdrawals() == nil {
+				return fmt.Errorf("missing withdrawals in block %d", number)
+			}
+			if hash := types.DeriveSha(block.Withdrawals(), hasher); hash != *want {
+				return fmt.Errorf("withdrawal root mismatch in block %d: have %x, want %x", number, hash, *want)
+			}
+		} else if block.Withdrawals() != nil {
+			return fmt.Errorf("unexpected withdrawals in block %d", number)
+		}
+		if hash := types.DeriveSha(receipts, hasher); hash != block.ReceiptHash() {
+			return fmt.Errorf("receipt root mismatch in block %d: have %x, want %x", number, hash, block.ReceiptHash())
+		}
+		if bloom := types.CreateBloom(receipts); bloom != block.Bloom() {
+			return fmt.Errorf("bloom mismatch in block %d", number)
+		}
+		hashes = append(hashes, block.Hash())
+	}
+	want, err := e.Accumulator()
+	if err != nil {
+		return err
+	}
+	have, err := ComputeAccumulator(hashes)
+	if err != nil {
+		return err
+	}
+	if have != want {
+		return fmt.Errorf("accumulator mismatch: have %x, want %x", have, want)
+	}
+	return nil
+}
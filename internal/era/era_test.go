@@ -0,0 +1,206 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
+)
+
+var testKey, _ = pqcrypto.HexToDilithium("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+
+// testChain is a chain reader backed by generated blocks and receipts.
+type testChain struct {
+	blocks   []*types.Block
+	receipts map[common.Hash]types.Receipts
+}
+
+func newTestChain(t *testing.T, n int) *testChain {
+	var (
+		config  = params.AllBeaconProtocolChanges
+		addr    = common.Address(testKey.GetAddress())
+		genesis = &core.Genesis{
+			Config:  config,
+			Alloc:   core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(config)
+	)
+	_, blocks, receipts := core.GenerateChainWithGenesis(genesis, beacon.NewFaker(), n, func(i int, g *core.BlockGen) {
+		if i%2 == 0 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     g.TxNonce(addr),
+			To:        &common.Address{0x01},
+			Value:     big.NewInt(1),
+			Gas:       params.TxGas,
+			GasFeeCap: g.BaseFee(),
+		}), signer, testKey)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		g.AddTx(tx)
+	})
+	chain := &testChain{
+		blocks:   append([]*types.Block{genesis.ToBlock()}, blocks...),
+		receipts: map[common.Hash]types.Receipts{genesis.ToBlock().Hash(): nil},
+	}
+	for i, block := range blocks {
+		chain.receipts[block.Hash()] = receipts[i]
+	}
+	return chain
+}
+
+func (c *testChain) GetBlockByNumber(number uint64) *types.Block {
+	if number >= uint64(len(c.blocks)) {
+		return nil
+	}
+	return c.blocks[number]
+}
+
+func (c *testChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return c.receipts[hash]
+}
+
+func TestExportAndRead(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chain = newTestChain(t, 20)
+		dir   = t.TempDir()
+	)
+	path, err := Export(chain, dir, "test", 0, uint64(len(chain.blocks)))
+	if err != nil {
+		t.Fatalf("failed to export era file: %v", err)
+	}
+	e, err := Open(path)
+	if err != nil {
+		t.Fatalf("failed to open era file: %v", err)
+	}
+	defer e.Close()
+
+	if err := e.Verify(); err != nil {
+		t.Fatalf("failed to verify era file: %v", err)
+	}
+	if e.Start() != 0 || e.Count() != uint64(len(chain.blocks)) {
+		t.Fatalf("range mismatch: have [%d, +%d), want [0, +%d)", e.Start(), e.Count(), len(chain.blocks))
+	}
+	hashes := make([]common.Hash, len(chain.blocks))
+	for i, want := range chain.blocks {
+		hashes[i] = want.Hash()
+
+		block, receipts, err := e.GetBlockWithReceiptsByNumber(uint64(i))
+		if err != nil {
+			t.Fatalf("failed to read block %d: %v", i, err)
+		}
+		if block.Hash() != want.Hash() {
+			t.Fatalf("block %d hash mismatch: have %x, want %x", i, block.Hash(), want.Hash())
+		}
+		if len(receipts) != len(chain.receipts[want.Hash()]) {
+			t.Fatalf("block %d receipt count mismatch: have %d, want %d", i, len(receipts), len(chain.receipts[want.Hash()]))
+		}
+	}
+	root, _ := ComputeAccumulator(hashes)
+	if have, _ := e.Accumulator(); have != root {
+		t.Fatalf("accumulator mismatch: have %x, want %x", have, root)
+	}
+	if name := Filename("test", 0, root); filepath.Base(path) != name {
+		t.Fatalf("filename mismatch: have %s, want %s", filepath.Base(path), name)
+	}
+	if _, err := e.GetBlockByNumber(uint64(len(chain.blocks))); err == nil {
+		t.Fatalf("out of range block retrieved")
+	}
+}
+
+func TestVerifyCorrupted(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t, 4)
+
+	// Write an era file with the receipts of a block altered
+	path := filepath.Join(t.TempDir(), "corrupted.era")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	builder := NewBuilder(f)
+	for _, block := range chain.blocks {
+		receipts := chain.receipts[block.Hash()]
+		if len(receipts) > 0 {
+			corrupted := *receipts[0]
+			corrupted.CumulativeGasUsed++
+			receipts = types.Receipts{&corrupted}
+		}
+		if err := builder.Add(block, receipts); err != nil {
+			t.Fatalf("failed to add block: %v", err)
+		}
+	}
+	if _, err := builder.Finalize(); err != nil {
+		t.Fatalf("failed to finalize era file: %v", err)
+	}
+	f.Close()
+
+	e, err := Open(path)
+	if err != nil {
+		t.Fatalf("failed to open era file: %v", err)
+	}
+	defer e.Close()
+
+	if err := e.Verify(); err == nil {
+		t.Fatalf("corrupted era file verified")
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{
+		Filename("test", 1, common.Hash{1}),
+		Filename("test", 0, common.Hash{0}),
+		Filename("other", 5, common.Hash{5}),
+		"unrelated.txt",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	files, err := ReadDir(dir, "test")
+	if err != nil {
+		t.Fatalf("failed to read directory: %v", err)
+	}
+	if len(files) != 2 || files[0] != Filename("test", 0, common.Hash{0}) || files[1] != Filename("test", 1, common.Hash{1}) {
+		t.Fatalf("era files mismatch: %v", files)
+	}
+	// Gaps between the epochs are rejected
+	if err := os.WriteFile(filepath.Join(dir, Filename("test", 3, common.Hash{3})), nil, 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if _, err := ReadDir(dir, "test"); err == nil {
+		t.Fatalf("gap between epochs not detected")
+	}
+}
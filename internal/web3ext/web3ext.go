@@ -22,9 +22,11 @@ var Modules = map[string]string{
 	"debug":  DebugJs,
 	"zond":   ZondJs,
 	"miner":  MinerJs,
+	"bundle": BundleJs,
 	"net":    NetJs,
 	"rpc":    RpcJs,
 	"txpool": TxpoolJs,
+	"trace":  TraceJs,
 	"dev":    DevJs,
 }
 
@@ -88,6 +90,17 @@ web3._extend({
 			name: 'stopWS',
 			call: 'admin_stopWS'
 		}),
+		new web3._extend.Method({
+			name: 'dropLocalTransaction',
+			call: 'admin_dropLocalTransaction',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'rebroadcastLocalTransactions',
+			call: 'admin_rebroadcastLocalTransactions',
+			params: 1,
+			inputFormatter: [null]
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -102,6 +115,10 @@ web3._extend({
 			name: 'datadir',
 			getter: 'admin_datadir'
 		}),
+		new web3._extend.Property({
+			name: 'localTransactions',
+			getter: 'admin_localTransactions'
+		}),
 	]
 });
 `
@@ -335,6 +352,12 @@ web3._extend({
 			params: 3,
 			inputFormatter: [null, null, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceCallMany',
+			call: 'debug_traceCallMany',
+			params: 3,
+			inputFormatter: [null, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'preimage',
 			call: 'debug_preimage',
@@ -363,6 +386,12 @@ web3._extend({
 			params: 2,
 			inputFormatter:[null, null],
 		}),
+		new web3._extend.Method({
+			name: 'getStateDiff',
+			call: 'debug_getStateDiff',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'freezeClient',
 			call: 'debug_freezeClient',
@@ -515,6 +544,12 @@ web3._extend({
 			params: 4,
 			inputFormatter: [web3._extend.formatters.inputCallFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter, null, null],
 		}),
+		new web3._extend.Method({
+			name: 'simulateV1',
+			call: 'zond_simulateV1',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'getBlockReceipts',
 			call: 'zond_getBlockReceipts',
@@ -569,6 +604,20 @@ web3._extend({
 });
 `
 
+const BundleJs = `
+web3._extend({
+	property: 'bundle',
+	methods: [
+		new web3._extend.Method({
+			name: 'sendBundle',
+			call: 'bundle_sendBundle',
+			params: 1
+		}),
+	],
+	properties: []
+});
+`
+
 const NetJs = `
 web3._extend({
 	property: 'net',
@@ -627,6 +676,37 @@ web3._extend({
 });
 `
 
+const TraceJs = `
+web3._extend({
+	property: 'trace',
+	methods: [
+		new web3._extend.Method({
+			name: 'block',
+			call: 'trace_block',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'transaction',
+			call: 'trace_transaction',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'filter',
+			call: 'trace_filter',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'replayBlockTransactions',
+			call: 'trace_replayBlockTransactions',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+	],
+	properties: []
+});
+`
+
 const DevJs = `
 web3._extend({
 	property: 'dev',
@@ -41,6 +41,7 @@ import (
 	"github.com/theQRL/go-zond/core/bloombits"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/txpool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto"
@@ -75,7 +76,7 @@ func testTransactionMarshal(t *testing.T, tests []txData, config *params.ChainCo
 		}
 
 		// rpcTransaction
-		rpcTx := newRPCTransaction(tx, common.Hash{}, 0, 0, nil, config)
+		rpcTx := newRPCTransaction(tx, common.Hash{}, 0, 0, 0, nil, config)
 		if data, err := json.Marshal(rpcTx); err != nil {
 			t.Fatalf("test %d: marshalling failed; %v", i, err)
 		} else if err = tx2.UnmarshalJSON(data); err != nil {
@@ -261,6 +262,7 @@ func (b testBackend) HeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc
 }
 func (b testBackend) CurrentHeader() *types.Header { return b.chain.CurrentBlock() }
 func (b testBackend) CurrentBlock() *types.Header  { return b.chain.CurrentBlock() }
+func (b testBackend) HistoryPruningCutoff() uint64 { return b.chain.HistoryPruningCutoff() }
 func (b testBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
 	if number == rpc.LatestBlockNumber {
 		head := b.chain.CurrentBlock()
@@ -355,6 +357,9 @@ func (b testBackend) TxPoolContent() (map[common.Address][]*types.Transaction, m
 func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
 	panic("implement me")
 }
+func (b testBackend) TxPoolPrediction(hash common.Hash) *txpool.Prediction {
+	panic("implement me")
+}
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
 	panic("implement me")
 }
@@ -646,6 +651,155 @@ func TestCall(t *testing.T) {
 	}
 }
 
+func TestSimulateV1(t *testing.T) {
+	t.Parallel()
+	// Initialize test accounts
+	var (
+		accounts = newAccounts(3)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		genBlocks = 10
+		signer    = types.LatestSigner(params.TestChainConfig)
+	)
+	api := NewBlockChainAPI(newTestBackend(t, genBlocks, genesis, beacon.NewFaker(), func(i int, b *core.BlockGen) {
+		// Transfer from account[0] to account[1]
+		//    value: 1000 wei
+		//    fee:   0 wei
+		tx, _ := types.SignTx(types.NewTx(&types.DynamicFeeTx{Nonce: uint64(i), To: &accounts[1].addr, Value: big.NewInt(1000), Gas: params.TxGas, GasFeeCap: b.BaseFee(), Data: nil}), signer, accounts[0].key)
+		b.AddTx(tx)
+	}))
+	var (
+		randomAccounts = newAccounts(2)
+		logger         = common.Address{0xc0}
+		reverter       = common.Address{0xc1}
+		overrides      = StateOverride{
+			logger:           OverrideAccount{Code: &hexutil.Bytes{0x60, 0x00, 0x60, 0x00, 0xa0}}, // LOG0(0, 0)
+			reverter:         OverrideAccount{Code: &hexutil.Bytes{0x60, 0x00, 0x60, 0x00, 0xfd}}, // REVERT(0, 0)
+			accounts[2].addr: OverrideAccount{Balance: newRPCBalance(big.NewInt(params.Ether))},   // only funded in the simulation
+		}
+		latest = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	// Simulate dependent transfers spread over multiple blocks, along with
+	// calls emitting logs and reverting.
+	results, err := api.SimulateV1(context.Background(), simOpts{
+		BlockStateCalls: []simBlock{
+			{
+				StateOverrides: &overrides,
+				Calls: []simCall{
+					{TransactionArgs: TransactionArgs{From: &accounts[2].addr, To: &randomAccounts[0].addr, Value: (*hexutil.Big)(big.NewInt(1000))}},
+					{TransactionArgs: TransactionArgs{From: &accounts[2].addr, To: &logger}},
+				},
+			},
+			{
+				Calls: []simCall{
+					{TransactionArgs: TransactionArgs{From: &randomAccounts[0].addr, To: &randomAccounts[1].addr, Value: (*hexutil.Big)(big.NewInt(1000))}},
+					{TransactionArgs: TransactionArgs{From: &randomAccounts[0].addr, To: &reverter}},
+				},
+			},
+		},
+	}, &latest)
+	if err != nil {
+		t.Fatalf("failed to simulate: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("block count mismatch: have %d, want 2", len(results))
+	}
+	var parentHash common.Hash
+	for i, result := range results {
+		if have, want := result["number"].(*hexutil.Big).ToInt().Uint64(), uint64(genBlocks+i+1); have != want {
+			t.Errorf("block %d: number mismatch: have %d, want %d", i, have, want)
+		}
+		if i > 0 && result["parentHash"].(common.Hash) != parentHash {
+			t.Errorf("block %d: parent hash mismatch: have %x, want %x", i, result["parentHash"], parentHash)
+		}
+		parentHash = result["hash"].(common.Hash)
+	}
+	first, second := results[0]["calls"].([]simCallResult), results[1]["calls"].([]simCallResult)
+	if first[0].Status != hexutil.Uint64(types.ReceiptStatusSuccessful) || first[0].GasUsed != hexutil.Uint64(params.TxGas) {
+		t.Errorf("transfer failed: status %d, gas used %d, error %v", first[0].Status, first[0].GasUsed, first[0].Error)
+	}
+	if len(first[1].Logs) != 1 || first[1].Logs[0].Address != logger || first[1].Logs[0].BlockHash != results[0]["hash"].(common.Hash) {
+		t.Errorf("log mismatch: have %v", first[1].Logs)
+	}
+	if second[0].Status != hexutil.Uint64(types.ReceiptStatusSuccessful) {
+		t.Errorf("dependent transfer failed: %v", second[0].Error)
+	}
+	if second[1].Status != hexutil.Uint64(types.ReceiptStatusFailed) || second[1].Error == nil || second[1].Error.Code != errCodeReverted {
+		t.Errorf("revert mismatch: status %d, error %v", second[1].Status, second[1].Error)
+	}
+	// Simulate a call reading the blob base fee, which must be derived for the
+	// simulated blocks after Cancun.
+	results, err = api.SimulateV1(context.Background(), simOpts{
+		BlockStateCalls: []simBlock{
+			{
+				StateOverrides: &StateOverride{
+					randomAccounts[1].addr: OverrideAccount{Code: &hexutil.Bytes{0x4a, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}}, // MSTORE(0, BLOBBASEFEE), RETURN(0, 32)
+				},
+				Calls: []simCall{{TransactionArgs: TransactionArgs{From: &accounts[2].addr, To: &randomAccounts[1].addr}}},
+			},
+		},
+	}, &latest)
+	if err != nil {
+		t.Fatalf("failed to simulate blob base fee: %v", err)
+	}
+	if results[0]["excessBlobGas"] == nil || results[0]["blobGasUsed"] == nil {
+		t.Errorf("blob gas fields missing in simulated block")
+	}
+	blobCall := results[0]["calls"].([]simCallResult)[0]
+	if have, want := new(big.Int).SetBytes(blobCall.ReturnValue), big.NewInt(params.BlobTxMinBlobGasprice); have.Cmp(want) != 0 {
+		t.Errorf("blob base fee mismatch: have %v, want %v, error %v", have, want, blobCall.Error)
+	}
+	// Simulate signed transactions with validation enabled.
+	signed := func(nonce uint64, tamper bool) simCall {
+		gas := params.TxGas
+		args := TransactionArgs{
+			From:                 &accounts[0].addr,
+			To:                   &accounts[1].addr,
+			ChainID:              (*hexutil.Big)(params.TestChainConfig.ChainID),
+			Nonce:                (*hexutil.Uint64)(&nonce),
+			Gas:                  (*hexutil.Uint64)(&gas),
+			MaxFeePerGas:         (*hexutil.Big)(big.NewInt(params.GWei)),
+			MaxPriorityFeePerGas: (*hexutil.Big)(big.NewInt(0)),
+			Value:                (*hexutil.Big)(big.NewInt(1000)),
+		}
+		tx, _ := types.SignTx(args.toTransaction(), signer, accounts[0].key)
+		if tamper {
+			args.Value = (*hexutil.Big)(big.NewInt(2000))
+		}
+		sig, pub := hexutil.Bytes(tx.RawSignatureValue()), hexutil.Bytes(tx.RawPublicKeyValue())
+		return simCall{TransactionArgs: args, Signature: &sig, PublicKey: &pub}
+	}
+	var testSuite = []struct {
+		call     simCall
+		wantCode int
+	}{
+		{call: signed(uint64(genBlocks), false)},
+		{call: signed(uint64(genBlocks), true), wantCode: errCodeInvalidSignature},
+		{call: signed(uint64(genBlocks+1), false), wantCode: errCodeNonceTooHigh},
+	}
+	for i, tc := range testSuite {
+		_, err := api.SimulateV1(context.Background(), simOpts{
+			BlockStateCalls: []simBlock{{Calls: []simCall{tc.call}}},
+			Validation:      true,
+		}, &latest)
+		if tc.wantCode == 0 {
+			if err != nil {
+				t.Errorf("test %d: want no error, have %v", i, err)
+			}
+			continue
+		}
+		var txErr *invalidTxError
+		if !errors.As(err, &txErr) || txErr.Code != tc.wantCode {
+			t.Errorf("test %d: error mismatch, have %v, want code %d", i, err, tc.wantCode)
+		}
+	}
+}
+
 type Account struct {
 	key  *dilithium.Dilithium
 	addr common.Address
@@ -0,0 +1,462 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package zondapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/consensus"
+	"github.com/theQRL/go-zond/consensus/misc/eip1559"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/rpc"
+	"github.com/theQRL/go-zond/trie"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks that can be simulated
+	// in a single request.
+	maxSimulateBlocks = 256
+
+	// timestampIncrement is the default increment between block timestamps.
+	timestampIncrement = 12
+)
+
+// simBlock is a batch of calls to be simulated sequentially.
+type simBlock struct {
+	BlockOverrides *BlockOverrides `json:"blockOverrides"`
+	StateOverrides *StateOverride  `json:"stateOverrides"`
+	Calls          []simCall       `json:"calls"`
+}
+
+// simCall is a call to be simulated. Besides the regular call arguments it may
+// carry the signature the transaction would be sent with, which is verified in
+// validation mode. If the public key is omitted, the signature is checked
+// against the key registered for the sender.
+type simCall struct {
+	TransactionArgs
+	PublicKey *hexutil.Bytes `json:"publicKey"`
+	Signature *hexutil.Bytes `json:"signature"`
+}
+
+// toTransaction converts the call to a transaction, carrying the signature if
+// one was supplied. Signed calls without a public key are converted to registered
+// key transactions.
+func (call *simCall) toTransaction(signer types.Signer) (*types.Transaction, error) {
+	if call.Signature == nil {
+		return call.TransactionArgs.toTransaction(), nil
+	}
+	if call.PublicKey != nil {
+		return call.TransactionArgs.toTransaction().WithSignatureAndPublicKey(signer, *call.Signature, *call.PublicKey)
+	}
+	al := types.AccessList{}
+	if call.AccessList != nil {
+		al = *call.AccessList
+	}
+	return types.NewTx(&types.RegisteredKeyTx{
+		To:         call.To,
+		ChainID:    (*big.Int)(call.ChainID),
+		Nonce:      uint64(*call.Nonce),
+		Gas:        uint64(*call.Gas),
+		GasFeeCap:  (*big.Int)(call.MaxFeePerGas),
+		GasTipCap:  (*big.Int)(call.MaxPriorityFeePerGas),
+		Value:      (*big.Int)(call.Value),
+		Data:       call.data(),
+		AccessList: al,
+		From:       call.from(),
+		Signature:  *call.Signature,
+	}), nil
+}
+
+// simCallResult is the result of a simulated call.
+type simCallResult struct {
+	ReturnValue hexutil.Bytes  `json:"returnData"`
+	Logs        []*types.Log   `json:"logs"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Status      hexutil.Uint64 `json:"status"`
+	Error       *callError     `json:"error,omitempty"`
+}
+
+func (r *simCallResult) MarshalJSON() ([]byte, error) {
+	type callResultAlias simCallResult
+	// Marshal logs to be an empty array instead of nil when empty
+	if r.Logs == nil {
+		r.Logs = []*types.Log{}
+	}
+	return json.Marshal((*callResultAlias)(r))
+}
+
+// simOpts are the inputs to zond_simulateV1.
+type simOpts struct {
+	BlockStateCalls        []simBlock `json:"blockStateCalls"`
+	Validation             bool       `json:"validation"`
+	ReturnFullTransactions bool       `json:"returnFullTransactions"`
+}
+
+// simulator is a stateful object that simulates a series of blocks.
+// it is not safe for concurrent use.
+type simulator struct {
+	b           Backend
+	state       *state.StateDB
+	base        *types.Header
+	chainConfig *params.ChainConfig
+	gp          *core.GasPool
+	validate    bool
+	fullTx      bool
+}
+
+// execute runs the simulation of a series of blocks.
+func (sim *simulator) execute(ctx context.Context, blocks []simBlock) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var (
+		cancel  context.CancelFunc
+		timeout = sim.b.RPCZVMTimeout()
+	)
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	// Make sure the context is cancelled when the call has completed
+	// this makes sure resources are cleaned up.
+	defer cancel()
+
+	var err error
+	blocks, err = sim.sanitizeChain(blocks)
+	if err != nil {
+		return nil, err
+	}
+	// Prepare block headers with preliminary fields for the response.
+	headers, err := sim.makeHeaders(blocks)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		results = make([]map[string]interface{}, len(blocks))
+		parent  = sim.base
+	)
+	for bi, block := range blocks {
+		result, callResults, err := sim.processBlock(ctx, &block, headers[bi], parent, headers[:bi], timeout)
+		if err != nil {
+			return nil, err
+		}
+		enc := RPCMarshalBlock(result, true, sim.fullTx, sim.chainConfig)
+		enc["calls"] = callResults
+		results[bi] = enc
+
+		headers[bi] = result.Header()
+		parent = headers[bi]
+	}
+	return results, nil
+}
+
+func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header, parent *types.Header, headers []*types.Header, timeout time.Duration) (*types.Block, []simCallResult, error) {
+	// Set header fields that depend only on parent block.
+	// Parent hash is needed for zvm.GetHashFn to work.
+	header.ParentHash = parent.Hash()
+	// In non-validation mode base fee is set to 0 if it is not overridden.
+	// This is because it creates an edge case in ZVM where gasPrice < baseFee.
+	if header.BaseFee == nil {
+		if sim.validate {
+			header.BaseFee = eip1559.CalcBaseFee(sim.chainConfig, parent)
+		} else {
+			header.BaseFee = big.NewInt(0)
+		}
+	}
+	// The blob fee market fields are derived from the parent, they are needed
+	// for the blob base fee to be available in the block context.
+	if sim.chainConfig.IsCancun(header.Number, header.Time) {
+		var excessBlobGas uint64
+		if parent.ExcessBlobGas != nil {
+			excessBlobGas = eip1559.CalcExcessBlobGas(*parent.ExcessBlobGas, *parent.BlobGasUsed)
+		}
+		header.ExcessBlobGas = &excessBlobGas
+		header.BlobGasUsed = new(uint64)
+	}
+	blockContext := core.NewZVMBlockContext(header, sim.newSimulatedChainContext(ctx, headers), nil)
+
+	// State overrides are applied prior to execution of a block
+	if err := block.StateOverrides.Apply(sim.state); err != nil {
+		return nil, nil, err
+	}
+	var (
+		gasUsed     uint64
+		txes        = make([]*types.Transaction, len(block.Calls))
+		callResults = make([]simCallResult, len(block.Calls))
+		receipts    = make([]*types.Receipt, len(block.Calls))
+		signer      = types.MakeSigner(sim.chainConfig, header.Number, header.Time)
+		vmConfig    = &vm.Config{NoBaseFee: !sim.validate}
+		zvm         = vm.NewZVM(blockContext, vm.TxContext{}, sim.state, sim.chainConfig, *vmConfig)
+	)
+	// Wait for the context to be done and cancel the zvm. Even if the
+	// ZVM has finished, cancelling may be done (repeatedly)
+	go func() {
+		<-ctx.Done()
+		zvm.Cancel()
+	}()
+	core.InstallSystemContracts(sim.chainConfig, parent, header, sim.state)
+	if header.ParentBeaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, zvm, sim.state)
+	}
+	if sim.chainConfig.IsPrague(header.Number, header.Time) {
+		core.ProcessParentBlockHash(header.ParentHash, zvm, sim.state)
+	}
+	for i, call := range block.Calls {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		if err := sim.sanitizeCall(&call, blockContext, &gasUsed); err != nil {
+			return nil, nil, err
+		}
+		tx, err := call.toTransaction(signer)
+		if err != nil {
+			return nil, nil, err
+		}
+		txes[i] = tx
+		sim.state.SetTxContext(tx.Hash(), i)
+
+		msg, err := sim.toMessage(&call, tx, signer, header.BaseFee)
+		if err != nil {
+			return nil, nil, txValidationError(err)
+		}
+		zvm.Reset(core.NewZVMTxContext(msg), sim.state)
+		result, err := core.ApplyMessage(zvm, msg, sim.gp)
+		if err != nil {
+			return nil, nil, txValidationError(err)
+		}
+		if err := sim.state.Error(); err != nil {
+			return nil, nil, err
+		}
+		// If the timer caused an abort, return an appropriate error message
+		if zvm.Cancelled() {
+			return nil, nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+		}
+		// Update the state with pending changes.
+		sim.state.Finalise(true)
+
+		gasUsed += result.UsedGas
+		receipts[i] = core.MakeReceipt(zvm, result, sim.state, blockContext.BlockNumber, common.Hash{}, tx, gasUsed, nil)
+		if header.BlobGasUsed != nil {
+			*header.BlobGasUsed += receipts[i].BlobGasUsed
+		}
+
+		callRes := simCallResult{ReturnValue: result.Return(), Logs: receipts[i].Logs, GasUsed: hexutil.Uint64(result.UsedGas)}
+		if result.Failed() {
+			callRes.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+			if errors.Is(result.Err, vm.ErrExecutionReverted) {
+				// If the result contains a revert reason, try to unpack it.
+				revertErr := newRevertError(result)
+				callRes.Error = &callError{Message: revertErr.Error(), Code: errCodeReverted, Data: revertErr.reason}
+			} else {
+				callRes.Error = &callError{Message: result.Err.Error(), Code: errCodeVMError}
+			}
+		} else {
+			callRes.Status = hexutil.Uint64(types.ReceiptStatusSuccessful)
+		}
+		callResults[i] = callRes
+	}
+	header.Root = sim.state.IntermediateRoot(true)
+	header.GasUsed = gasUsed
+
+	b := types.NewBlock(header, &types.Body{Transactions: txes, Withdrawals: make([]*types.Withdrawal, 0)}, receipts, trie.NewStackTrie(nil))
+	repairLogs(callResults, b.Hash())
+	return b, callResults, nil
+}
+
+// toMessage converts a simulated call to the message to execute. In validation
+// mode, calls carrying a signature are checked like regular transactions:
+// embedded public keys are verified and registered, while signatures without
+// one are verified against the key registered for the sender.
+func (sim *simulator) toMessage(call *simCall, tx *types.Transaction, signer types.Signer, baseFee *big.Int) (*core.Message, error) {
+	if !sim.validate || call.Signature == nil {
+		msg, err := call.ToMessage(sim.gp.Gas(), baseFee)
+		if err != nil {
+			return nil, err
+		}
+		msg.SkipAccountChecks = !sim.validate
+		return msg, nil
+	}
+	if err := types.VerifySignature(signer, tx); err != nil {
+		return nil, err
+	}
+	msg, err := core.TransactionToMessage(tx, signer, baseFee)
+	if err != nil {
+		return nil, err
+	}
+	if call.From != nil && msg.From != *call.From {
+		return nil, fmt.Errorf("%w: public key does not match sender %v", types.ErrInvalidSig, call.From.Hex())
+	}
+	return msg, nil
+}
+
+// repairLogs updates the block hash in the logs present in the result of
+// a simulated block. This is needed as during execution when logs are collected
+// the block hash is not known.
+func repairLogs(calls []simCallResult, hash common.Hash) {
+	for i := range calls {
+		for j := range calls[i].Logs {
+			calls[i].Logs[j].BlockHash = hash
+		}
+	}
+}
+
+// sanitizeCall fills in the defaults of a simulated call, letting it consume
+// the remaining gas of the block unless specified otherwise.
+func (sim *simulator) sanitizeCall(call *simCall, blockContext vm.BlockContext, gasUsed *uint64) error {
+	if call.Nonce == nil {
+		nonce := sim.state.GetNonce(call.from())
+		call.Nonce = (*hexutil.Uint64)(&nonce)
+	}
+	// Let the call run wild unless explicitly specified.
+	if call.Gas == nil {
+		remaining := blockContext.GasLimit - *gasUsed
+		call.Gas = (*hexutil.Uint64)(&remaining)
+	}
+	if *gasUsed+uint64(*call.Gas) > blockContext.GasLimit {
+		return &blockGasLimitReachedError{fmt.Sprintf("block gas limit reached: %d >= %d", *gasUsed, blockContext.GasLimit)}
+	}
+	return call.callDefaults(sim.gp.Gas(), sim.chainConfig.ChainID)
+}
+
+// sanitizeChain checks the chain integrity. Specifically it checks that
+// block numbers and timestamp are strictly increasing, setting default values
+// when necessary. Gaps in block numbers are filled with empty blocks.
+// Note: It modifies the block's override object.
+func (sim *simulator) sanitizeChain(blocks []simBlock) ([]simBlock, error) {
+	var (
+		res           = make([]simBlock, 0, len(blocks))
+		base          = sim.base
+		prevNumber    = base.Number
+		prevTimestamp = base.Time
+	)
+	for _, block := range blocks {
+		if block.BlockOverrides == nil {
+			block.BlockOverrides = new(BlockOverrides)
+		}
+		if block.BlockOverrides.Number == nil {
+			n := new(big.Int).Add(prevNumber, big.NewInt(1))
+			block.BlockOverrides.Number = (*hexutil.Big)(n)
+		}
+		diff := new(big.Int).Sub(block.BlockOverrides.Number.ToInt(), prevNumber)
+		if diff.Cmp(common.Big0) <= 0 {
+			return nil, &invalidBlockNumberError{fmt.Sprintf("block numbers must be in order: %d <= %d", block.BlockOverrides.Number.ToInt().Uint64(), prevNumber)}
+		}
+		if total := new(big.Int).Sub(block.BlockOverrides.Number.ToInt(), base.Number); total.Cmp(big.NewInt(maxSimulateBlocks)) > 0 {
+			return nil, &clientLimitExceededError{message: "too many blocks"}
+		}
+		if diff.Cmp(big.NewInt(1)) > 0 {
+			// Fill the gap with empty blocks.
+			gap := new(big.Int).Sub(diff, big.NewInt(1))
+			// Assign block number to the empty blocks.
+			for i := uint64(0); i < gap.Uint64(); i++ {
+				n := new(big.Int).Add(prevNumber, big.NewInt(int64(i+1)))
+				t := prevTimestamp + timestampIncrement
+				b := simBlock{BlockOverrides: &BlockOverrides{Number: (*hexutil.Big)(n), Time: (*hexutil.Uint64)(&t)}}
+				prevTimestamp = t
+				res = append(res, b)
+			}
+		}
+		// Only append block after filling a potential gap.
+		prevNumber = block.BlockOverrides.Number.ToInt()
+		var t uint64
+		if block.BlockOverrides.Time == nil {
+			t = prevTimestamp + timestampIncrement
+			block.BlockOverrides.Time = (*hexutil.Uint64)(&t)
+		} else {
+			t = uint64(*block.BlockOverrides.Time)
+			if t <= prevTimestamp {
+				return nil, &invalidBlockTimestampError{fmt.Sprintf("block timestamps must be in order: %d <= %d", t, prevTimestamp)}
+			}
+		}
+		prevTimestamp = t
+		res = append(res, block)
+	}
+	return res, nil
+}
+
+// makeHeaders makes header object with preliminary fields based on a simulated block.
+// Some fields have to be filled post-execution.
+// It assumes blocks are in order and numbers have been validated.
+func (sim *simulator) makeHeaders(blocks []simBlock) ([]*types.Header, error) {
+	var (
+		res    = make([]*types.Header, len(blocks))
+		header = sim.base
+	)
+	for bi, block := range blocks {
+		if block.BlockOverrides == nil || block.BlockOverrides.Number == nil {
+			return nil, errors.New("empty block number")
+		}
+		header = block.BlockOverrides.MakeHeader(&types.Header{
+			ReceiptHash:     types.EmptyReceiptsHash,
+			TxHash:          types.EmptyTxsHash,
+			Coinbase:        header.Coinbase,
+			GasLimit:        header.GasLimit,
+			WithdrawalsHash: &types.EmptyWithdrawalsHash,
+		})
+		// There is no beacon chain behind simulated blocks, use the empty root
+		if sim.chainConfig.IsCancun(header.Number, header.Time) {
+			header.ParentBeaconRoot = new(common.Hash)
+		}
+		res[bi] = header
+	}
+	return res, nil
+}
+
+func (sim *simulator) newSimulatedChainContext(ctx context.Context, headers []*types.Header) *ChainContext {
+	return NewChainContext(ctx, &simBackend{base: sim.base, b: sim.b, headers: headers})
+}
+
+// simBackend resolves the headers of both the canonical chain and the blocks
+// simulated so far.
+type simBackend struct {
+	b       ChainContextBackend
+	base    *types.Header
+	headers []*types.Header
+}
+
+func (b *simBackend) Engine() consensus.Engine {
+	return b.b.Engine()
+}
+
+func (b *simBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	if uint64(number) == b.base.Number.Uint64() {
+		return b.base, nil
+	}
+	if uint64(number) < b.base.Number.Uint64() {
+		// Resolve canonical header.
+		return b.b.HeaderByNumber(ctx, number)
+	}
+	// Simulated block.
+	for _, header := range b.headers {
+		if header.Number.Uint64() == uint64(number) {
+			return header, nil
+		}
+	}
+	return nil, errors.New("header not found")
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto"
+)
+
+const (
+	// maxBundles is the maximum number of bundles tracked by the miner.
+	maxBundles = 1024
+
+	// maxBundleTxs is the maximum number of transactions in a single bundle.
+	maxBundleTxs = 64
+)
+
+var (
+	errBundleEmpty    = errors.New("bundle is empty")
+	errBundleTooLarge = errors.New("bundle has too many transactions")
+	errBundleBlobTx   = errors.New("blob transactions are not allowed in bundles")
+	errBundleStale    = errors.New("bundle targets a past block")
+	errBundleTime     = errors.New("bundle timestamp range is empty")
+	errBundlesFull    = errors.New("too many pending bundles")
+	errBundleReverted = errors.New("bundle transaction reverted")
+)
+
+// Bundle is an atomic group of transactions submitted to the miner. Either all
+// of its transactions are included in order into the target block, or none.
+type Bundle struct {
+	Txs          types.Transactions
+	BlockNumber  uint64 // Number of the block the bundle is valid for
+	MinTimestamp uint64 // Minimum timestamp of the block, 0 means no limit
+	MaxTimestamp uint64 // Maximum timestamp of the block, 0 means no limit
+}
+
+// Hash returns the identifier of the bundle, the hash of the concatenated hashes
+// of its transactions.
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
+
+// validFor reports whether the bundle may be included into the given block.
+func (b *Bundle) validFor(header *types.Header) bool {
+	if header.Number.Uint64() != b.BlockNumber {
+		return false
+	}
+	if b.MinTimestamp != 0 && header.Time < b.MinTimestamp {
+		return false
+	}
+	if b.MaxTimestamp != 0 && header.Time > b.MaxTimestamp {
+		return false
+	}
+	return true
+}
+
+// bundlePool keeps the bundles submitted to the miner until their target block
+// is passed.
+type bundlePool struct {
+	bundles []*Bundle
+	lock    sync.Mutex
+}
+
+// add inserts a bundle into the pool, dropping the ones targeting blocks not
+// above the given head.
+func (p *bundlePool) add(bundle *Bundle, head uint64) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.prune(head + 1)
+	if len(p.bundles) >= maxBundles {
+		return errBundlesFull
+	}
+	p.bundles = append(p.bundles, bundle)
+	return nil
+}
+
+// pending returns the bundles valid for the given block in submission order,
+// dropping the ones targeting earlier blocks.
+func (p *bundlePool) pending(header *types.Header) []*Bundle {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.prune(header.Number.Uint64())
+
+	var bundles []*Bundle
+	for _, bundle := range p.bundles {
+		if bundle.validFor(header) {
+			bundles = append(bundles, bundle)
+		}
+	}
+	return bundles
+}
+
+// prune drops the bundles targeting blocks below the given number.
+func (p *bundlePool) prune(number uint64) {
+	bundles := p.bundles[:0]
+	for _, bundle := range p.bundles {
+		if bundle.BlockNumber >= number {
+			bundles = append(bundles, bundle)
+		}
+	}
+	clear(p.bundles[len(bundles):])
+	p.bundles = bundles
+}
+
+// SendBundle submits a bundle of transactions for inclusion into its target
+// block. The bundles are only considered by the ordering strategies making use
+// of them, such as BundleStrategy.
+func (miner *Miner) SendBundle(bundle *Bundle) error {
+	if len(bundle.Txs) == 0 {
+		return errBundleEmpty
+	}
+	if len(bundle.Txs) > maxBundleTxs {
+		return fmt.Errorf("%w: have %d, limit %d", errBundleTooLarge, len(bundle.Txs), maxBundleTxs)
+	}
+	if bundle.MaxTimestamp != 0 && bundle.MaxTimestamp < bundle.MinTimestamp {
+		return errBundleTime
+	}
+	head := miner.chain.CurrentBlock()
+	if bundle.BlockNumber <= head.Number.Uint64() {
+		return fmt.Errorf("%w: target %d, head %d", errBundleStale, bundle.BlockNumber, head.Number)
+	}
+	signer := types.LatestSigner(miner.chainConfig)
+	for _, tx := range bundle.Txs {
+		if tx.Type() == types.BlobTxType {
+			return errBundleBlobTx
+		}
+		if err := core.SenderVerifier.Verify(signer, tx); err != nil {
+			return fmt.Errorf("invalid transaction %x: %w", tx.Hash(), err)
+		}
+	}
+	return miner.bundles.add(bundle, head.Number.Uint64())
+}
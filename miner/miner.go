@@ -47,6 +47,9 @@ type Config struct {
 	GasCeil             uint64         // Target gas ceiling for mined blocks.
 	GasPrice            *big.Int       // Minimum gas price for mining a transaction
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
+	Strategy            string         // Name of the transaction ordering strategy
+	SkipReverting       bool           // Whether to skip the transactions predicted to revert by the pool
+	Lanes               []txpool.Lane  `toml:"-"` // Priority lanes of the transaction pool to reserve gas for
 }
 
 // DefaultConfig contains default settings for miner.
@@ -59,6 +62,8 @@ var DefaultConfig = Config{
 	// for payload generation. It should be enough for Gzond to
 	// run 3 rounds.
 	Recommit: 10 * time.Second,
+
+	Strategy: PriceStrategy,
 }
 
 // Miner is the main object which takes care of submitting new work to consensus
@@ -72,6 +77,8 @@ type Miner struct {
 	chain       *core.BlockChain
 	pending     *pending
 	pendingMu   sync.Mutex // Lock protects the pending block
+	strategy    Strategy   // Transaction ordering strategy used to fill blocks
+	bundles     *bundlePool
 }
 
 // New creates a new miner with provided config.
@@ -83,6 +90,8 @@ func New(zond Backend, config Config, engine consensus.Engine) *Miner {
 		txpool:      zond.TxPool(),
 		chain:       zond.BlockChain(),
 		pending:     &pending{},
+		strategy:    newStrategy(config.Strategy),
+		bundles:     new(bundlePool),
 	}
 }
 
@@ -155,7 +155,7 @@ func createMiner(t *testing.T) *Miner {
 	statedb, _ := state.New(bc.Genesis().Root(), bc.StateCache(), nil)
 	blockchain := &testBlockChain{bc.Genesis().Root(), chainConfig, statedb, 10000000, new(event.Feed)}
 
-	pool := legacypool.New(testTxPoolConfig, blockchain)
+	pool := legacypool.New(testTxPoolConfig, blockchain, nil)
 	txpool, _ := txpool.New(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), blockchain, []txpool.SubPool{pool})
 
 	// Create Miner
@@ -63,7 +63,6 @@ var (
 
 func init() {
 	testTxPoolConfig = legacypool.DefaultConfig
-	testTxPoolConfig.Journal = ""
 	beaconChainConfig = new(params.ChainConfig)
 	*beaconChainConfig = *params.TestChainConfig
 
@@ -110,7 +109,7 @@ func newTestWorkerBackend(t *testing.T, chainConfig *params.ChainConfig, engine
 	if err != nil {
 		t.Fatalf("core.NewBlockChain failed: %v", err)
 	}
-	pool := legacypool.New(testTxPoolConfig, chain)
+	pool := legacypool.New(testTxPoolConfig, chain, nil)
 	txpool, _ := txpool.New(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), chain, []txpool.SubPool{pool})
 
 	return &testWorkerBackend{
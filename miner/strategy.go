@@ -0,0 +1,269 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/misc/eip1559"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/log"
+)
+
+const (
+	// PriceStrategy orders the pending transactions by effective miner tip,
+	// honouring the nonce order of each account. Local transactions are
+	// included ahead of the remote ones.
+	PriceStrategy = "price"
+
+	// BundleStrategy includes the transaction bundles submitted for the block
+	// ahead of the pending transactions, which are then ordered by price.
+	BundleStrategy = "bundle"
+)
+
+// Strategy selects the transactions to include into a block under construction
+// and the order they are applied in.
+type Strategy interface {
+	// Fill commits transactions into the block through the given builder. An
+	// error is only returned if the block building was interrupted.
+	Fill(b *Builder) error
+}
+
+var (
+	strategiesLock sync.RWMutex
+	strategies     = map[string]func() Strategy{
+		PriceStrategy:  func() Strategy { return priceStrategy{} },
+		BundleStrategy: func() Strategy { return bundleStrategy{} },
+	}
+)
+
+// RegisterStrategy makes a transaction ordering strategy available to the miner
+// configuration under the given name. It panics if the name is already taken.
+func RegisterStrategy(name string, constructor func() Strategy) {
+	strategiesLock.Lock()
+	defer strategiesLock.Unlock()
+
+	if _, ok := strategies[name]; ok {
+		panic(fmt.Sprintf("miner strategy %q already registered", name))
+	}
+	strategies[name] = constructor
+}
+
+// HasStrategy reports whether an ordering strategy is registered under the
+// given name.
+func HasStrategy(name string) bool {
+	strategiesLock.RLock()
+	defer strategiesLock.RUnlock()
+
+	_, ok := strategies[name]
+	return ok
+}
+
+// newStrategy creates the ordering strategy registered under the given name,
+// falling back to the price strategy if there is none.
+func newStrategy(name string) Strategy {
+	if name == "" {
+		name = PriceStrategy
+	}
+	strategiesLock.RLock()
+	constructor, ok := strategies[name]
+	strategiesLock.RUnlock()
+
+	if !ok {
+		log.Warn("Unknown miner strategy, using default", "strategy", name, "default", PriceStrategy)
+		return priceStrategy{}
+	}
+	return constructor()
+}
+
+// Builder is the view of a block under construction handed to the ordering
+// strategies. It is only valid for the duration of the Fill call.
+type Builder struct {
+	miner     *Miner
+	env       *environment
+	interrupt *atomic.Int32
+	tip       *big.Int // Minimum miner tip of the included transactions
+}
+
+// Header returns the header of the block under construction. It must not be
+// modified.
+func (b *Builder) Header() *types.Header {
+	return b.env.header
+}
+
+// Signer returns the signer valid for the block under construction.
+func (b *Builder) Signer() types.Signer {
+	return b.env.signer
+}
+
+// GasLeft returns the amount of gas still available in the block.
+func (b *Builder) GasLeft() uint64 {
+	return b.env.gasPool.Gas()
+}
+
+// Pending retrieves the pending transactions of the pool eligible for inclusion
+// into the block, split into the local and the remote ones. If the miner is
+// configured to skip reverting transactions, the ones predicted to revert by
+// the pool simulation are left out together with the subsequent ones of the
+// same account.
+func (b *Builder) Pending() (locals, remotes map[common.Address][]*txpool.LazyTransaction) {
+	// Retrieve the pending transactions pre-filtered by the 1559 dynamic fees
+	filter := txpool.PendingFilter{
+		MinTip: b.tip,
+	}
+	if b.env.header.BaseFee != nil {
+		filter.BaseFee = b.env.header.BaseFee
+	}
+	if b.env.header.ExcessBlobGas != nil {
+		filter.BlobFee = eip1559.CalcBlobFee(*b.env.header.ExcessBlobGas)
+	}
+	locals, remotes = make(map[common.Address][]*txpool.LazyTransaction), b.miner.txpool.Pending(filter)
+	if b.miner.config.SkipReverting {
+		for account, txs := range remotes {
+			for i, tx := range txs {
+				if prediction := b.miner.txpool.Prediction(tx.Hash); prediction != nil && prediction.Reverted {
+					txs = txs[:i]
+					break
+				}
+			}
+			if len(txs) == 0 {
+				delete(remotes, account)
+			} else {
+				remotes[account] = txs
+			}
+		}
+	}
+	for _, account := range b.miner.txpool.Locals() {
+		if txs := remotes[account]; len(txs) > 0 {
+			delete(remotes, account)
+			locals[account] = txs
+		}
+	}
+	return locals, remotes
+}
+
+// Bundles returns the transaction bundles submitted for the block under
+// construction, in submission order.
+func (b *Builder) Bundles() []*Bundle {
+	return b.miner.bundles.pending(b.env.header)
+}
+
+// CommitByPriceAndNonce commits the given transactions ordered by effective
+// miner tip, honouring the nonce order of each account. Transactions which fail
+// to apply are skipped together with the subsequent ones of the same account.
+//
+// Note, the input map is reowned so the caller should not interact any more
+// with it after providing it.
+func (b *Builder) CommitByPriceAndNonce(txs map[common.Address][]*txpool.LazyTransaction) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	return b.miner.commitTransactions(b.env, newTransactionsByPriceAndNonce(b.env.signer, txs, b.env.header.BaseFee), b.interrupt)
+}
+
+// CommitLanes commits the pending transactions of the priority lanes ahead of
+// any other, each lane within the gas reserved for it. Only the leading
+// transactions of an account belonging to a lane are considered, as the later
+// ones can't be executed before the others. The gas left unused by a lane is
+// released to the rest of the block, and the lane transactions which don't fit
+// in the reserved gas compete with the remaining ones.
+func (b *Builder) CommitLanes(pending ...map[common.Address][]*txpool.LazyTransaction) error {
+	for _, lane := range b.miner.config.Lanes {
+		if lane.Gas == 0 {
+			continue
+		}
+		txs := make(map[common.Address][]*txpool.LazyTransaction)
+		for _, set := range pending {
+			for addr, list := range set {
+				var n int
+				for ; n < len(list); n++ {
+					if tx := list[n].Resolve(); tx == nil || !lane.Match(addr, tx) {
+						break
+					}
+				}
+				if n > 0 {
+					txs[addr] = list[:n]
+				}
+			}
+		}
+		if len(txs) == 0 {
+			continue
+		}
+		if err := b.commitWithin(min(lane.Gas, b.GasLeft()), txs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitWithin commits the given transactions ordered by price and nonce, using
+// at most the given amount of gas.
+func (b *Builder) commitWithin(gas uint64, txs map[common.Address][]*txpool.LazyTransaction) error {
+	gasPool := b.env.gasPool
+	b.env.gasPool = new(core.GasPool).AddGas(gas)
+	defer func() {
+		gasPool.SubGas(gas - b.env.gasPool.Gas())
+		b.env.gasPool = gasPool
+	}()
+	return b.CommitByPriceAndNonce(txs)
+}
+
+// CommitBundle commits the given transactions atomically in order. If any of
+// them fails to apply or reverts, the block is left as it was before the call
+// and the error is returned.
+func (b *Builder) CommitBundle(txs types.Transactions) error {
+	return b.miner.commitBundle(b.env, txs, b.interrupt)
+}
+
+// priceStrategy is the default ordering strategy, filling the block with the
+// pending transactions of the priority lanes first, within the gas reserved for
+// them, then the local accounts and the remote ones after, all ordered by price
+// and nonce.
+type priceStrategy struct{}
+
+func (priceStrategy) Fill(b *Builder) error {
+	locals, remotes := b.Pending()
+	if err := b.CommitLanes(locals, remotes); err != nil {
+		return err
+	}
+	if err := b.CommitByPriceAndNonce(locals); err != nil {
+		return err
+	}
+	return b.CommitByPriceAndNonce(remotes)
+}
+
+// bundleStrategy includes the bundles submitted for the block first, skipping
+// any which fails, and fills the remaining space with the price strategy.
+type bundleStrategy struct{}
+
+func (bundleStrategy) Fill(b *Builder) error {
+	for _, bundle := range b.Bundles() {
+		if err := b.CommitBundle(bundle.Txs); err != nil {
+			if isInterrupt(err) {
+				return err
+			}
+			log.Debug("Bundle skipped", "hash", bundle.Hash(), "err", err)
+		}
+	}
+	return priceStrategy{}.Fill(b)
+}
@@ -0,0 +1,312 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/params"
+)
+
+func TestBundleStrategy(t *testing.T) {
+	var (
+		engine  = beacon.NewFaker()
+		backend = newTestWorkerBackend(t, params.TestChainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+		config  = testConfig
+		signer  = types.LatestSigner(params.TestChainConfig)
+	)
+	backend.txPool.Add(pendingTxs, true, true)
+	config.Strategy = BundleStrategy
+	miner := New(backend, config, engine)
+
+	mkTx := func(nonce uint64, to *common.Address, data []byte) *types.Transaction {
+		return types.MustSignNewTx(testBankKey, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			To:        to,
+			Value:     big.NewInt(1),
+			Gas:       100000,
+			GasFeeCap: big.NewInt(params.InitialBaseFee),
+			Data:      data,
+		})
+	}
+	// The second transaction deploys a contract whose init code reverts, so
+	// the whole bundle must be discarded.
+	reverting := &Bundle{
+		Txs:         types.Transactions{mkTx(0, &testUserAddress, nil), mkTx(1, nil, common.FromHex("0x60006000fd"))},
+		BlockNumber: 1,
+	}
+	valid := &Bundle{
+		Txs:         types.Transactions{mkTx(0, &testUserAddress, []byte{1}), mkTx(1, &testUserAddress, []byte{2})},
+		BlockNumber: 1,
+	}
+	future := &Bundle{
+		Txs:         types.Transactions{mkTx(0, &testUserAddress, []byte{3})},
+		BlockNumber: 2,
+	}
+	for _, bundle := range []*Bundle{reverting, valid, future} {
+		if err := miner.SendBundle(bundle); err != nil {
+			t.Fatalf("failed to send bundle: %v", err)
+		}
+	}
+	stale := &Bundle{Txs: types.Transactions{mkTx(0, &testUserAddress, nil)}, BlockNumber: 0}
+	if err := miner.SendBundle(stale); !errors.Is(err, errBundleStale) {
+		t.Fatalf("stale bundle error mismatch: have %v, want %v", err, errBundleStale)
+	}
+	result := miner.generateWork(&generateParams{
+		timestamp:   uint64(time.Now().Unix()),
+		parentHash:  backend.chain.CurrentBlock().Hash(),
+		coinbase:    testBankAddress,
+		withdrawals: types.Withdrawals{},
+	})
+	if result.err != nil {
+		t.Fatalf("failed to generate work: %v", result.err)
+	}
+	// The valid bundle is included, the pending transaction of the bank is
+	// skipped as its nonce is already used by the bundle.
+	txs := result.block.Transactions()
+	if len(txs) != len(valid.Txs) {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(txs), len(valid.Txs))
+	}
+	for i, tx := range txs {
+		if tx.Hash() != valid.Txs[i].Hash() {
+			t.Errorf("transaction %d mismatch: have %x, want %x", i, tx.Hash(), valid.Txs[i].Hash())
+		}
+	}
+	if have, want := result.stateDB.GetNonce(testBankAddress), uint64(2); have != want {
+		t.Errorf("nonce mismatch: have %d, want %d", have, want)
+	}
+}
+
+func TestBundleBlobTx(t *testing.T) {
+	var (
+		engine  = beacon.NewFaker()
+		backend = newTestWorkerBackend(t, params.TestChainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+		signer  = types.LatestSigner(params.TestChainConfig)
+		miner   = New(backend, testConfig, engine)
+	)
+	env, err := miner.prepareWork(&generateParams{
+		timestamp:   uint64(time.Now().Unix()),
+		parentHash:  backend.chain.CurrentBlock().Hash(),
+		coinbase:    testBankAddress,
+		withdrawals: types.Withdrawals{},
+	})
+	if err != nil {
+		t.Fatalf("failed to prepare work: %v", err)
+	}
+	env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+
+	mkTx := func(nonce uint64) *types.Transaction {
+		return types.MustSignNewTx(testBankKey, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			To:        &testUserAddress,
+			Value:     big.NewInt(1),
+			Gas:       params.TxGas,
+			GasFeeCap: big.NewInt(params.InitialBaseFee),
+		})
+	}
+	blobTx := types.MustSignNewTx(testBankKey, signer, &types.BlobTx{
+		ChainID:    params.TestChainConfig.ChainID,
+		Nonce:      1,
+		To:         testUserAddress,
+		Gas:        params.TxGas,
+		GasFeeCap:  big.NewInt(params.InitialBaseFee),
+		BlobFeeCap: big.NewInt(1),
+		BlobHashes: []common.Hash{{0x01}},
+	})
+	// The blob transaction sits in the middle of the bundle, the transaction
+	// before it must not be left in the block.
+	err = miner.commitBundle(env, types.Transactions{mkTx(0), blobTx, mkTx(2)}, nil)
+	if !errors.Is(err, errBundleBlobTx) {
+		t.Fatalf("bundle error mismatch: have %v, want %v", err, errBundleBlobTx)
+	}
+	if len(env.txs) != 0 || len(env.receipts) != 0 || env.tcount != 0 {
+		t.Errorf("bundle partially applied: %d transactions, %d receipts", len(env.txs), len(env.receipts))
+	}
+	if have := env.state.GetNonce(testBankAddress); have != 0 {
+		t.Errorf("nonce mismatch: have %d, want 0", have)
+	}
+	if have := env.gasPool.Gas(); have != env.header.GasLimit {
+		t.Errorf("gas pool mismatch: have %d, want %d", have, env.header.GasLimit)
+	}
+}
+
+func TestCommitLanes(t *testing.T) {
+	var (
+		engine   = beacon.NewFaker()
+		backend  = newTestWorkerBackend(t, params.TestChainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+		config   = testConfig
+		signer   = types.LatestSigner(params.TestChainConfig)
+		selector = []byte{0xde, 0xad, 0xbe, 0xef}
+		contract = common.BytesToAddress([]byte{0xc0, 0xde})
+		callGas  = params.TxGas + 4*params.TxDataNonZeroGasEIP2028
+	)
+	config.Lanes = []txpool.Lane{
+		{Name: "unreserved", Targets: []txpool.LaneTarget{{To: testUserAddress}}},
+		{Name: "calls", Targets: []txpool.LaneTarget{{To: contract, Selector: selector}}, Gas: callGas + params.TxGas/2},
+	}
+	miner := New(backend, config, engine)
+
+	mkTx := func(nonce uint64, to common.Address, data []byte) *types.Transaction {
+		return types.MustSignNewTx(testBankKey, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			To:        &to,
+			Gas:       callGas,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: big.NewInt(params.InitialBaseFee + params.GWei),
+			Data:      data,
+		})
+	}
+	// The first two transactions belong to the calls lane, but only the first
+	// one fits in the gas reserved for it
+	txs := types.Transactions{
+		mkTx(0, contract, selector),
+		mkTx(1, contract, selector),
+		mkTx(2, testUserAddress, nil),
+	}
+	for _, err := range backend.txPool.Add(txs, false, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	env, err := miner.prepareWork(&generateParams{
+		timestamp:   uint64(time.Now().Unix()),
+		parentHash:  backend.chain.CurrentBlock().Hash(),
+		coinbase:    testBankAddress,
+		withdrawals: types.Withdrawals{},
+	})
+	if err != nil {
+		t.Fatalf("failed to prepare work: %v", err)
+	}
+	env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+
+	builder := &Builder{miner: miner, env: env, tip: config.GasPrice}
+	locals, remotes := builder.Pending()
+	if err := builder.CommitLanes(locals, remotes); err != nil {
+		t.Fatalf("failed to commit lanes: %v", err)
+	}
+	if len(env.txs) != 1 || env.txs[0].Hash() != txs[0].Hash() {
+		t.Fatalf("lane transactions mismatch: have %d, want only the first", len(env.txs))
+	}
+	// The gas left unused by the lane is released to the rest of the block
+	if have, want := env.gasPool.Gas(), env.header.GasLimit-env.header.GasUsed; have != want {
+		t.Errorf("gas pool mismatch: have %d, want %d", have, want)
+	}
+	// The remaining transactions compete with the others
+	if err := (priceStrategy{}).Fill(builder); err != nil {
+		t.Fatalf("failed to fill block: %v", err)
+	}
+	if len(env.txs) != len(txs) {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(env.txs), len(txs))
+	}
+	for i, tx := range env.txs {
+		if tx.Hash() != txs[i].Hash() {
+			t.Errorf("transaction %d mismatch: have %x, want %x", i, tx.Hash(), txs[i].Hash())
+		}
+	}
+}
+
+func TestUnknownStrategy(t *testing.T) {
+	if _, ok := newStrategy("unknown").(priceStrategy); !ok {
+		t.Error("unknown strategy did not fall back to the price strategy")
+	}
+	if HasStrategy("unknown") {
+		t.Error("unknown strategy reported as registered")
+	}
+	if !HasStrategy(BundleStrategy) {
+		t.Error("bundle strategy not registered")
+	}
+}
+
+func TestSkipReverting(t *testing.T) {
+	var (
+		engine  = beacon.NewFaker()
+		backend = newTestWorkerBackend(t, params.TestChainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+		config  = testConfig
+		signer  = types.LatestSigner(params.TestChainConfig)
+	)
+	backend.txPool.EnableSimulation(backend.chain)
+	config.SkipReverting = true
+	miner := New(backend, config, engine)
+
+	mkTx := func(nonce uint64, to *common.Address, data []byte) *types.Transaction {
+		return types.MustSignNewTx(testBankKey, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			To:        to,
+			Value:     big.NewInt(1),
+			Gas:       100000,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: big.NewInt(params.InitialBaseFee + params.GWei),
+			Data:      data,
+		})
+	}
+	// The second transaction deploys a contract whose init code reverts, the
+	// third one can't be included before it.
+	txs := types.Transactions{
+		mkTx(0, &testUserAddress, nil),
+		mkTx(1, nil, common.FromHex("0x60006000fd")),
+		mkTx(2, &testUserAddress, nil),
+	}
+	for _, err := range backend.txPool.Add(txs, false, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	for i := 0; ; i++ {
+		if backend.txPool.Prediction(txs[2].Hash()) != nil {
+			break
+		}
+		if i == 100 {
+			t.Fatal("transactions not simulated")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i, reverted := range []bool{false, true, false} {
+		prediction := backend.txPool.Prediction(txs[i].Hash())
+		if prediction.Reverted != reverted {
+			t.Errorf("transaction %d revert prediction mismatch: have %v, want %v", i, prediction.Reverted, reverted)
+		}
+		if prediction.GasUsed == 0 {
+			t.Errorf("transaction %d predicted to use no gas", i)
+		}
+	}
+	result := miner.generateWork(&generateParams{
+		timestamp:   uint64(time.Now().Unix()),
+		parentHash:  backend.chain.CurrentBlock().Hash(),
+		coinbase:    testBankAddress,
+		withdrawals: types.Withdrawals{},
+	})
+	if result.err != nil {
+		t.Fatalf("failed to generate work: %v", result.err)
+	}
+	included := result.block.Transactions()
+	if len(included) != 1 || included[0].Hash() != txs[0].Hash() {
+		t.Fatalf("included transactions mismatch: have %d, want only the first", len(included))
+	}
+}
@@ -27,7 +27,6 @@ import (
 	"github.com/theQRL/go-zond/consensus/misc/eip1559"
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/state"
-	"github.com/theQRL/go-zond/core/txpool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/log"
@@ -52,6 +51,8 @@ type environment struct {
 	header   *types.Header
 	txs      []*types.Transaction
 	receipts []*types.Receipt
+	sidecars []*types.BlobTxSidecar
+	blobs    int
 }
 
 const (
@@ -65,9 +66,10 @@ const (
 type newPayloadResult struct {
 	err      error
 	block    *types.Block
-	fees     *big.Int         // total block fees
-	stateDB  *state.StateDB   // StateDB after executing the transactions
-	receipts []*types.Receipt // Receipts collected during construction
+	fees     *big.Int               // total block fees
+	stateDB  *state.StateDB         // StateDB after executing the transactions
+	receipts []*types.Receipt       // Receipts collected during construction
+	sidecars []*types.BlobTxSidecar // Blob sidecars of the included blob transactions
 }
 
 // generateParams wraps various settings for generating sealing task.
@@ -78,6 +80,7 @@ type generateParams struct {
 	coinbase    common.Address    // The fee recipient address for including transaction
 	random      common.Hash       // The randomness generated by beacon chain, empty before the merge
 	withdrawals types.Withdrawals // List of withdrawals to include in block.
+	beaconRoot  *common.Hash      // The beacon root (cancun field).
 	noTxs       bool              // Flag whether an empty block without any transaction is expected
 }
 
@@ -109,6 +112,7 @@ func (miner *Miner) generateWork(params *generateParams) *newPayloadResult {
 		fees:     totalFees(block, work.receipts),
 		stateDB:  work.state,
 		receipts: work.receipts,
+		sidecars: work.sidecars,
 	}
 }
 
@@ -156,12 +160,33 @@ func (miner *Miner) prepareWork(genParams *generateParams) (*environment, error)
 	// Set baseFee and GasLimit if we are on an EIP-1559 chain
 	header.BaseFee = eip1559.CalcBaseFee(miner.chainConfig, parent)
 
+	// Apply the blob fee market if we are on a Cancun chain
+	if miner.chainConfig.IsCancun(header.Number, header.Time) {
+		var excessBlobGas uint64
+		if parent.ExcessBlobGas != nil {
+			excessBlobGas = eip1559.CalcExcessBlobGas(*parent.ExcessBlobGas, *parent.BlobGasUsed)
+		}
+		header.BlobGasUsed = new(uint64)
+		header.ExcessBlobGas = &excessBlobGas
+		header.ParentBeaconRoot = genParams.beaconRoot
+	}
 	// Could potentially happen if starting to mine in an odd state..
 	env, err := miner.makeEnv(parent, header, genParams.coinbase)
 	if err != nil {
 		log.Error("Failed to create sealing context", "err", err)
 		return nil, err
 	}
+	var (
+		context = core.NewZVMBlockContext(header, miner.chain, nil)
+		vmenv   = vm.NewZVM(context, vm.TxContext{}, env.state, miner.chainConfig, vm.Config{})
+	)
+	core.InstallSystemContracts(miner.chainConfig, parent, header, env.state)
+	if header.ParentBeaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, vmenv, env.state)
+	}
+	if miner.chainConfig.IsPrague(header.Number, header.Time) {
+		core.ProcessParentBlockHash(header.ParentHash, vmenv, env.state)
+	}
 	return env, nil
 }
 
@@ -174,7 +199,7 @@ func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase
 	}
 	// Note the passed coinbase may be different with header.Coinbase.
 	return &environment{
-		signer:   types.MakeSigner(miner.chainConfig),
+		signer:   types.MakeSigner(miner.chainConfig, header.Number, header.Time),
 		state:    state,
 		coinbase: coinbase,
 		header:   header,
@@ -182,6 +207,9 @@ func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase
 }
 
 func (miner *Miner) commitTransaction(env *environment, tx *types.Transaction) error {
+	if tx.Type() == types.BlobTxType {
+		return miner.commitBlobTransaction(env, tx)
+	}
 	receipt, err := miner.applyTransaction(env, tx)
 	if err != nil {
 		return err
@@ -192,6 +220,31 @@ func (miner *Miner) commitTransaction(env *environment, tx *types.Transaction) e
 	return nil
 }
 
+func (miner *Miner) commitBlobTransaction(env *environment, tx *types.Transaction) error {
+	sc := tx.BlobTxSidecar()
+	if sc == nil {
+		panic("blob transaction without blobs in miner")
+	}
+	// Checking against blob gas limit: It's kind of ugly to perform this check here, but there
+	// isn't really a better place right now. The blob gas limit is checked at block validation time
+	// and not during execution. This means core.ApplyTransaction will not return an error if the
+	// tx has too many blobs. So we have to explicitly check it here.
+	if (env.blobs+len(sc.Blobs))*params.BlobTxBlobGasPerBlob > params.MaxBlobGasPerBlock {
+		return errors.New("max data blobs reached")
+	}
+	receipt, err := miner.applyTransaction(env, tx.WithoutBlobTxSidecar())
+	if err != nil {
+		return err
+	}
+	env.txs = append(env.txs, tx.WithoutBlobTxSidecar())
+	env.receipts = append(env.receipts, receipt)
+	env.sidecars = append(env.sidecars, sc)
+	env.blobs += len(sc.Blobs)
+	*env.header.BlobGasUsed += receipt.BlobGasUsed
+	env.tcount++
+	return nil
+}
+
 // applyTransaction runs the transaction. If execution fails, state and gas pool are reverted.
 func (miner *Miner) applyTransaction(env *environment, tx *types.Transaction) (*types.Receipt, error) {
 	var (
@@ -235,6 +288,12 @@ func (miner *Miner) commitTransactions(env *environment, txs *transactionsByPric
 			txs.Pop()
 			continue
 		}
+		// If we don't have enough blob space for the next transaction, skip the account.
+		if left := uint64(params.MaxBlobGasPerBlock - env.blobs*params.BlobTxBlobGasPerBlob); left < ltx.BlobGas {
+			log.Trace("Not enough blob gas left for transaction", "hash", ltx.Hash, "left", left, "needed", ltx.BlobGas)
+			txs.Pop()
+			continue
+		}
 
 		// Transaction seems to fit, pull it up from the pool
 		tx := ltx.Resolve()
@@ -273,47 +332,67 @@ func (miner *Miner) commitTransactions(env *environment, txs *transactionsByPric
 	return nil
 }
 
-// fillTransactions retrieves the pending transactions from the txpool and fills them
-// into the given sealing block. The transaction selection and ordering strategy can
-// be customized with the plugin in the future.
-func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment) error {
-	miner.confMu.RLock()
-	tip := miner.config.GasPrice
-	miner.confMu.RUnlock()
-
-	// Retrieve the pending transactions pre-filtered by the 1559 dynamic fees
-	filter := txpool.PendingFilter{
-		MinTip: tip,
-	}
-	if env.header.BaseFee != nil {
-		filter.BaseFee = env.header.BaseFee
-	}
-	pendingTxs := miner.txpool.Pending(filter)
-
-	// Split the pending transactions into locals and remotes.
-	localTxs, remoteTxs := make(map[common.Address][]*txpool.LazyTransaction), pendingTxs
-	for _, account := range miner.txpool.Locals() {
-		if txs := remoteTxs[account]; len(txs) > 0 {
-			delete(remoteTxs, account)
-			localTxs[account] = txs
+// commitBundle applies the transactions of a bundle in order. If any of them
+// fails or reverts, the block is restored to its state before the bundle.
+func (miner *Miner) commitBundle(env *environment, txs types.Transactions, interrupt *atomic.Int32) error {
+	if interrupt != nil {
+		if signal := interrupt.Load(); signal != commitInterruptNone {
+			return signalToErr(signal)
 		}
 	}
-	// Fill the block with all available pending transactions.
-	if len(localTxs) > 0 {
-		txs := newTransactionsByPriceAndNonce(env.signer, localTxs, env.header.BaseFee)
-		if err := miner.commitTransactions(env, txs, interrupt); err != nil {
-			return err
+	// Reject the bundles which can never be included before touching the block
+	for _, tx := range txs {
+		if tx.Type() == types.BlobTxType {
+			return fmt.Errorf("transaction %x: %w", tx.Hash(), errBundleBlobTx)
 		}
 	}
-	if len(remoteTxs) > 0 {
-		txs := newTransactionsByPriceAndNonce(env.signer, remoteTxs, env.header.BaseFee)
-		if err := miner.commitTransactions(env, txs, interrupt); err != nil {
-			return err
+	// The state journal is flushed after every transaction, so the state can't
+	// be reverted to a snapshot taken before the bundle. Keep a copy instead.
+	var (
+		state   = env.state.Copy()
+		gas     = env.gasPool.Gas()
+		gasUsed = env.header.GasUsed
+		tcount  = env.tcount
+		count   = len(env.txs)
+	)
+	for _, tx := range txs {
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+
+		err := miner.commitTransaction(env, tx)
+		if err == nil && env.receipts[len(env.receipts)-1].Status == types.ReceiptStatusFailed {
+			err = errBundleReverted
+		}
+		if err != nil {
+			env.state = state
+			env.gasPool.SetGas(gas)
+			env.header.GasUsed = gasUsed
+			env.tcount = tcount
+			env.txs, env.receipts = env.txs[:count], env.receipts[:count]
+			return fmt.Errorf("transaction %x: %w", tx.Hash(), err)
 		}
 	}
 	return nil
 }
 
+// fillTransactions fills the given sealing block with transactions, selected
+// and ordered by the configured ordering strategy.
+func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment) error {
+	miner.confMu.RLock()
+	tip := miner.config.GasPrice
+	strategy := miner.strategy
+	miner.confMu.RUnlock()
+
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+	return strategy.Fill(&Builder{
+		miner:     miner,
+		env:       env,
+		interrupt: interrupt,
+		tip:       tip,
+	})
+}
+
 // totalFees computes total consumed miner fees in Wei. Block transactions and receipts have to have the same order.
 func totalFees(block *types.Block, receipts []*types.Receipt) *big.Int {
 	feesWei := new(big.Int)
@@ -324,6 +403,14 @@ func totalFees(block *types.Block, receipts []*types.Receipt) *big.Int {
 	return feesWei
 }
 
+// isInterrupt reports whether the error is caused by an interruption of the
+// block building.
+func isInterrupt(err error) bool {
+	return errors.Is(err, errBlockInterruptedByNewHead) ||
+		errors.Is(err, errBlockInterruptedByRecommit) ||
+		errors.Is(err, errBlockInterruptedByTimeout)
+}
+
 // signalToErr converts the interruption signal to a concrete error type for return.
 // The given signal must be a valid interruption signal.
 func signalToErr(signal int32) error {
@@ -198,6 +198,10 @@ type Config struct {
 	JWTSecret string `toml:",omitempty"`
 
 	DBEngine string `toml:",omitempty"`
+
+	// AncientEngine is the backing implementation of the chain ancient store,
+	// flat files ("file") or a dedicated Pebble instance ("pebble").
+	AncientEngine string `toml:",omitempty"`
 }
 
 // IPCEndpoint resolves an IPC endpoint based on a configured value, taking into
@@ -31,8 +31,7 @@ var (
 	TestnetGenesisHash = common.HexToHash("0xc8384368a4547d62a54385aadb484564d4d841c7fd93496656ff62b9ace02f90")
 )
 
-// NOTE(rgeraldes24): unused atm
-// func newUint64(val uint64) *uint64 { return &val }
+func newUint64(val uint64) *uint64 { return &val }
 
 var (
 	// MainnetChainConfig is the chain parameters to run a node on the main network.
@@ -51,18 +50,24 @@ var (
 	// AllBeaconProtocolChanges contains every protocol change (EIPs) introduced
 	// and accepted by the Zond core developers into the Beacon consensus.
 	AllBeaconProtocolChanges = &ChainConfig{
-		ChainID: big.NewInt(1337),
+		ChainID:    big.NewInt(1337),
+		CancunTime: newUint64(0),
+		PragueTime: newUint64(0),
 	}
 
 	AllDevChainProtocolChanges = &ChainConfig{
-		ChainID:   big.NewInt(1337),
-		IsDevMode: true,
+		ChainID:    big.NewInt(1337),
+		CancunTime: newUint64(0),
+		PragueTime: newUint64(0),
+		IsDevMode:  true,
 	}
 
 	// TestChainConfig contains every protocol change (EIPs) introduced
 	// and accepted by the Zond core developers for testing proposes.
 	TestChainConfig = &ChainConfig{
-		ChainID: big.NewInt(1),
+		ChainID:    big.NewInt(1),
+		CancunTime: newUint64(0),
+		PragueTime: newUint64(0),
 	}
 
 	// NonActivatedConfig defines the chain configuration without activating
@@ -86,6 +91,11 @@ var NetworkNames = map[string]string{
 type ChainConfig struct {
 	ChainID *big.Int `json:"chainId"` // chainId identifies the current chain and is used for replay protection
 
+	// Fork scheduling is done using timestamps. A nil value means the fork
+	// is not scheduled, zero means it is active from genesis.
+	CancunTime *uint64 `json:"cancunTime,omitempty"` // Cancun switch time (nil = no fork, 0 = already on cancun)
+	PragueTime *uint64 `json:"pragueTime,omitempty"` // Prague switch time (nil = no fork, 0 = already on prague)
+
 	IsDevMode bool `json:"isDev,omitempty"`
 }
 
@@ -102,27 +112,47 @@ func (c *ChainConfig) Description() string {
 	banner += "Consensus: Beacon (proof-of-stake)\n"
 	banner += "\n"
 
+	// Add a special section for the timestamp based forks
+	banner += "Hard forks (timestamp based):\n"
+	if c.CancunTime != nil {
+		banner += fmt.Sprintf(" - Cancun:                      @%-10v\n", *c.CancunTime)
+	}
+	if c.PragueTime != nil {
+		banner += fmt.Sprintf(" - Prague:                      @%-10v\n", *c.PragueTime)
+	}
+	banner += "\n"
+
 	return banner
 }
 
+// IsCancun returns whether time is either equal to the Cancun fork time or greater.
+func (c *ChainConfig) IsCancun(num *big.Int, time uint64) bool {
+	return isTimestampForked(c.CancunTime, time)
+}
+
+// IsPrague returns whether time is either equal to the Prague fork time or greater.
+func (c *ChainConfig) IsPrague(num *big.Int, time uint64) bool {
+	return isTimestampForked(c.PragueTime, time)
+}
+
 // CheckCompatible checks whether scheduled fork transitions have been imported
 // with a mismatching chain configuration.
 func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64, time uint64) *ConfigCompatError {
 	var (
 		bhead = new(big.Int).SetUint64(height)
-		// btime = time
+		btime = time
 	)
 	// Iterate checkCompatible to find the lowest conflict.
 	var lasterr *ConfigCompatError
 	for {
-		err := c.checkCompatible(newcfg /*, bhead, btime*/)
+		err := c.checkCompatible(newcfg, bhead, btime)
 		if err == nil || (lasterr != nil && err.RewindToBlock == lasterr.RewindToBlock && err.RewindToTime == lasterr.RewindToTime) {
 			break
 		}
 		lasterr = err
 
 		if err.RewindToTime > 0 {
-			// btime = err.RewindToTime
+			btime = err.RewindToTime
 		} else {
 			bhead.SetUint64(err.RewindToBlock)
 		}
@@ -140,7 +170,10 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 		optional  bool     // if true, the fork may be nil and next fork is still allowed
 	}
 	var lastFork fork
-	for _, cur := range []fork{} {
+	for _, cur := range []fork{
+		{name: "cancunTime", timestamp: c.CancunTime},
+		{name: "pragueTime", timestamp: c.PragueTime},
+	} {
 		if lastFork.name != "" {
 			switch {
 			// Non-optional forks must all be present in the chain config up to the last defined fork
@@ -178,11 +211,16 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 	return nil
 }
 
-func (c *ChainConfig) checkCompatible(newcfg *ChainConfig /*, headNumber *big.Int, headTimestamp uint64*/) *ConfigCompatError {
+func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int, headTimestamp uint64) *ConfigCompatError {
 	if !configBlockEqual(c.ChainID, newcfg.ChainID) {
 		return newBlockCompatError("chain ID", c.ChainID, newcfg.ChainID)
 	}
-
+	if isForkTimestampIncompatible(c.CancunTime, newcfg.CancunTime, headTimestamp) {
+		return newTimestampCompatError("Cancun fork timestamp", c.CancunTime, newcfg.CancunTime)
+	}
+	if isForkTimestampIncompatible(c.PragueTime, newcfg.PragueTime, headTimestamp) {
+		return newTimestampCompatError("Prague fork timestamp", c.PragueTime, newcfg.PragueTime)
+	}
 	return nil
 }
 
@@ -323,7 +361,9 @@ func (err *ConfigCompatError) Error() string {
 // Rules is a one time interface meaning that it shouldn't be used in between transition
 // phases.
 type Rules struct {
-	ChainID *big.Int
+	ChainID  *big.Int
+	IsCancun bool
+	IsPrague bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -333,6 +373,8 @@ func (c *ChainConfig) Rules(num *big.Int, timestamp uint64) Rules {
 		chainID = new(big.Int)
 	}
 	return Rules{
-		ChainID: new(big.Int).Set(chainID),
+		ChainID:  new(big.Int).Set(chainID),
+		IsCancun: c.IsCancun(num, timestamp),
+		IsPrague: c.IsPrague(num, timestamp),
 	}
 }
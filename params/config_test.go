@@ -17,6 +17,8 @@
 package params
 
 import (
+	"math"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -50,76 +52,34 @@ func TestCheckCompatible(t *testing.T) {
 				NewBlock:    common.Big32,
 			},
 		},
-		// NOTE(rgeraldes24): not valid at the moment
-		/*
-			{
-				stored:    AllBeaconProtocolChanges,
-				new:       &ChainConfig{},
-				headBlock: 3,
-				wantErr: &ConfigCompatError{
-					What:          "Homestead fork block",
-					StoredBlock:   big.NewInt(0),
-					NewBlock:      nil,
-					RewindToBlock: 0,
-				},
-			},
-			{
-				stored:    AllBeaconProtocolChanges,
-				new:       &ChainConfig{},
-				headBlock: 3,
-				wantErr: &ConfigCompatError{
-					What:          "Homestead fork block",
-					StoredBlock:   big.NewInt(0),
-					NewBlock:      big.NewInt(1),
-					RewindToBlock: 0,
-				},
-			},
-			{
-				stored:    &ChainConfig{},
-				new:       &ChainConfig{},
-				headBlock: 25,
-				wantErr: &ConfigCompatError{
-					What:          "EIP150 fork block",
-					StoredBlock:   big.NewInt(10),
-					NewBlock:      big.NewInt(20),
-					RewindToBlock: 9,
-				},
-			},
-			{
-				stored:    &ChainConfig{},
-				new:       &ChainConfig{},
-				headBlock: 40,
-				wantErr:   nil,
-			},
-			{
-				stored:    &ChainConfig{},
-				new:       &ChainConfig{},
-				headBlock: 40,
-				wantErr: &ConfigCompatError{
-					What:          "Petersburg fork block",
-					StoredBlock:   nil,
-					NewBlock:      big.NewInt(31),
-					RewindToBlock: 30,
-				},
-			},
-			{
-				stored:        &ChainConfig{},
-				new:           &ChainConfig{},
-				headTimestamp: 9,
-				wantErr:       nil,
+		{
+			stored:        &ChainConfig{CancunTime: newUint64(10)},
+			new:           &ChainConfig{CancunTime: newUint64(20)},
+			headTimestamp: 9,
+			wantErr:       nil,
+		},
+		{
+			stored:        &ChainConfig{CancunTime: newUint64(10)},
+			new:           &ChainConfig{CancunTime: newUint64(20)},
+			headTimestamp: 25,
+			wantErr: &ConfigCompatError{
+				What:         "Cancun fork timestamp",
+				StoredTime:   newUint64(10),
+				NewTime:      newUint64(20),
+				RewindToTime: 9,
 			},
-			{
-				stored:        &ChainConfig{},
-				new:           &ChainConfig{},
-				headTimestamp: 25,
-				wantErr: &ConfigCompatError{
-					What:         "Shanghai fork timestamp",
-					StoredTime:   newUint64(10),
-					NewTime:      newUint64(20),
-					RewindToTime: 9,
-				},
+		},
+		{
+			stored:        &ChainConfig{CancunTime: newUint64(10)},
+			new:           &ChainConfig{},
+			headTimestamp: 25,
+			wantErr: &ConfigCompatError{
+				What:         "Cancun fork timestamp",
+				StoredTime:   newUint64(10),
+				NewTime:      nil,
+				RewindToTime: 9,
 			},
-		*/
+		},
 	}
 
 	for _, test := range tests {
@@ -130,23 +90,29 @@ func TestCheckCompatible(t *testing.T) {
 	}
 }
 
-// NOTE(rgeraldes24): not valid at the moment
-/*
 func TestConfigRules(t *testing.T) {
 	c := &ChainConfig{
-		ShanghaiTime: newUint64(500),
+		CancunTime: newUint64(500),
 	}
 	var stamp uint64
-	if r := c.Rules(big.NewInt(0), true, stamp); r.IsShanghai {
-		t.Errorf("expected %v to not be shanghai", stamp)
+	if r := c.Rules(big.NewInt(0), stamp); r.IsCancun {
+		t.Errorf("expected %v to not be cancun", stamp)
 	}
 	stamp = 500
-	if r := c.Rules(big.NewInt(0), true, stamp); !r.IsShanghai {
-		t.Errorf("expected %v to be shanghai", stamp)
+	if r := c.Rules(big.NewInt(0), stamp); !r.IsCancun {
+		t.Errorf("expected %v to be cancun", stamp)
 	}
 	stamp = math.MaxInt64
-	if r := c.Rules(big.NewInt(0), true, stamp); !r.IsShanghai {
-		t.Errorf("expected %v to be shanghai", stamp)
+	if r := c.Rules(big.NewInt(0), stamp); !r.IsCancun {
+		t.Errorf("expected %v to be cancun", stamp)
+	}
+}
+
+func TestCheckConfigForkOrder(t *testing.T) {
+	if err := AllBeaconProtocolChanges.CheckConfigForkOrder(); err != nil {
+		t.Fatalf("unexpected fork ordering error: %v", err)
+	}
+	if err := (&ChainConfig{ChainID: common.Big1}).CheckConfigForkOrder(); err != nil {
+		t.Fatalf("unexpected fork ordering error: %v", err)
 	}
 }
-*/
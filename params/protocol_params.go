@@ -16,6 +16,8 @@
 
 package params
 
+import "github.com/theQRL/go-zond/common"
+
 const (
 	GasLimitBoundDivisor uint64 = 1024               // The bound divisor of the gas limit, used in update calculations.
 	MinGasLimit          uint64 = 5000               // Minimum the gas limit may ever be.
@@ -94,8 +96,56 @@ const (
 	Bn256PairingBaseGasIstanbul     uint64 = 45000 // Base price for an elliptic curve pairing check
 	Bn256PairingPerPointGasIstanbul uint64 = 34000 // Per-point price for an elliptic curve pairing check
 
+	DilithiumVerifyBaseGas    uint64 = 30000 // Base price for a Dilithium signature verification
+	DilithiumVerifyPerWordGas uint64 = 12    // Per-word price for hashing the message of a Dilithium signature verification
+
+	BlobTxBlobGasPerBlob             = 1 << 17 // Gas consumption of a single data blob (== blob byte size)
+	BlobTxMinBlobGasprice            = 1       // Minimum gas price for data blobs
+	BlobTxBlobGaspriceUpdateFraction = 3338477 // Controls the maximum rate of change for blob gas price
+	BlobTxHashGas                    = 3       // Gas needed for a BLOBHASH operation
+
+	BlobTxTargetBlobGasPerBlock = 3 * BlobTxBlobGasPerBlob // Target consumable blob gas for data blobs per block (for 1559-like pricing)
+	MaxBlobGasPerBlock          = 6 * BlobTxBlobGasPerBlob // Maximum consumable blob gas for data blobs per block
+
+	HistoryServeWindow = 8191 // Number of recent block hashes served by the history storage contract
+
 	// The Refund Quotient is the cap on how much of the used gas can be refunded. Before EIP-3529,
 	// up to half the consumed gas could be refunded. Redefined as 1/5th in EIP-3529
 	RefundQuotient        uint64 = 2
 	RefundQuotientEIP3529 uint64 = 5
 )
+
+var (
+	// PublicKeyRegistryAddress is the address of the system contract whose
+	// storage holds the Dilithium public keys registered by transactions sent
+	// to it since the Cancun fork.
+	PublicKeyRegistryAddress, _ = common.NewAddressFromString("Z0000000000000000000000000000000000000100")
+
+	// PublicKeyRegistryCode is the code of the public key registry system
+	// contract. The registration is performed by the protocol, the code only
+	// rejects calls transferring value to the registry.
+	PublicKeyRegistryCode = common.FromHex("34156008575f5ffd5b00")
+
+	// BeaconRootsStorageAddress is the address of the system contract keeping a
+	// ring buffer of the parent beacon block roots, keyed by block timestamp.
+	BeaconRootsStorageAddress, _ = common.NewAddressFromString("Z000F3df6D732807Ef1319fB7B8bB8522d0Beac02")
+
+	// SystemAddress is the sender of the system calls made by the protocol at
+	// the start of each block, such as the beacon roots update.
+	SystemAddress, _ = common.NewAddressFromString("Zfffffffffffffffffffffffffffffffffffffffe")
+
+	// BeaconRootsCode is the code of the beacon roots system contract. Calls from
+	// the system address store the passed root at the block timestamp, all other
+	// calls return the root stored for the timestamp passed as calldata.
+	BeaconRootsCode = common.FromHex("3373fffffffffffffffffffffffffffffffffffffffe14604d57602036146024575f5ffd5b5f35801560495762001fff810690815414603c575f5ffd5b62001fff01545f5260205ff35b5f5ffd5b62001fff42064281555f359062001fff015500")
+
+	// HistoryStorageAddress is the address of the system contract keeping a
+	// ring buffer of the recent block hashes, keyed by block number.
+	HistoryStorageAddress, _ = common.NewAddressFromString("Z0000F90827F1C53a10cb7A02335B175320002935")
+
+	// HistoryStorageCode is the code of the block hash history system contract.
+	// Calls from the system address store the passed parent hash at the parent
+	// block number, all other calls return the hash stored for the block number
+	// passed as calldata, if it is within the serving window.
+	HistoryStorageCode = common.FromHex("3373fffffffffffffffffffffffffffffffffffffffe14604657602036036042575f35600143038111604257611fff81430311604257611fff9006545f5260205ff35b5f5ffd5b5f35611fff60014303065500")
+)
@@ -24,11 +24,31 @@ import (
 	"github.com/theQRL/go-zond/params"
 )
 
+func u64(val uint64) *uint64 { return &val }
+
 // Forks table defines supported forks and their chain config.
 var Forks = map[string]*params.ChainConfig{
 	"Shanghai": {
 		ChainID: big.NewInt(1),
 	},
+	"ShanghaiToCancunAtTime15k": {
+		ChainID:    big.NewInt(1),
+		CancunTime: u64(15_000),
+	},
+	"Cancun": {
+		ChainID:    big.NewInt(1),
+		CancunTime: u64(0),
+	},
+	"CancunToPragueAtTime15k": {
+		ChainID:    big.NewInt(1),
+		CancunTime: u64(0),
+		PragueTime: u64(15_000),
+	},
+	"Prague": {
+		ChainID:    big.NewInt(1),
+		CancunTime: u64(0),
+		PragueTime: u64(0),
+	},
 }
 
 // AvailableForks returns the set of defined fork names
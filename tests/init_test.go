@@ -42,6 +42,7 @@ var (
 	rlpTestDir         = filepath.Join(baseDir, "RLPTests")
 	executionSpecDir   = filepath.Join(".", "spec-tests", "fixtures")
 	benchmarksDir      = filepath.Join(".", "evm-benchmarks", "benchmarks")
+	zondStateTestDir   = filepath.Join(".", "zond-tests", "StateTests")
 )
 
 func readJSON(reader io.Reader, value interface{}) error {
@@ -60,8 +60,10 @@ func TestState(t *testing.T) {
 	// EOF is not part of cancun
 	st.skipLoad(`^stEOF/`)
 
-	// For Istanbul, older tests were moved into LegacyTests
+	// For Istanbul, older tests were moved into LegacyTests. The tests kept in
+	// this repository go first, as walk skips on the first missing submodule.
 	for _, dir := range []string{
+		zondStateTestDir,
 		filepath.Join(baseDir, "EIPTests", "StateTests"),
 		stateTestDir,
 		legacyStateTestDir,
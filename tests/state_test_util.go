@@ -273,6 +273,13 @@ func (t *StateTest) RunNoVerify(subtest StateSubtest, vmconfig vm.Config, snapsh
 	}
 	zvm := vm.NewZVM(context, txContext, statedb, config, vmconfig)
 
+	// Store the parent hash in the history storage contract, mirroring the
+	// system call made at the start of each block.
+	if config.IsPrague(block.Number(), block.Time()) && block.NumberU64() > 0 {
+		core.ProcessParentBlockHash(vmTestBlockHash(block.NumberU64()-1), zvm, statedb)
+		zvm.Reset(txContext, statedb)
+	}
+
 	// Execute the message.
 	snapshot := statedb.Snapshot()
 	gaspool := new(core.GasPool)
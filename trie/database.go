@@ -124,6 +124,17 @@ func (db *Database) Reader(blockRoot common.Hash) (Reader, error) {
 	return nil, errors.New("unknown backend")
 }
 
+// HistoricReader returns a reader for accessing the historical state with the
+// provided state root, rebuilt from the indexed state histories. It's only
+// supported by path-based database and will return an error for others.
+func (db *Database) HistoricReader(root common.Hash) (*pathdb.HistoricalStateReader, error) {
+	pdb, ok := db.backend.(*pathdb.Database)
+	if !ok {
+		return nil, errors.New("not supported")
+	}
+	return pdb.HistoricReader(root, &trieLoader{db: db})
+}
+
 // Update performs a state transition by committing dirty nodes contained in the
 // given set in order to update state from the specified parent to the specified
 // root. The held pre-images accumulated up to this point will be flushed in case
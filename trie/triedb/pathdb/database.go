@@ -86,10 +86,11 @@ type layer interface {
 
 // Config contains the settings for database.
 type Config struct {
-	StateHistory   uint64 // Number of recent blocks to maintain state history for
-	CleanCacheSize int    // Maximum memory allowance (in bytes) for caching clean nodes
-	DirtyCacheSize int    // Maximum memory allowance (in bytes) for caching dirty nodes
-	ReadOnly       bool   // Flag whether the database is opened in read only mode.
+	StateHistory        uint64 // Number of recent blocks to maintain state history for
+	EnableStateIndexing bool   // Whether to index state histories for serving historical state
+	CleanCacheSize      int    // Maximum memory allowance (in bytes) for caching clean nodes
+	DirtyCacheSize      int    // Maximum memory allowance (in bytes) for caching dirty nodes
+	ReadOnly            bool   // Flag whether the database is opened in read only mode.
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -178,6 +179,11 @@ func New(diskdb zonddb.Database, config *Config) *Database {
 		if pruned != 0 {
 			log.Warn("Truncated extra state histories", "number", pruned)
 		}
+		// Index the state histories not yet indexed, or drop the whole index
+		// if the indexing is disabled since then.
+		if err := initHistoryIndex(db.diskdb, freezer, config.EnableStateIndexing); err != nil {
+			log.Crit("Failed to initialize state history index", "err", err)
+		}
 	}
 	log.Warn("Path-based state scheme is an experimental feature")
 	return db
@@ -279,6 +285,12 @@ func (db *Database) Reset(root common.Hash) error {
 		if err := db.freezer.Reset(); err != nil {
 			return err
 		}
+		if err := rawdb.DeleteStateHistoryIndex(db.diskdb); err != nil {
+			return err
+		}
+		if db.config.EnableStateIndexing {
+			rawdb.WriteStateHistoryIndexHead(db.diskdb, 0)
+		}
 	}
 	// Re-construct a new disk layer backed by persistent state
 	// with **empty clean cache and node buffer**.
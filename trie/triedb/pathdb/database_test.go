@@ -97,9 +97,13 @@ type tester struct {
 }
 
 func newTester(t *testing.T) *tester {
+	return newTesterWithConfig(t, &Config{CleanCacheSize: 256 * 1024, DirtyCacheSize: 256 * 1024})
+}
+
+func newTesterWithConfig(t *testing.T, config *Config) *tester {
 	var (
 		disk, _ = rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
-		db      = New(disk, &Config{CleanCacheSize: 256 * 1024, DirtyCacheSize: 256 * 1024})
+		db      = New(disk, config)
 		obj     = &tester{
 			db:           db,
 			preimages:    make(map[common.Hash]common.Address),
@@ -41,6 +41,15 @@ var (
 	// a destination without associated state history available.
 	errStateUnrecoverable = errors.New("state is unrecoverable")
 
+	// errStateUnavailable is returned if the requested historical state can't
+	// be served by the state histories, e.g. they are pruned or not indexed.
+	errStateUnavailable = errors.New("historical state is unavailable")
+
+	// errIncompleteHistory is returned if the requested storage slot can't be
+	// resolved because the storage changes of the owner account are not fully
+	// recorded in the state history.
+	errIncompleteHistory = errors.New("incomplete state history")
+
 	// errUnexpectedNode is returned if the requested node with specified path is
 	// not hash matched with expectation.
 	errUnexpectedNode = errors.New("unexpected node")
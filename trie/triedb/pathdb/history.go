@@ -533,6 +533,16 @@ func writeHistory(db zonddb.KeyValueStore, freezer *rawdb.ResettableFreezer, dl
 	// Write history data into five freezer table respectively.
 	rawdb.WriteStateHistory(freezer, dl.stateID(), h.meta.encode(), accountIndex, storageIndex, accountData, storageData)
 
+	// Index the history if the state history indexing is enabled.
+	if rawdb.ReadStateHistoryIndexHead(db) != nil {
+		batch := db.NewBatch()
+		indexHistory(batch, dl.stateID(), h)
+		rawdb.WriteStateHistoryIndexHead(batch, dl.stateID())
+		if err := batch.Write(); err != nil {
+			return err
+		}
+	}
+
 	// Prune stale state histories based on the config.
 	if limit != 0 && dl.stateID() > limit {
 		n, err = truncateFromTail(db, freezer, dl.stateID()-limit)
@@ -576,7 +586,7 @@ func checkHistories(freezer *rawdb.ResettableFreezer, start, count uint64, check
 
 // truncateFromHead removes the extra state histories from the head with the given
 // parameters. It returns the number of items removed from the head.
-func truncateFromHead(db zonddb.Batcher, freezer *rawdb.ResettableFreezer, nhead uint64) (int, error) {
+func truncateFromHead(db zonddb.KeyValueStore, freezer *rawdb.ResettableFreezer, nhead uint64) (int, error) {
 	ohead, err := freezer.Ancients()
 	if err != nil {
 		return 0, err
@@ -597,6 +607,16 @@ func truncateFromHead(db zonddb.Batcher, freezer *rawdb.ResettableFreezer, nhead
 		}
 		rawdb.DeleteStateID(batch, m.root)
 	}
+	// Drop the index entries of the truncated histories if the state history
+	// indexing is enabled, and rewind the index head accordingly.
+	if indexHead := rawdb.ReadStateHistoryIndexHead(db); indexHead != nil {
+		if err := unindexHistories(batch, freezer, *indexHead, nhead+1, ohead); err != nil {
+			return 0, err
+		}
+		if *indexHead > nhead {
+			rawdb.WriteStateHistoryIndexHead(batch, nhead)
+		}
+	}
 	if err := batch.Write(); err != nil {
 		return 0, err
 	}
@@ -609,7 +629,7 @@ func truncateFromHead(db zonddb.Batcher, freezer *rawdb.ResettableFreezer, nhead
 
 // truncateFromTail removes the extra state histories from the tail with the given
 // parameters. It returns the number of items removed from the tail.
-func truncateFromTail(db zonddb.Batcher, freezer *rawdb.ResettableFreezer, ntail uint64) (int, error) {
+func truncateFromTail(db zonddb.KeyValueStore, freezer *rawdb.ResettableFreezer, ntail uint64) (int, error) {
 	otail, err := freezer.Tail()
 	if err != nil {
 		return 0, err
@@ -630,6 +650,13 @@ func truncateFromTail(db zonddb.Batcher, freezer *rawdb.ResettableFreezer, ntail
 		}
 		rawdb.DeleteStateID(batch, m.root)
 	}
+	// Drop the index entries of the pruned histories if the state history
+	// indexing is enabled.
+	if indexHead := rawdb.ReadStateHistoryIndexHead(db); indexHead != nil {
+		if err := unindexHistories(batch, freezer, *indexHead, otail+1, ntail); err != nil {
+			return 0, err
+		}
+	}
 	if err := batch.Write(); err != nil {
 		return 0, err
 	}
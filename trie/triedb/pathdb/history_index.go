@@ -0,0 +1,147 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>
+
+package pathdb
+
+import (
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/zonddb"
+)
+
+// The state history index maps every account and storage slot to the ids of
+// the state histories in which it was mutated. It's maintained alongside the
+// state histories in the freezer, and is used to locate the original value
+// of a state at an arbitrary historical point without applying the reverse
+// diffs one by one:
+//
+//   - the value of the state at state id n is recorded in the first state
+//     history with id > n in which the state was mutated
+//   - if no such history exists, the state was not mutated since then and
+//     the value can be resolved from the disk layer directly
+//
+// The index entries are stored in the key-value store and keyed by the state
+// identifier followed by the big-endian encoded history id, so that the first
+// relevant history can be located by a single iterator seek. The id of the last
+// indexed state history is tracked as well, and its presence indicates that
+// the indexing is enabled.
+
+// indexHistory writes the index entries of the given state history into the
+// provided batch.
+func indexHistory(batch zonddb.KeyValueWriter, id uint64, h *history) {
+	for _, addr := range h.accountList {
+		rawdb.WriteStateHistoryAccountIndex(batch, addr, id)
+		for _, slotHash := range h.storageList[addr] {
+			rawdb.WriteStateHistoryStorageIndex(batch, addr, slotHash, id)
+		}
+	}
+	for _, addr := range h.meta.incomplete {
+		rawdb.WriteStateHistoryIncompleteIndex(batch, addr, id)
+	}
+}
+
+// unindexHistory removes the index entries of the given state history with
+// the provided batch.
+func unindexHistory(batch zonddb.KeyValueWriter, id uint64, h *history) {
+	for _, addr := range h.accountList {
+		rawdb.DeleteStateHistoryAccountIndex(batch, addr, id)
+		for _, slotHash := range h.storageList[addr] {
+			rawdb.DeleteStateHistoryStorageIndex(batch, addr, slotHash, id)
+		}
+	}
+	for _, addr := range h.meta.incomplete {
+		rawdb.DeleteStateHistoryIncompleteIndex(batch, addr, id)
+	}
+}
+
+// unindexHistories removes the index entries of the state histories within
+// the range [start, end]. Histories above the index head are skipped as they
+// are not indexed yet. The index head is left untouched, it's the caller's
+// responsibility to adjust it if needed.
+func unindexHistories(batch zonddb.KeyValueWriter, freezer *rawdb.ResettableFreezer, head uint64, start, end uint64) error {
+	if end > head {
+		end = head
+	}
+	for id := start; id <= end; id++ {
+		h, err := readHistory(freezer, id)
+		if err != nil {
+			return err
+		}
+		unindexHistory(batch, id, h)
+	}
+	return nil
+}
+
+// initHistoryIndex aligns the state history index with the state histories
+// stored in the freezer. If the indexing is enabled, all the histories not
+// yet indexed will be indexed; otherwise the stale index left by a previous
+// run will be removed entirely.
+func initHistoryIndex(db zonddb.KeyValueStore, freezer *rawdb.ResettableFreezer, enabled bool) error {
+	indexHead := rawdb.ReadStateHistoryIndexHead(db)
+	if !enabled {
+		if indexHead == nil {
+			return nil
+		}
+		log.Info("Deleting state history index")
+		return rawdb.DeleteStateHistoryIndex(db)
+	}
+	tail, err := freezer.Tail()
+	if err != nil {
+		return err
+	}
+	head, err := freezer.Ancients()
+	if err != nil {
+		return err
+	}
+	// The id of the first state history starts from one, so the id of the last
+	// stored history equals the number of items in the freezer, and the ids of
+	// all the histories pruned from the tail are not higher than the tail.
+	start := tail + 1
+	if indexHead != nil && *indexHead+1 > start {
+		start = *indexHead + 1
+	}
+	var (
+		batch  = db.NewBatch()
+		begin  = time.Now()
+		logged = time.Now()
+	)
+	for id := start; id <= head; id++ {
+		h, err := readHistory(freezer, id)
+		if err != nil {
+			return err
+		}
+		indexHistory(batch, id, h)
+		if batch.ValueSize() >= zonddb.IdealBatchSize {
+			rawdb.WriteStateHistoryIndexHead(batch, id)
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Indexing state histories", "indexed", id-start+1, "remaining", head-id, "elapsed", common.PrettyDuration(time.Since(begin)))
+			logged = time.Now()
+		}
+	}
+	if start <= head {
+		log.Info("Indexed state histories", "count", head-start+1, "elapsed", common.PrettyDuration(time.Since(begin)))
+	}
+	rawdb.WriteStateHistoryIndexHead(batch, head)
+	return batch.Write()
+}
@@ -0,0 +1,269 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>
+
+package pathdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/rlp"
+	"github.com/theQRL/go-zond/trie/triestate"
+)
+
+// HistoricalStateReader is a reader for accessing the state at a historical
+// point which is no longer maintained in the layer tree. The state is rebuilt
+// from the indexed state histories on top of the persistent disk state.
+type HistoricalStateReader struct {
+	id     uint64               // The state id of the requested state
+	root   common.Hash          // The state root of the requested state
+	db     *Database            // The database for accessing state histories
+	loader triestate.TrieLoader // The loader for accessing the disk state
+}
+
+// HistoricReader constructs a reader for accessing the requested historical
+// state. An error will be returned if the state is not reachable from the
+// state histories, or the state history indexing is not enabled.
+func (db *Database) HistoricReader(root common.Hash, loader triestate.TrieLoader) (*HistoricalStateReader, error) {
+	if db.freezer == nil || rawdb.ReadStateHistoryIndexHead(db.diskdb) == nil {
+		return nil, fmt.Errorf("%w: state history is not indexed", errStateUnavailable)
+	}
+	root = types.TrieRootHash(root)
+	id := rawdb.ReadStateID(db.diskdb, root)
+	if id == nil {
+		return nil, fmt.Errorf("%w: state %#x is unknown", errStateUnavailable, root)
+	}
+	r := &HistoricalStateReader{
+		id:     *id,
+		root:   root,
+		db:     db,
+		loader: loader,
+	}
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if err := r.check(db.tree.bottom()); err != nil {
+		return nil, err
+	}
+	// Ensure the state history following the requested state is linked with
+	// it. The root->id lookups are only maintained for the canonical states,
+	// it's just a sanity check for detecting the corrupted lookups.
+	if r.id < db.tree.bottom().stateID() {
+		var m meta
+		if err := m.decode(rawdb.ReadStateHistoryMeta(db.freezer, r.id+1)); err != nil {
+			return nil, err
+		}
+		if m.parent != root {
+			return nil, fmt.Errorf("%w: want %#x, got %#x", errUnexpectedHistory, root, m.parent)
+		}
+	}
+	return r, nil
+}
+
+// check ensures the requested state is still reachable with the given disk
+// layer, by checking all the state histories from the requested state up to
+// the disk layer are still present.
+func (r *HistoricalStateReader) check(dl layer) error {
+	if r.id > dl.stateID() {
+		return fmt.Errorf("%w: state %#x is above the disk layer", errStateUnavailable, r.root)
+	}
+	tail, err := r.db.freezer.Tail()
+	if err != nil {
+		return err
+	}
+	if r.id < tail {
+		return fmt.Errorf("%w: state history of %#x is pruned", errStateUnavailable, r.root)
+	}
+	return nil
+}
+
+// Account returns the account with the provided address in the requested
+// state. Nil is returned if the account was not present.
+func (r *HistoricalStateReader) Account(address common.Address) (*types.StateAccount, error) {
+	// Hold the read lock to prevent the disk layer from being moved forward
+	// or reverted while the state is being resolved.
+	r.db.lock.RLock()
+	defer r.db.lock.RUnlock()
+
+	dl := r.db.tree.bottom()
+	if err := r.check(dl); err != nil {
+		return nil, err
+	}
+	id, ok := rawdb.FindStateHistoryAccountIndex(r.db.diskdb, address, r.id+1)
+	if !ok || id > dl.stateID() {
+		return r.diskAccount(dl, address)
+	}
+	_, blob, err := readHistoryAccount(r.db.freezer, id, address)
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	return types.FullAccount(blob)
+}
+
+// Storage returns the storage slot with the provided account address and
+// slot key hash in the requested state. The returned value is the slot
+// content with the leading zeros trimmed, nil if the slot was not present.
+func (r *HistoricalStateReader) Storage(address common.Address, slotHash common.Hash) ([]byte, error) {
+	// Hold the read lock to prevent the disk layer from being moved forward
+	// or reverted while the state is being resolved.
+	r.db.lock.RLock()
+	defer r.db.lock.RUnlock()
+
+	dl := r.db.tree.bottom()
+	if err := r.check(dl); err != nil {
+		return nil, err
+	}
+	id, found := rawdb.FindStateHistoryStorageIndex(r.db.diskdb, address, slotHash, r.id+1)
+	if found && id > dl.stateID() {
+		found = false
+	}
+	// The storage deletion of a large contract is not fully recorded in the
+	// state history. Reject the slot if any incomplete history is applied
+	// before the first one containing the slot, since the slot may have been
+	// wiped without being tracked.
+	if n, ok := rawdb.FindStateHistoryIncompleteIndex(r.db.diskdb, address, r.id+1); ok && n <= dl.stateID() && (!found || n < id) {
+		return nil, fmt.Errorf("%w: address %#x, id %d", errIncompleteHistory, address, n)
+	}
+	var (
+		blob []byte
+		err  error
+	)
+	if found {
+		blob, err = readHistoryStorage(r.db.freezer, id, address, slotHash)
+	} else {
+		blob, err = r.diskStorage(dl, address, slotHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	_, content, _, err := rlp.Split(blob)
+	return content, err
+}
+
+// diskAccount resolves the account from the persistent disk state.
+func (r *HistoricalStateReader) diskAccount(dl layer, address common.Address) (*types.StateAccount, error) {
+	tr, err := r.loader.OpenTrie(dl.rootHash())
+	if err != nil {
+		return nil, err
+	}
+	blob, err := tr.Get(crypto.Keccak256(address.Bytes()))
+	if err != nil || len(blob) == 0 {
+		return nil, err
+	}
+	account := new(types.StateAccount)
+	if err := rlp.DecodeBytes(blob, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// diskStorage resolves the rlp-encoded storage slot from the persistent disk
+// state.
+func (r *HistoricalStateReader) diskStorage(dl layer, address common.Address, slotHash common.Hash) ([]byte, error) {
+	account, err := r.diskAccount(dl, address)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	tr, err := r.loader.OpenStorageTrie(dl.rootHash(), crypto.Keccak256Hash(address.Bytes()), account.Root)
+	if err != nil {
+		return nil, err
+	}
+	return tr.Get(slotHash.Bytes())
+}
+
+// readHistoryAccount locates the account in the state history with the given
+// id and returns the account index along with the original account data in
+// 'slim-rlp' format. Empty data means the account was not present.
+func readHistoryAccount(freezer *rawdb.ResettableFreezer, id uint64, address common.Address) (accountIndex, []byte, error) {
+	indexes := rawdb.ReadStateAccountIndex(freezer, id)
+	if len(indexes)%accountIndexSize != 0 || len(indexes) == 0 {
+		return accountIndex{}, nil, fmt.Errorf("invalid account index, id: %d, len: %d", id, len(indexes))
+	}
+	// The account indexes are sorted by address, binary search is performed
+	// to locate the requested one.
+	n := len(indexes) / accountIndexSize
+	pos := sort.Search(n, func(i int) bool {
+		start := i * accountIndexSize
+		return bytes.Compare(indexes[start:start+common.AddressLength], address.Bytes()) >= 0
+	})
+	if pos == n {
+		return accountIndex{}, nil, fmt.Errorf("account %#x is not found in state history %d", address, id)
+	}
+	var index accountIndex
+	index.decode(indexes[pos*accountIndexSize : (pos+1)*accountIndexSize])
+	if index.address != address {
+		return accountIndex{}, nil, fmt.Errorf("account %#x is not found in state history %d", address, id)
+	}
+	data := rawdb.ReadStateAccountHistory(freezer, id)
+	last := index.offset + uint32(index.length)
+	if uint32(len(data)) < last {
+		return accountIndex{}, nil, errors.New("account data buffer is corrupted")
+	}
+	return index, data[index.offset:last], nil
+}
+
+// readHistoryStorage locates the storage slot in the state history with the
+// given id and returns the original slot value in rlp-encoded format. Empty
+// data means the slot was not present.
+func readHistoryStorage(freezer *rawdb.ResettableFreezer, id uint64, address common.Address, slotHash common.Hash) ([]byte, error) {
+	accIndex, _, err := readHistoryAccount(freezer, id, address)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		indexes = rawdb.ReadStateStorageIndex(freezer, id)
+		start   = int(accIndex.storageOffset) * slotIndexSize
+		end     = int(accIndex.storageOffset+accIndex.storageSlots) * slotIndexSize
+	)
+	if len(indexes) < end {
+		return nil, errors.New("storage index buffer is corrupted")
+	}
+	indexes = indexes[start:end]
+
+	// The slot indexes of an account are sorted by slot hash, binary search
+	// is performed to locate the requested one.
+	n := int(accIndex.storageSlots)
+	pos := sort.Search(n, func(i int) bool {
+		start := i * slotIndexSize
+		return bytes.Compare(indexes[start:start+common.HashLength], slotHash.Bytes()) >= 0
+	})
+	if pos == n {
+		return nil, fmt.Errorf("storage slot %#x of %#x is not found in state history %d", slotHash, address, id)
+	}
+	var index slotIndex
+	index.decode(indexes[pos*slotIndexSize : (pos+1)*slotIndexSize])
+	if index.hash != slotHash {
+		return nil, fmt.Errorf("storage slot %#x of %#x is not found in state history %d", slotHash, address, id)
+	}
+	data := rawdb.ReadStateStorageHistory(freezer, id)
+	last := index.offset + uint32(index.length)
+	if uint32(len(data)) < last {
+		return nil, errors.New("storage data buffer is corrupted")
+	}
+	return data[index.offset:last], nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>
+
+package pathdb
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/rlp"
+	"github.com/theQRL/go-zond/trie/triestate"
+)
+
+// fullAccountLoader is a trie loader serving the accounts in the full
+// rlp format, which is the format used by the account trie.
+type fullAccountLoader struct {
+	*hashLoader
+}
+
+func newFullAccountLoader(accounts map[common.Hash][]byte, storages map[common.Hash]map[common.Hash][]byte) *fullAccountLoader {
+	full := make(map[common.Hash][]byte, len(accounts))
+	for addrHash, blob := range accounts {
+		account, err := types.FullAccount(blob)
+		if err != nil {
+			panic(err)
+		}
+		full[addrHash], _ = rlp.EncodeToBytes(account)
+	}
+	return &fullAccountLoader{hashLoader: newHashLoader(full, storages)}
+}
+
+// OpenTrie opens the main account trie, the root is not verified as the
+// accounts are re-encoded.
+func (l *fullAccountLoader) OpenTrie(root common.Hash) (triestate.Trie, error) {
+	return &testHasher{dirties: make(map[common.Hash][]byte), cleans: l.accounts}, nil
+}
+
+func TestHistoricReader(t *testing.T) {
+	tester := newTesterWithConfig(t, &Config{
+		EnableStateIndexing: true,
+		CleanCacheSize:      256 * 1024,
+		DirtyCacheSize:      256 * 1024,
+	})
+	defer tester.release()
+
+	var (
+		bottom = tester.bottomIndex()
+		disk   = tester.roots[bottom]
+		loader = newFullAccountLoader(tester.snapAccounts[disk], tester.snapStorages[disk])
+	)
+	// The in-memory database scans the whole key space for every iterator,
+	// check a few historical states only to keep the test fast.
+	for _, i := range []int{0, bottom / 2, bottom - 1} {
+		root := tester.roots[i]
+		reader, err := tester.db.HistoricReader(root, loader)
+		if err != nil {
+			t.Fatalf("Failed to open historic reader, index: %d, err: %v", i, err)
+		}
+		for addrHash, addr := range tester.preimages {
+			account, err := reader.Account(addr)
+			if err != nil {
+				t.Fatalf("Failed to read account, index: %d, err: %v", i, err)
+			}
+			blob, ok := tester.snapAccounts[root][addrHash]
+			if !ok {
+				if account != nil {
+					t.Fatalf("Unexpected account, index: %d, address: %x", i, addr)
+				}
+				continue
+			}
+			if account == nil || !bytes.Equal(types.SlimAccountRLP(*account), blob) {
+				t.Fatalf("Account is mismatched, index: %d, address: %x", i, addr)
+			}
+			for slotHash, slot := range tester.snapStorages[root][addrHash] {
+				value, err := reader.Storage(addr, slotHash)
+				if err != nil {
+					t.Fatalf("Failed to read storage, index: %d, err: %v", i, err)
+				}
+				_, content, _, _ := rlp.Split(slot)
+				if !bytes.Equal(value, content) {
+					t.Fatalf("Storage is mismatched, index: %d, address: %x, slot: %x", i, addr, slotHash)
+				}
+			}
+		}
+	}
+	// Pruned state histories can't serve the historical state anymore.
+	if _, err := truncateFromTail(tester.db.diskdb, tester.db.freezer, 1); err != nil {
+		t.Fatalf("Failed to truncate state history, err: %v", err)
+	}
+	if _, err := tester.db.HistoricReader(tester.roots[0], loader); !errors.Is(err, errStateUnavailable) {
+		t.Fatalf("Unexpected error, want %v, got %v", errStateUnavailable, err)
+	}
+}
+
+func TestHistoricReaderDisabled(t *testing.T) {
+	tester := newTester(t)
+	defer tester.release()
+
+	loader := newHashLoader(tester.snapAccounts[tester.roots[0]], tester.snapStorages[tester.roots[0]])
+	if _, err := tester.db.HistoricReader(tester.roots[0], loader); !errors.Is(err, errStateUnavailable) {
+		t.Fatalf("Unexpected error, want %v, got %v", errStateUnavailable, err)
+	}
+}
@@ -24,8 +24,11 @@ import (
 	"os"
 	"strings"
 
+	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/txpool"
 	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/internal/zondapi"
 	"github.com/theQRL/go-zond/rlp"
 )
 
@@ -141,3 +144,55 @@ func (api *AdminAPI) ImportChain(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// LocalTransactions returns the local transactions tracked by the node, grouped
+// by account and nonce. These are the transactions persisted to survive node
+// restarts.
+func (api *AdminAPI) LocalTransactions() map[common.Address]map[string]*zondapi.RPCTransaction {
+	var (
+		content = make(map[common.Address]map[string]*zondapi.RPCTransaction)
+		header  = api.zond.BlockChain().CurrentHeader()
+		config  = api.zond.BlockChain().Config()
+	)
+	for account, txs := range api.zond.legacyPool.LocalTransactions() {
+		dump := make(map[string]*zondapi.RPCTransaction, len(txs))
+		for _, tx := range txs {
+			dump[fmt.Sprintf("%d", tx.Nonce())] = zondapi.NewRPCPendingTransaction(tx, header, config)
+		}
+		content[account] = dump
+	}
+	return content
+}
+
+// DropLocalTransaction removes a local transaction from the pool and from disk,
+// e.g. to discard a stuck transaction. The later transactions of the account
+// are kept, but can't be executed until the nonce gap is filled. It returns
+// whether the transaction was found.
+func (api *AdminAPI) DropLocalTransaction(hash common.Hash) bool {
+	return api.zond.legacyPool.DropLocal(hash)
+}
+
+// RebroadcastLocalTransactions propagates the executable local transactions to
+// the peers again, limited to the given account if any. It returns the hashes
+// of the rebroadcast transactions.
+func (api *AdminAPI) RebroadcastLocalTransactions(account *common.Address) []common.Hash {
+	var (
+		txs    types.Transactions
+		hashes = make([]common.Hash, 0)
+	)
+	for addr, list := range api.zond.legacyPool.LocalTransactions() {
+		if account != nil && *account != addr {
+			continue
+		}
+		for _, tx := range list {
+			if api.zond.txPool.Status(tx.Hash()) == txpool.TxStatusPending {
+				txs = append(txs, tx)
+				hashes = append(hashes, tx.Hash())
+			}
+		}
+	}
+	if len(txs) > 0 {
+		api.zond.handler.BroadcastTransactions(txs)
+	}
+	return hashes
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package zond
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/txpool/legacypool"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/zond/downloader"
+)
+
+// Tests that the admin API lists, drops and rebroadcasts the local transactions
+// tracked by the pool.
+func TestAdminLocalTransactions(t *testing.T) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{testAddr: {Balance: big.NewInt(params.Ether)}},
+		}
+	)
+	chain, _ := core.NewBlockChain(db, nil, gspec, beacon.NewFaker(), vm.Config{}, nil)
+	defer chain.Stop()
+
+	legacyPool := legacypool.New(legacypool.DefaultConfig, chain, db)
+	pool, err := txpool.New(new(big.Int).SetUint64(legacypool.DefaultConfig.PriceLimit), chain, []txpool.SubPool{legacyPool})
+	if err != nil {
+		t.Fatalf("failed to create transaction pool: %v", err)
+	}
+	defer pool.Close()
+
+	handler, err := newHandler(&handlerConfig{
+		Database:   db,
+		Chain:      chain,
+		TxPool:     pool,
+		Network:    1,
+		Sync:       downloader.SnapSync,
+		BloomCache: 1,
+	})
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	handler.Start(1000)
+	defer handler.Stop()
+
+	api := NewAdminAPI(&Zond{blockchain: chain, txPool: pool, legacyPool: legacyPool, handler: handler})
+
+	signer := types.LatestSigner(params.TestChainConfig)
+	txs := make([]*types.Transaction, 3)
+	for i := range txs {
+		txs[i] = types.MustSignNewTx(testKey, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     uint64(i),
+			To:        &common.Address{},
+			Gas:       params.TxGas,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: big.NewInt(10 * params.GWei),
+		})
+	}
+	for _, err := range pool.Add(txs, true, true) {
+		if err != nil {
+			t.Fatalf("failed to add local transaction: %v", err)
+		}
+	}
+	locals := api.LocalTransactions()
+	if len(locals) != 1 || len(locals[testAddr]) != 3 {
+		t.Fatalf("local transactions mismatch: have %v, want 3 of %v", locals, testAddr)
+	}
+	if have := locals[testAddr]["1"].Hash; have != txs[1].Hash() {
+		t.Errorf("local transaction 1 mismatch: have %x, want %x", have, txs[1].Hash())
+	}
+	// Only the executable transactions of the requested account are rebroadcast
+	if hashes := api.RebroadcastLocalTransactions(&common.Address{0x01}); len(hashes) != 0 {
+		t.Errorf("rebroadcast transactions of unknown account: have %d, want 0", len(hashes))
+	}
+	if hashes := api.RebroadcastLocalTransactions(nil); len(hashes) != 3 {
+		t.Errorf("rebroadcast transactions mismatch: have %d, want 3", len(hashes))
+	}
+	// Dropping a transaction leaves the later ones unexecutable
+	if api.DropLocalTransaction(common.Hash{0x01}) {
+		t.Errorf("unknown transaction dropped")
+	}
+	if !api.DropLocalTransaction(txs[1].Hash()) {
+		t.Fatalf("failed to drop local transaction")
+	}
+	if locals := api.LocalTransactions(); len(locals[testAddr]) != 2 {
+		t.Errorf("local transactions mismatch after drop: have %d, want 2", len(locals[testAddr]))
+	}
+	account := common.Address(testAddr)
+	hashes := api.RebroadcastLocalTransactions(&account)
+	if len(hashes) != 1 || hashes[0] != txs[0].Hash() {
+		t.Errorf("rebroadcast transactions mismatch after drop: have %v, want [%x]", hashes, txs[0].Hash())
+	}
+}
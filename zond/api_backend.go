@@ -57,6 +57,10 @@ func (b *ZondAPIBackend) CurrentBlock() *types.Header {
 	return b.zond.blockchain.CurrentBlock()
 }
 
+func (b *ZondAPIBackend) HistoryPruningCutoff() uint64 {
+	return b.zond.blockchain.HistoryPruningCutoff()
+}
+
 func (b *ZondAPIBackend) SetHead(number uint64) {
 	b.zond.handler.downloader.Cancel()
 	b.zond.blockchain.SetHead(number)
@@ -201,7 +205,7 @@ func (b *ZondAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.
 	if header == nil {
 		return nil, nil, errors.New("header not found")
 	}
-	stateDb, err := b.zond.BlockChain().StateAt(header.Root)
+	stateDb, err := b.stateAt(header.Root)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -223,7 +227,7 @@ func (b *ZondAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, block
 		if blockNrOrHash.RequireCanonical && b.zond.blockchain.GetCanonicalHash(header.Number.Uint64()) != hash {
 			return nil, nil, errors.New("hash is not currently canonical")
 		}
-		stateDb, err := b.zond.BlockChain().StateAt(header.Root)
+		stateDb, err := b.stateAt(header.Root)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -232,6 +236,20 @@ func (b *ZondAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, block
 	return nil, nil, errors.New("invalid arguments; neither block nor hash specified")
 }
 
+// stateAt returns the state with the given root, falling back to the historical
+// state rebuilt from state histories if it's no longer maintained in the live
+// database.
+func (b *ZondAPIBackend) stateAt(root common.Hash) (*state.StateDB, error) {
+	stateDb, err := b.zond.BlockChain().StateAt(root)
+	if err == nil {
+		return stateDb, nil
+	}
+	if historic, herr := b.zond.BlockChain().HistoricState(root); herr == nil {
+		return historic, nil
+	}
+	return nil, err
+}
+
 func (b *ZondAPIBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
 	return b.zond.blockchain.GetReceiptsByHash(hash), nil
 }
@@ -316,6 +334,10 @@ func (b *ZondAPIBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transa
 	return b.zond.txPool.ContentFrom(addr)
 }
 
+func (b *ZondAPIBackend) TxPoolPrediction(hash common.Hash) *txpool.Prediction {
+	return b.zond.txPool.Prediction(hash)
+}
+
 func (b *ZondAPIBackend) TxPool() *txpool.TxPool {
 	return b.zond.txPool
 }
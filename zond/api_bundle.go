@@ -0,0 +1,71 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package zond
+
+import (
+	"fmt"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/miner"
+)
+
+// BundleAPI provides an API to submit atomic transaction bundles to the miner.
+type BundleAPI struct {
+	z *Zond
+}
+
+// NewBundleAPI creates a new BundleAPI instance.
+func NewBundleAPI(z *Zond) *BundleAPI {
+	return &BundleAPI{z}
+}
+
+// SendBundleArgs represents the arguments of a bundle submission.
+type SendBundleArgs struct {
+	Txs          []hexutil.Bytes `json:"txs"`
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	MinTimestamp *hexutil.Uint64 `json:"minTimestamp"`
+	MaxTimestamp *hexutil.Uint64 `json:"maxTimestamp"`
+}
+
+// SendBundle submits a bundle of signed transactions for inclusion into the
+// given block and returns the hash of the bundle. The transactions are either
+// all included in order, or none of them if any fails or reverts.
+func (api *BundleAPI) SendBundle(args SendBundleArgs) (common.Hash, error) {
+	bundle := &miner.Bundle{
+		Txs:         make(types.Transactions, len(args.Txs)),
+		BlockNumber: uint64(args.BlockNumber),
+	}
+	for i, encoded := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(encoded); err != nil {
+			return common.Hash{}, fmt.Errorf("invalid transaction %d: %w", i, err)
+		}
+		bundle.Txs[i] = tx
+	}
+	if args.MinTimestamp != nil {
+		bundle.MinTimestamp = uint64(*args.MinTimestamp)
+	}
+	if args.MaxTimestamp != nil {
+		bundle.MaxTimestamp = uint64(*args.MaxTimestamp)
+	}
+	if err := api.z.Miner().SendBundle(bundle); err != nil {
+		return common.Hash{}, err
+	}
+	return bundle.Hash(), nil
+}
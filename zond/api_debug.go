@@ -24,6 +24,7 @@ import (
 
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
 	"github.com/theQRL/go-zond/core/types"
@@ -443,3 +444,162 @@ func (api *DebugAPI) GetTrieFlushInterval() (string, error) {
 	}
 	return api.zond.blockchain.GetTrieFlushInterval().String(), nil
 }
+
+// StateDiffResult is the result of a debug_getStateDiff API call and the
+// notification of the debug_subscribe("stateDiffs") subscription.
+type StateDiffResult struct {
+	Number   hexutil.Uint64       `json:"number"`
+	Hash     common.Hash          `json:"hash"`
+	Accounts []*AccountDiffResult `json:"accounts"`
+}
+
+// AccountDiffResult is an account mutated by a block. The account state before
+// and after the block is null if the account was not present.
+type AccountDiffResult struct {
+	Address common.Address       `json:"address"`
+	Prev    *AccountStateResult  `json:"prev"`
+	Post    *AccountStateResult  `json:"post"`
+	Storage []*StorageDiffResult `json:"storage,omitempty"`
+}
+
+// AccountStateResult is the state of an account in a state diff.
+type AccountStateResult struct {
+	Nonce       hexutil.Uint64 `json:"nonce"`
+	Balance     *hexutil.Big   `json:"balance"`
+	CodeHash    common.Hash    `json:"codeHash"`
+	StorageRoot common.Hash    `json:"storageRoot"`
+}
+
+// StorageDiffResult is a storage slot mutated by a block. The slot is keyed by
+// the hash of the slot key, as the preimage is not tracked.
+type StorageDiffResult struct {
+	Hash common.Hash `json:"hash"`
+	Prev common.Hash `json:"prev"`
+	Post common.Hash `json:"post"`
+}
+
+// GetStateDiff returns the accounts and storage slots mutated by the given
+// block, along with their values before and after it. The diffs are only
+// available if their persistence is enabled, for the blocks within the state
+// history retention.
+func (api *DebugAPI) GetStateDiff(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*StateDiffResult, error) {
+	if !api.zond.config.StateDiffs {
+		return nil, errors.New("state diff persistence is not enabled")
+	}
+	header, err := api.zond.APIBackend.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.New("block not found")
+	}
+	diff := api.zond.blockchain.GetStateDiff(header.Hash(), header.Number.Uint64())
+	if diff == nil {
+		return nil, fmt.Errorf("state diff of block #%d not available", header.Number)
+	}
+	return newStateDiffResult(header, diff)
+}
+
+// StateDiffs creates a subscription that fires the state diff of each new
+// canonical block.
+func (api *DebugAPI) StateDiffs(ctx context.Context) (*rpc.Subscription, error) {
+	if !api.zond.config.StateDiffs {
+		return nil, errors.New("state diff persistence is not enabled")
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	var (
+		rpcSub = notifier.CreateSubscription()
+		diffs  = make(chan core.StateDiffEvent, 16)
+		sub    = api.zond.blockchain.SubscribeStateDiffEvent(diffs)
+	)
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case ev := <-diffs:
+				result, err := newStateDiffResult(ev.Header, ev.Diff)
+				if err != nil {
+					log.Warn("Failed to convert state diff", "number", ev.Header.Number, "hash", ev.Header.Hash(), "err", err)
+					continue
+				}
+				notifier.Notify(rpcSub.ID, result)
+			case <-rpcSub.Err():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// newStateDiffResult converts the state diff of the given block into its RPC
+// representation.
+func newStateDiffResult(header *types.Header, diff *types.StateDiff) (*StateDiffResult, error) {
+	result := &StateDiffResult{
+		Number:   hexutil.Uint64(header.Number.Uint64()),
+		Hash:     header.Hash(),
+		Accounts: make([]*AccountDiffResult, 0, len(diff.Accounts)),
+	}
+	for _, account := range diff.Accounts {
+		prev, err := newAccountStateResult(account.Prev)
+		if err != nil {
+			return nil, err
+		}
+		post, err := newAccountStateResult(account.Post)
+		if err != nil {
+			return nil, err
+		}
+		entry := &AccountDiffResult{
+			Address: account.Address,
+			Prev:    prev,
+			Post:    post,
+		}
+		for _, slot := range account.Storage {
+			prev, err := decodeStorageValue(slot.Prev)
+			if err != nil {
+				return nil, err
+			}
+			post, err := decodeStorageValue(slot.Post)
+			if err != nil {
+				return nil, err
+			}
+			entry.Storage = append(entry.Storage, &StorageDiffResult{Hash: slot.Hash, Prev: prev, Post: post})
+		}
+		result.Accounts = append(result.Accounts, entry)
+	}
+	return result, nil
+}
+
+// newAccountStateResult decodes the account in 'slim RLP' format, nil if the
+// account is not present.
+func newAccountStateResult(blob []byte) (*AccountStateResult, error) {
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	account, err := types.FullAccount(blob)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountStateResult{
+		Nonce:       hexutil.Uint64(account.Nonce),
+		Balance:     (*hexutil.Big)(account.Balance),
+		CodeHash:    common.BytesToHash(account.CodeHash),
+		StorageRoot: account.Root,
+	}, nil
+}
+
+// decodeStorageValue decodes the slot value in prefix-zero trimmed RLP format.
+func decodeStorageValue(blob []byte) (common.Hash, error) {
+	if len(blob) == 0 {
+		return common.Hash{}, nil
+	}
+	_, content, _, err := rlp.Split(blob)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(content), nil
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/theQRL/go-zond/core/state"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/rlp"
 	"github.com/theQRL/go-zond/trie"
 )
 
@@ -219,3 +220,39 @@ func TestStorageRangeAt(t *testing.T) {
 		}
 	}
 }
+
+// Tests that the persisted state diffs are converted into their RPC form.
+func TestStateDiffResult(t *testing.T) {
+	var (
+		header = &types.Header{Number: big.NewInt(7)}
+		addr   = common.Address{0x01}
+		slot   = common.Hash{0x02}
+		prev   = types.StateAccount{Nonce: 1, Balance: big.NewInt(10), Root: types.EmptyRootHash, CodeHash: types.EmptyCodeHash.Bytes()}
+		value  = common.Hash{0x03}
+	)
+	blob, _ := rlp.EncodeToBytes(common.TrimLeftZeroes(value[:]))
+	diff := &types.StateDiff{
+		Accounts: []*types.AccountDiff{{
+			Address: addr,
+			Prev:    types.SlimAccountRLP(prev),
+			Storage: []*types.StorageDiff{{Hash: slot, Post: blob}},
+		}},
+	}
+	result, err := newStateDiffResult(header, diff)
+	if err != nil {
+		t.Fatalf("failed to convert state diff: %v", err)
+	}
+	if result.Number != 7 || result.Hash != header.Hash() || len(result.Accounts) != 1 {
+		t.Fatalf("unexpected state diff result: %s", dumper.Sdump(result))
+	}
+	account := result.Accounts[0]
+	if account.Address != addr || account.Post != nil {
+		t.Fatalf("unexpected account diff: %s", dumper.Sdump(account))
+	}
+	if account.Prev.Nonce != 1 || account.Prev.Balance.ToInt().Int64() != 10 || account.Prev.CodeHash != types.EmptyCodeHash || account.Prev.StorageRoot != types.EmptyRootHash {
+		t.Fatalf("unexpected account state: %s", dumper.Sdump(account.Prev))
+	}
+	if len(account.Storage) != 1 || account.Storage[0].Hash != slot || account.Storage[0].Prev != (common.Hash{}) || account.Storage[0].Post != value {
+		t.Fatalf("unexpected storage diff: %s", dumper.Sdump(account.Storage))
+	}
+}
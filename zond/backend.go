@@ -18,7 +18,9 @@
 package zond
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"math/big"
 	"runtime"
 	"sync"
@@ -32,6 +34,7 @@ import (
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state/pruner"
 	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/txpool/blobpool"
 	"github.com/theQRL/go-zond/core/txpool/legacypool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
@@ -51,6 +54,7 @@ import (
 	"github.com/theQRL/go-zond/zond/gasprice"
 	"github.com/theQRL/go-zond/zond/protocols/snap"
 	"github.com/theQRL/go-zond/zond/protocols/zond"
+	"github.com/theQRL/go-zond/zond/tracers"
 	"github.com/theQRL/go-zond/zond/zondconfig"
 	"github.com/theQRL/go-zond/zonddb"
 )
@@ -60,7 +64,8 @@ type Zond struct {
 	config *zondconfig.Config
 
 	// Handlers
-	txPool *txpool.TxPool
+	txPool     *txpool.TxPool
+	legacyPool *legacypool.LegacyPool // Subpool tracking the local transactions
 
 	blockchain         *core.BlockChain
 	handler            *handler
@@ -91,6 +96,8 @@ type Zond struct {
 	lock sync.RWMutex // Protects the variadic fields (e.g. gas price and etherbase)
 
 	shutdownTracker *shutdowncheck.ShutdownTracker // Tracks if and when the node has shutdown ungracefully
+	historyExpirer  *historyExpirer                // Exports and prunes the chain history behind the horizon, nil if disabled
+	liveTracer      core.BlockchainLogger          // Traces the whole block processing, nil if disabled
 }
 
 // New creates a new Zond object (including the initialisation of the common Zond object),
@@ -179,23 +186,61 @@ func New(stack *node.Node, config *zondconfig.Config) (*Zond, error) {
 			SnapshotLimit:       config.SnapshotCache,
 			Preimages:           config.Preimages,
 			StateHistory:        config.StateHistory,
+			StateDiffs:          config.StateDiffs,
 			StateScheme:         config.StateScheme,
 		}
 	)
+	if config.VMTrace != "" {
+		var traceConfig json.RawMessage
+		if config.VMTraceJsonConfig != "" {
+			traceConfig = json.RawMessage(config.VMTraceJsonConfig)
+		}
+		tracer, err := tracers.LiveDirectory.New(config.VMTrace, traceConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create live tracer %s: %v", config.VMTrace, err)
+		}
+		vmConfig.Tracer = tracer
+		zond.liveTracer = tracer
+	}
+	// The transactions can't be indexed without their bodies, bound the
+	// transaction indices by the retained chain history.
+	if config.HistoryHorizon != 0 && (config.TransactionHistory == 0 || config.TransactionHistory > config.HistoryHorizon) {
+		log.Warn("Limiting transaction indices to the history horizon", "provided", config.TransactionHistory, "updated", config.HistoryHorizon)
+		config.TransactionHistory = config.HistoryHorizon
+	}
 	zond.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, config.Genesis, zond.engine, vmConfig, &config.TransactionHistory)
 	if err != nil {
 		return nil, err
 	}
+	if config.HistoryHorizon != 0 {
+		dir := config.HistoryEraDir
+		if dir == "" {
+			dir = "era"
+		}
+		zond.historyExpirer = newHistoryExpirer(zond.blockchain, chainDb, stack.ResolvePath(dir), config.HistoryHorizon)
+	}
 	zond.bloomIndexer.Start(zond.blockchain)
 
-	if config.TxPool.Journal != "" {
-		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
+	if config.BlobPool.Datadir != "" {
+		config.BlobPool.Datadir = stack.ResolvePath(config.BlobPool.Datadir)
 	}
-	legacyPool := legacypool.New(config.TxPool, zond.blockchain)
-	zond.txPool, err = txpool.New(new(big.Int).SetUint64(config.TxPool.PriceLimit), zond.blockchain, []txpool.SubPool{legacyPool})
+	blobPool := blobpool.New(config.BlobPool, zond.blockchain)
+
+	zond.legacyPool = legacypool.New(config.TxPool, zond.blockchain, chainDb)
+	zond.txPool, err = txpool.New(new(big.Int).SetUint64(config.TxPool.PriceLimit), zond.blockchain, []txpool.SubPool{zond.legacyPool, blobPool})
 	if err != nil {
 		return nil, err
 	}
+	// Move the local transactions of the flat journal used by earlier versions
+	// into the database.
+	if err := zond.legacyPool.ImportJournal(stack.ResolvePath(legacypool.LegacyJournal)); err != nil {
+		log.Warn("Failed to import legacy transaction journal", "err", err)
+	}
+	if config.TxPool.Simulate {
+		zond.txPool.EnableSimulation(zond.blockchain)
+	} else if config.Miner.SkipReverting {
+		log.Warn("Reverting transactions can't be skipped without the transaction pool simulation")
+	}
 	// Permit the downloader to use the trie cache allowance during fast sync
 	cacheLimit := cacheConfig.TrieCleanLimit + cacheConfig.TrieDirtyLimit + cacheConfig.SnapshotLimit
 	if zond.handler, err = newHandler(&handlerConfig{
@@ -212,6 +257,7 @@ func New(stack *node.Node, config *zondconfig.Config) (*Zond, error) {
 		return nil, err
 	}
 
+	config.Miner.Lanes = zond.legacyPool.Lanes()
 	zond.miner = miner.New(zond, config.Miner, zond.engine)
 	zond.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 
@@ -278,6 +324,9 @@ func (s *Zond) APIs() []rpc.API {
 		{
 			Namespace: "miner",
 			Service:   NewMinerAPI(s),
+		}, {
+			Namespace: "bundle",
+			Service:   NewBundleAPI(s),
 		}, {
 			Namespace: "zond",
 			Service:   downloader.NewDownloaderAPI(s.handler.downloader, s.eventMux),
@@ -334,6 +383,11 @@ func (s *Zond) Start() error {
 	// Regularly update shutdown marker
 	s.shutdownTracker.Start()
 
+	// Start expiring the chain history if requested
+	if s.historyExpirer != nil {
+		s.historyExpirer.start()
+	}
+
 	// Figure out a max peers count based on the server limits
 	maxPeers := s.p2pServer.MaxPeers
 
@@ -354,7 +408,15 @@ func (s *Zond) Stop() error {
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
 	s.txPool.Close()
+	if s.historyExpirer != nil {
+		s.historyExpirer.stop()
+	}
 	s.blockchain.Stop()
+	if closer, ok := s.liveTracer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Error("Failed to close live tracer", "err", err)
+		}
+	}
 	s.engine.Close()
 
 	// Clean shutdown marker as the last thing before closing db
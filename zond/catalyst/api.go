@@ -20,6 +20,7 @@ package catalyst
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
@@ -76,8 +77,11 @@ const (
 // All methods provided over the engine endpoint.
 var caps = []string{
 	"engine_forkchoiceUpdatedV2",
+	"engine_forkchoiceUpdatedV3",
 	"engine_getPayloadV2",
+	"engine_getPayloadV3",
 	"engine_newPayloadV2",
+	"engine_newPayloadV3",
 	"engine_getPayloadBodiesByHashV1",
 	"engine_getPayloadBodiesByRangeV1",
 }
@@ -160,8 +164,33 @@ func newConsensusAPIWithoutHeartbeat(zond *zond.Zond) *ConsensusAPI {
 // and return its payloadID.
 // ForkchoiceUpdatedV2 is equivalent to V1 with the addition of withdrawals in the payload attributes.
 func (api *ConsensusAPI) ForkchoiceUpdatedV2(update engine.ForkchoiceStateV1, params *engine.PayloadAttributes) (engine.ForkChoiceResponse, error) {
-	if params != nil && params.Withdrawals == nil {
-		return engine.STATUS_INVALID, engine.InvalidPayloadAttributes.With(errors.New("missing withdrawals"))
+	if params != nil {
+		if params.Withdrawals == nil {
+			return engine.STATUS_INVALID, engine.InvalidPayloadAttributes.With(errors.New("missing withdrawals"))
+		}
+		if params.BeaconRoot != nil {
+			return engine.STATUS_INVALID, engine.InvalidPayloadAttributes.With(errors.New("unexpected beacon root"))
+		}
+		if api.zond.BlockChain().Config().IsCancun(nil, params.Timestamp) {
+			return engine.STATUS_INVALID, engine.UnsupportedFork.With(errors.New("forkchoiceUpdatedV2 must only be called for pre-cancun payloads"))
+		}
+	}
+	return api.forkchoiceUpdated(update, params, false)
+}
+
+// ForkchoiceUpdatedV3 is equivalent to V2 with the addition of parent beacon block root
+// in the payload attributes.
+func (api *ConsensusAPI) ForkchoiceUpdatedV3(update engine.ForkchoiceStateV1, params *engine.PayloadAttributes) (engine.ForkChoiceResponse, error) {
+	if params != nil {
+		if params.Withdrawals == nil {
+			return engine.STATUS_INVALID, engine.InvalidPayloadAttributes.With(errors.New("missing withdrawals"))
+		}
+		if params.BeaconRoot == nil {
+			return engine.STATUS_INVALID, engine.InvalidPayloadAttributes.With(errors.New("missing beacon root"))
+		}
+		if !api.zond.BlockChain().Config().IsCancun(nil, params.Timestamp) {
+			return engine.STATUS_INVALID, engine.UnsupportedFork.With(errors.New("forkchoiceUpdatedV3 must only be called for cancun payloads"))
+		}
 	}
 	return api.forkchoiceUpdated(update, params, false)
 }
@@ -280,6 +309,7 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 			FeeRecipient: payloadAttributes.SuggestedFeeRecipient,
 			Random:       payloadAttributes.Random,
 			Withdrawals:  payloadAttributes.Withdrawals,
+			BeaconRoot:   payloadAttributes.BeaconRoot,
 		}
 		id := args.Id()
 		// If we already are busy generating this work, then we do not need
@@ -311,9 +341,36 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 	return valid(nil), nil
 }
 
-// GetPayloadV2 returns a cached payload by id.
+// GetPayloadV2 returns a cached pre-cancun payload by id.
 func (api *ConsensusAPI) GetPayloadV2(payloadID engine.PayloadID) (*engine.ExecutionPayloadEnvelope, error) {
-	return api.getPayload(payloadID, false)
+	data, err := api.getPayload(payloadID, false)
+	if err != nil {
+		return nil, err
+	}
+	if api.isCancun(data.ExecutionPayload) {
+		return nil, engine.UnsupportedFork.With(errors.New("getPayloadV2 called for cancun payload"))
+	}
+	// Blobs bundle is not part of the V2 response.
+	data.BlobsBundle = nil
+	return data, nil
+}
+
+// GetPayloadV3 returns a cached cancun payload by id, along with the blobs
+// bundle of the blob transactions it contains.
+func (api *ConsensusAPI) GetPayloadV3(payloadID engine.PayloadID) (*engine.ExecutionPayloadEnvelope, error) {
+	data, err := api.getPayload(payloadID, false)
+	if err != nil {
+		return nil, err
+	}
+	if !api.isCancun(data.ExecutionPayload) {
+		return nil, engine.UnsupportedFork.With(errors.New("getPayloadV3 called for pre-cancun payload"))
+	}
+	return data, nil
+}
+
+// isCancun reports whether the given payload belongs to the cancun fork.
+func (api *ConsensusAPI) isCancun(payload *engine.ExecutableData) bool {
+	return api.zond.BlockChain().Config().IsCancun(new(big.Int).SetUint64(payload.Number), payload.Timestamp)
 }
 
 func (api *ConsensusAPI) getPayload(payloadID engine.PayloadID, full bool) (*engine.ExecutionPayloadEnvelope, error) {
@@ -330,11 +387,40 @@ func (api *ConsensusAPI) NewPayloadV2(params engine.ExecutableData) (engine.Payl
 	if params.Withdrawals == nil {
 		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil withdrawals post-shanghai"))
 	}
+	if params.ExcessBlobGas != nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("non-nil excessBlobGas pre-cancun"))
+	}
+	if params.BlobGasUsed != nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("non-nil blobGasUsed pre-cancun"))
+	}
+	return api.newPayload(params, nil, nil)
+}
 
-	return api.newPayload(params)
+// NewPayloadV3 creates a Zond execution block, inserts it in the chain, and returns the status of the chain.
+// The versioned hashes of the blob transactions in the payload are checked against the given list.
+func (api *ConsensusAPI) NewPayloadV3(params engine.ExecutableData, versionedHashes []common.Hash, beaconRoot *common.Hash) (engine.PayloadStatusV1, error) {
+	if params.Withdrawals == nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil withdrawals post-shanghai"))
+	}
+	if params.ExcessBlobGas == nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil excessBlobGas post-cancun"))
+	}
+	if params.BlobGasUsed == nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil blobGasUsed post-cancun"))
+	}
+	if versionedHashes == nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil versionedHashes post-cancun"))
+	}
+	if beaconRoot == nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil parentBeaconBlockRoot post-cancun"))
+	}
+	if !api.isCancun(&params) {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.UnsupportedFork.With(errors.New("newPayloadV3 called pre-cancun"))
+	}
+	return api.newPayload(params, versionedHashes, beaconRoot)
 }
 
-func (api *ConsensusAPI) newPayload(params engine.ExecutableData) (engine.PayloadStatusV1, error) {
+func (api *ConsensusAPI) newPayload(params engine.ExecutableData, versionedHashes []common.Hash, beaconRoot *common.Hash) (engine.PayloadStatusV1, error) {
 	// The locking here is, strictly, not required. Without these locks, this can happen:
 	//
 	// 1. NewPayload( execdata-N ) is invoked from the CL. It goes all the way down to
@@ -352,7 +438,7 @@ func (api *ConsensusAPI) newPayload(params engine.ExecutableData) (engine.Payloa
 	defer api.newPayloadLock.Unlock()
 
 	log.Trace("Engine API request received", "method", "NewPayload", "number", params.Number, "hash", params.BlockHash)
-	block, err := engine.ExecutableDataToBlock(params)
+	block, err := engine.ExecutableDataToBlock(params, versionedHashes, beaconRoot)
 	if err != nil {
 		log.Warn("Invalid NewPayload params",
 			"params.Number", params.Number,
@@ -369,6 +455,10 @@ func (api *ConsensusAPI) newPayload(params engine.ExecutableData) (engine.Payloa
 			"params.BaseFeePerGas", params.BaseFeePerGas,
 			"len(params.Transactions)", len(params.Transactions),
 			"len(params.Withdrawals)", len(params.Withdrawals),
+			"params.ExcessBlobGas", ptrToString(params.ExcessBlobGas),
+			"params.BlobGasUsed", ptrToString(params.BlobGasUsed),
+			"beaconRoot", beaconRoot,
+			"len(versionedHashes)", len(versionedHashes),
 			"error", err)
 		return api.invalid(err, nil), nil
 	}
@@ -655,3 +745,11 @@ func getBody(block *types.Block) *engine.ExecutionPayloadBodyV1 {
 		Withdrawals:     withdrawals,
 	}
 }
+
+// ptrToString converts an optional uint64 into a loggable string.
+func ptrToString(s *uint64) string {
+	if s != nil {
+		return fmt.Sprintf("%v", *s)
+	}
+	return "<nil>"
+}
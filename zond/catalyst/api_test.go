@@ -35,6 +35,7 @@ import (
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/blobcommit"
 	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/miner"
 	"github.com/theQRL/go-zond/node"
@@ -55,6 +56,9 @@ var (
 	testAddr = common.Address(testKey.GetAddress())
 
 	testBalance = big.NewInt(2e18)
+
+	// testBeaconRoot is the parent beacon block root of the payloads built in tests.
+	testBeaconRoot = common.Hash{42}
 )
 
 func generateChain(n int) (*core.Genesis, []*types.Block) {
@@ -104,7 +108,8 @@ func TestEth2AssembleBlock(t *testing.T) {
 	}
 	zondservice.TxPool().Add([]*types.Transaction{tx}, true, false)
 	blockParams := engine.PayloadAttributes{
-		Timestamp: blocks[9].Time() + 5,
+		Timestamp:  blocks[9].Time() + 5,
+		BeaconRoot: &testBeaconRoot,
 	}
 	// The miner needs to pick up on the txs in the pool, so a few retries might be
 	// needed.
@@ -141,7 +146,8 @@ func TestEth2AssembleBlockWithAnotherBlocksTxs(t *testing.T) {
 	txs := blocks[9].Transactions()
 	api.zond.TxPool().Add(txs, false, true)
 	blockParams := engine.PayloadAttributes{
-		Timestamp: blocks[8].Time() + 5,
+		Timestamp:  blocks[8].Time() + 5,
+		BeaconRoot: &testBeaconRoot,
 	}
 	// The miner needs to pick up on the txs in the pool, so a few retries might be
 	// needed.
@@ -150,6 +156,91 @@ func TestEth2AssembleBlockWithAnotherBlocksTxs(t *testing.T) {
 	}
 }
 
+func TestEth2AssembleBlockWithBlobs(t *testing.T) {
+	genesis, blocks := generateChain(10)
+	n, zondservice := startZondService(t, genesis, blocks)
+	defer n.Close()
+
+	api := NewConsensusAPI(zondservice)
+
+	// Put a blob transaction in the pool and produce a new block
+	var (
+		blob       = blobcommit.Blob{0x01}
+		commitment = blobcommit.BlobToCommitment(&blob)
+		sidecar    = &types.BlobTxSidecar{
+			Blobs:       []blobcommit.Blob{blob},
+			Commitments: []blobcommit.Commitment{commitment},
+		}
+		hashes = sidecar.BlobHashes()
+	)
+	tx := types.MustSignNewTx(testKey, types.LatestSigner(zondservice.BlockChain().Config()), &types.BlobTx{
+		ChainID:    zondservice.BlockChain().Config().ChainID,
+		Nonce:      10,
+		GasTipCap:  big.NewInt(params.GWei),
+		GasFeeCap:  big.NewInt(8750000000),
+		Gas:        params.TxGas,
+		To:         blocks[9].Coinbase(),
+		Value:      big.NewInt(1),
+		BlobFeeCap: big.NewInt(params.GWei),
+		BlobHashes: hashes,
+		Sidecar:    sidecar,
+	})
+	// Blob pool rejects nonce gaps, so wait until the pool catches up with the head
+	if err := zondservice.TxPool().Sync(); err != nil {
+		t.Fatalf("failed to sync transaction pool: %v", err)
+	}
+	if errs := zondservice.TxPool().Add([]*types.Transaction{tx}, true, false); errs[0] != nil {
+		t.Fatalf("failed to add blob transaction: %v", errs[0])
+	}
+	args := &miner.BuildPayloadArgs{
+		Parent:      blocks[9].Hash(),
+		Timestamp:   blocks[9].Time() + 5,
+		Withdrawals: []*types.Withdrawal{},
+		BeaconRoot:  &testBeaconRoot,
+	}
+	// The miner needs to pick up on the txs in the pool, so a few retries might be
+	// needed.
+	var envelope *engine.ExecutionPayloadEnvelope
+	for retries := 3; retries > 0; retries-- {
+		payload, err := zondservice.Miner().BuildPayload(args)
+		if err != nil {
+			t.Fatalf("error building payload: %v", err)
+		}
+		envelope = payload.ResolveFull()
+		if len(envelope.ExecutionPayload.Transactions) == 1 {
+			break
+		}
+	}
+	if have := len(envelope.ExecutionPayload.Transactions); have != 1 {
+		t.Fatalf("invalid number of transactions, have %d want 1", have)
+	}
+	if envelope.BlobsBundle == nil || len(envelope.BlobsBundle.Commitments) != 1 || len(envelope.BlobsBundle.Blobs) != 1 {
+		t.Fatalf("invalid blobs bundle: %v", envelope.BlobsBundle)
+	}
+	if !bytes.Equal(envelope.BlobsBundle.Commitments[0], commitment[:]) {
+		t.Fatalf("invalid commitment, have %x want %x", envelope.BlobsBundle.Commitments[0], commitment)
+	}
+	if have, want := *envelope.ExecutionPayload.BlobGasUsed, uint64(params.BlobTxBlobGasPerBlob); have != want {
+		t.Fatalf("invalid blob gas used, have %d want %d", have, want)
+	}
+	// Mismatching versioned hashes must be rejected
+	status, err := api.NewPayloadV3(*envelope.ExecutionPayload, []common.Hash{}, &testBeaconRoot)
+	if err != nil {
+		t.Fatalf("error importing payload: %v", err)
+	}
+	if status.Status != engine.INVALID {
+		t.Fatalf("invalid status, have %v want %v", status.Status, engine.INVALID)
+	}
+	// The correct versioned hashes must be accepted
+	status, err = api.NewPayloadV3(*envelope.ExecutionPayload, hashes, &testBeaconRoot)
+	if err != nil {
+		t.Fatalf("error importing payload: %v", err)
+	}
+	if status.Status != engine.VALID {
+		t.Fatalf("invalid status, have %v want %v", status.Status, engine.VALID)
+	}
+}
+
 func TestEth2PrepareAndGetPayload(t *testing.T) {
 	genesis, blocks := generateChain(10)
 	n, zondservice := startZondService(t, genesis, blocks[:9])
@@ -163,13 +254,14 @@ func TestEth2PrepareAndGetPayload(t *testing.T) {
 	blockParams := engine.PayloadAttributes{
 		Timestamp:   blocks[8].Time() + 5,
 		Withdrawals: []*types.Withdrawal{},
+		BeaconRoot:  &testBeaconRoot,
 	}
 	fcState := engine.ForkchoiceStateV1{
 		HeadBlockHash:      blocks[8].Hash(),
 		SafeBlockHash:      common.Hash{},
 		FinalizedBlockHash: common.Hash{},
 	}
-	_, err := api.ForkchoiceUpdatedV2(fcState, &blockParams)
+	_, err := api.ForkchoiceUpdatedV3(fcState, &blockParams)
 	if err != nil {
 		t.Fatalf("error preparing payload, err=%v", err)
 	}
@@ -180,19 +272,28 @@ func TestEth2PrepareAndGetPayload(t *testing.T) {
 		Timestamp:    blockParams.Timestamp,
 		FeeRecipient: blockParams.SuggestedFeeRecipient,
 		Random:       blockParams.Random,
+		BeaconRoot:   blockParams.BeaconRoot,
 	}).Id()
-	execData, err := api.GetPayloadV2(payloadID)
+	execData, err := api.GetPayloadV3(payloadID)
 	if err != nil {
 		t.Fatalf("error getting payload, err=%v", err)
 	}
 	if len(execData.ExecutionPayload.Transactions) != blocks[9].Transactions().Len() {
 		t.Fatalf("invalid number of transactions %d != 1", len(execData.ExecutionPayload.Transactions))
 	}
+	if execData.BlobsBundle == nil {
+		t.Fatal("missing blobs bundle in cancun payload")
+	}
+	// Test retrieving the cancun payload with the pre-cancun method
+	_, err = api.GetPayloadV2(payloadID)
+	if apiErr, ok := err.(*engine.EngineAPIError); !ok || apiErr.ErrorCode() != engine.UnsupportedFork.ErrorCode() {
+		t.Fatalf("expected unsupported fork error, got %v", err)
+	}
 	// Test invalid payloadID
 	var invPayload engine.PayloadID
 	copy(invPayload[:], payloadID[:])
 	invPayload[0] = ^invPayload[0]
-	_, err = api.GetPayloadV2(invPayload)
+	_, err = api.GetPayloadV3(invPayload)
 	if err == nil {
 		t.Fatal("expected error retrieving invalid payload")
 	}
@@ -242,13 +343,14 @@ func TestInvalidPayloadTimestamp(t *testing.T) {
 				Random:                crypto.Keccak256Hash([]byte{byte(123)}),
 				SuggestedFeeRecipient: parent.Coinbase,
 				Withdrawals:           []*types.Withdrawal{},
+				BeaconRoot:            &testBeaconRoot,
 			}
 			fcState := engine.ForkchoiceStateV1{
 				HeadBlockHash:      parent.Hash(),
 				SafeBlockHash:      common.Hash{},
 				FinalizedBlockHash: common.Hash{},
 			}
-			_, err := api.ForkchoiceUpdatedV2(fcState, &params)
+			_, err := api.ForkchoiceUpdatedV3(fcState, &params)
 			if test.shouldErr && err == nil {
 				t.Fatalf("expected error preparing payload with invalid timestamp, err=%v", err)
 			} else if !test.shouldErr && err != nil {
@@ -291,16 +393,17 @@ func TestEth2NewBlock(t *testing.T) {
 		zondservice.TxPool().Add([]*types.Transaction{signedTx}, true, false)
 
 		execData, err := assembleWithTransactions(api, parent.Hash(), &engine.PayloadAttributes{
-			Timestamp: parent.Time() + 5,
+			Timestamp:  parent.Time() + 5,
+			BeaconRoot: &testBeaconRoot,
 		}, 1)
 		if err != nil {
 			t.Fatalf("Failed to create the executable data %v", err)
 		}
-		block, err := engine.ExecutableDataToBlock(*execData)
+		block, err := engine.ExecutableDataToBlock(*execData, nil, &testBeaconRoot)
 		if err != nil {
 			t.Fatalf("Failed to convert executable data to block %v", err)
 		}
-		newResp, err := api.NewPayloadV2(*execData)
+		newResp, err := api.NewPayloadV3(*execData, []common.Hash{}, &testBeaconRoot)
 		switch {
 		case err != nil:
 			t.Fatalf("Failed to insert block: %v", err)
@@ -315,7 +418,7 @@ func TestEth2NewBlock(t *testing.T) {
 			SafeBlockHash:      block.Hash(),
 			FinalizedBlockHash: block.Hash(),
 		}
-		if _, err := api.ForkchoiceUpdatedV2(fcState, nil); err != nil {
+		if _, err := api.ForkchoiceUpdatedV3(fcState, nil); err != nil {
 			t.Fatalf("Failed to insert block: %v", err)
 		}
 		if have, want := zondservice.BlockChain().CurrentBlock().Number.Uint64(), block.NumberU64(); have != want {
@@ -333,16 +436,17 @@ func TestEth2NewBlock(t *testing.T) {
 	parent = blocks[len(blocks)-1]
 	for i := 0; i < 10; i++ {
 		execData, err := assembleBlock(api, parent.Hash(), &engine.PayloadAttributes{
-			Timestamp: parent.Time() + 6,
+			Timestamp:  parent.Time() + 6,
+			BeaconRoot: &testBeaconRoot,
 		})
 		if err != nil {
 			t.Fatalf("Failed to create the executable data %v", err)
 		}
-		block, err := engine.ExecutableDataToBlock(*execData)
+		block, err := engine.ExecutableDataToBlock(*execData, nil, &testBeaconRoot)
 		if err != nil {
 			t.Fatalf("Failed to convert executable data to block %v", err)
 		}
-		newResp, err := api.NewPayloadV2(*execData)
+		newResp, err := api.NewPayloadV3(*execData, []common.Hash{}, &testBeaconRoot)
 		if err != nil || newResp.Status != "VALID" {
 			t.Fatalf("Failed to insert block: %v", err)
 		}
@@ -355,7 +459,7 @@ func TestEth2NewBlock(t *testing.T) {
 			SafeBlockHash:      block.Hash(),
 			FinalizedBlockHash: block.Hash(),
 		}
-		if _, err := api.ForkchoiceUpdatedV2(fcState, nil); err != nil {
+		if _, err := api.ForkchoiceUpdatedV3(fcState, nil); err != nil {
 			t.Fatalf("Failed to insert block: %v", err)
 		}
 		if zondservice.BlockChain().CurrentBlock().Number.Uint64() != block.NumberU64() {
@@ -482,7 +586,7 @@ func setupBlocks(t *testing.T, zondservice *zond.Zond, n int, parent *types.Head
 		}
 
 		payload := getNewPayload(t, api, parent, w)
-		execResp, err := api.NewPayloadV2(*payload)
+		execResp, err := api.NewPayloadV3(*payload, []common.Hash{}, &testBeaconRoot)
 		if err != nil {
 			t.Fatalf("can't execute payload: %v", err)
 		}
@@ -494,7 +598,7 @@ func setupBlocks(t *testing.T, zondservice *zond.Zond, n int, parent *types.Head
 			SafeBlockHash:      payload.ParentHash,
 			FinalizedBlockHash: payload.ParentHash,
 		}
-		if _, err := api.ForkchoiceUpdatedV2(fcState, nil); err != nil {
+		if _, err := api.ForkchoiceUpdatedV3(fcState, nil); err != nil {
 			t.Fatalf("Failed to insert block: %v", err)
 		}
 		if zondservice.BlockChain().CurrentBlock().Number.Uint64() != payload.Number {
@@ -555,6 +659,7 @@ func TestNewPayloadOnInvalidChain(t *testing.T) {
 				Random:                crypto.Keccak256Hash([]byte{byte(i)}),
 				SuggestedFeeRecipient: parent.Coinbase,
 				Withdrawals:           []*types.Withdrawal{},
+				BeaconRoot:            &testBeaconRoot,
 			}
 			fcState = engine.ForkchoiceStateV1{
 				HeadBlockHash:      parent.Hash(),
@@ -566,7 +671,7 @@ func TestNewPayloadOnInvalidChain(t *testing.T) {
 			err     error
 		)
 		for i := 0; ; i++ {
-			if resp, err = api.ForkchoiceUpdatedV2(fcState, &params); err != nil {
+			if resp, err = api.ForkchoiceUpdatedV3(fcState, &params); err != nil {
 				t.Fatalf("error preparing payload, err=%v", err)
 			}
 			if resp.PayloadStatus.Status != engine.VALID {
@@ -574,7 +679,7 @@ func TestNewPayloadOnInvalidChain(t *testing.T) {
 			}
 			// give the payload some time to be built
 			time.Sleep(50 * time.Millisecond)
-			if payload, err = api.GetPayloadV2(*resp.PayloadID); err != nil {
+			if payload, err = api.GetPayloadV3(*resp.PayloadID); err != nil {
 				t.Fatalf("can't get payload: %v", err)
 			}
 			if len(payload.ExecutionPayload.Transactions) > 0 {
@@ -586,7 +691,7 @@ func TestNewPayloadOnInvalidChain(t *testing.T) {
 				t.Fatalf("payload should not be empty")
 			}
 		}
-		execResp, err := api.NewPayloadV2(*payload.ExecutionPayload)
+		execResp, err := api.NewPayloadV3(*payload.ExecutionPayload, []common.Hash{}, &testBeaconRoot)
 		if err != nil {
 			t.Fatalf("can't execute payload: %v", err)
 		}
@@ -598,7 +703,7 @@ func TestNewPayloadOnInvalidChain(t *testing.T) {
 			SafeBlockHash:      payload.ExecutionPayload.ParentHash,
 			FinalizedBlockHash: payload.ExecutionPayload.ParentHash,
 		}
-		if _, err := api.ForkchoiceUpdatedV2(fcState, nil); err != nil {
+		if _, err := api.ForkchoiceUpdatedV3(fcState, nil); err != nil {
 			t.Fatalf("Failed to insert block: %v", err)
 		}
 		if zondservice.BlockChain().CurrentBlock().Number.Uint64() != payload.ExecutionPayload.Number {
@@ -615,6 +720,7 @@ func assembleBlock(api *ConsensusAPI, parentHash common.Hash, params *engine.Pay
 		FeeRecipient: params.SuggestedFeeRecipient,
 		Random:       params.Random,
 		Withdrawals:  params.Withdrawals,
+		BeaconRoot:   params.BeaconRoot,
 	}
 	payload, err := api.zond.Miner().BuildPayload(args)
 	if err != nil {
@@ -637,7 +743,7 @@ func TestEmptyBlocks(t *testing.T) {
 	// (1) check LatestValidHash by sending a normal payload (P1'')
 	payload := getNewPayload(t, api, commonAncestor, nil)
 
-	status, err := api.NewPayloadV2(*payload)
+	status, err := api.NewPayloadV3(*payload, []common.Hash{}, &testBeaconRoot)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -653,7 +759,7 @@ func TestEmptyBlocks(t *testing.T) {
 	payload.GasUsed += 1
 	payload = setBlockhash(payload)
 	// Now latestValidHash should be the common ancestor
-	status, err = api.NewPayloadV2(*payload)
+	status, err = api.NewPayloadV3(*payload, []common.Hash{}, &testBeaconRoot)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -671,7 +777,7 @@ func TestEmptyBlocks(t *testing.T) {
 	payload.ParentHash = common.Hash{1}
 	payload = setBlockhash(payload)
 	// Now latestValidHash should be the common ancestor
-	status, err = api.NewPayloadV2(*payload)
+	status, err = api.NewPayloadV3(*payload, []common.Hash{}, &testBeaconRoot)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -689,6 +795,7 @@ func getNewPayload(t *testing.T, api *ConsensusAPI, parent *types.Header, withdr
 		Random:                crypto.Keccak256Hash([]byte{byte(1)}),
 		SuggestedFeeRecipient: parent.Coinbase,
 		Withdrawals:           withdrawals,
+		BeaconRoot:            &testBeaconRoot,
 	}
 
 	payload, err := assembleBlock(api, parent.Hash(), &params)
@@ -705,20 +812,23 @@ func setBlockhash(data *engine.ExecutableData) *engine.ExecutableData {
 	number := big.NewInt(0)
 	number.SetUint64(data.Number)
 	header := &types.Header{
-		ParentHash:      data.ParentHash,
-		Coinbase:        data.FeeRecipient,
-		Root:            data.StateRoot,
-		TxHash:          types.DeriveSha(types.Transactions(txs), trie.NewStackTrie(nil)),
-		ReceiptHash:     data.ReceiptsRoot,
-		Bloom:           types.BytesToBloom(data.LogsBloom),
-		Number:          number,
-		GasLimit:        data.GasLimit,
-		GasUsed:         data.GasUsed,
-		Time:            data.Timestamp,
-		BaseFee:         data.BaseFeePerGas,
-		Extra:           data.ExtraData,
-		Random:          data.Random,
-		WithdrawalsHash: &types.EmptyWithdrawalsHash,
+		ParentHash:       data.ParentHash,
+		Coinbase:         data.FeeRecipient,
+		Root:             data.StateRoot,
+		TxHash:           types.DeriveSha(types.Transactions(txs), trie.NewStackTrie(nil)),
+		ReceiptHash:      data.ReceiptsRoot,
+		Bloom:            types.BytesToBloom(data.LogsBloom),
+		Number:           number,
+		GasLimit:         data.GasLimit,
+		GasUsed:          data.GasUsed,
+		Time:             data.Timestamp,
+		BaseFee:          data.BaseFeePerGas,
+		Extra:            data.ExtraData,
+		Random:           data.Random,
+		WithdrawalsHash:  &types.EmptyWithdrawalsHash,
+		ExcessBlobGas:    data.ExcessBlobGas,
+		BlobGasUsed:      data.BlobGasUsed,
+		ParentBeaconRoot: &testBeaconRoot,
 	}
 	block := types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs})
 	data.BlockHash = block.Hash()
@@ -782,7 +892,7 @@ func TestTrickRemoteBlockCache(t *testing.T) {
 
 	// feed the payloads to node B
 	for _, payload := range invalidChain {
-		status, err := apiB.NewPayloadV2(*payload)
+		status, err := apiB.NewPayloadV3(*payload, []common.Hash{}, &testBeaconRoot)
 		if err != nil {
 			panic(err)
 		}
@@ -790,7 +900,7 @@ func TestTrickRemoteBlockCache(t *testing.T) {
 			t.Error("invalid status: VALID on an invalid chain")
 		}
 		// Now reorg to the head of the invalid chain
-		resp, err := apiB.ForkchoiceUpdatedV2(engine.ForkchoiceStateV1{HeadBlockHash: payload.BlockHash, SafeBlockHash: payload.BlockHash, FinalizedBlockHash: payload.ParentHash}, nil)
+		resp, err := apiB.ForkchoiceUpdatedV3(engine.ForkchoiceStateV1{HeadBlockHash: payload.BlockHash, SafeBlockHash: payload.BlockHash, FinalizedBlockHash: payload.ParentHash}, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -815,7 +925,7 @@ func TestInvalidBloom(t *testing.T) {
 	// (1) check LatestValidHash by sending a normal payload (P1'')
 	payload := getNewPayload(t, api, commonAncestor, nil)
 	payload.LogsBloom = append(payload.LogsBloom, byte(1))
-	status, err := api.NewPayloadV2(*payload)
+	status, err := api.NewPayloadV3(*payload, []common.Hash{}, &testBeaconRoot)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -838,7 +948,8 @@ func TestSimultaneousNewBlock(t *testing.T) {
 	)
 	for i := 0; i < 10; i++ {
 		execData, err := assembleBlock(api, parent.Hash(), &engine.PayloadAttributes{
-			Timestamp: parent.Time() + 5,
+			Timestamp:  parent.Time() + 5,
+			BeaconRoot: &testBeaconRoot,
 		})
 		if err != nil {
 			t.Fatalf("Failed to create the executable data %v", err)
@@ -854,7 +965,7 @@ func TestSimultaneousNewBlock(t *testing.T) {
 			for ii := 0; ii < 10; ii++ {
 				go func() {
 					defer wg.Done()
-					if newResp, err := api.NewPayloadV2(*execData); err != nil {
+					if newResp, err := api.NewPayloadV3(*execData, []common.Hash{}, &testBeaconRoot); err != nil {
 						errMu.Lock()
 						testErr = fmt.Errorf("failed to insert block: %w", err)
 						errMu.Unlock()
@@ -870,7 +981,7 @@ func TestSimultaneousNewBlock(t *testing.T) {
 				t.Fatal(testErr)
 			}
 		}
-		block, err := engine.ExecutableDataToBlock(*execData)
+		block, err := engine.ExecutableDataToBlock(*execData, nil, &testBeaconRoot)
 		if err != nil {
 			t.Fatalf("Failed to convert executable data to block %v", err)
 		}
@@ -893,7 +1004,7 @@ func TestSimultaneousNewBlock(t *testing.T) {
 			for ii := 0; ii < 10; ii++ {
 				go func() {
 					defer wg.Done()
-					if _, err := api.ForkchoiceUpdatedV2(fcState, nil); err != nil {
+					if _, err := api.ForkchoiceUpdatedV3(fcState, nil); err != nil {
 						errMu.Lock()
 						testErr = fmt.Errorf("failed to insert block: %w", err)
 						errMu.Unlock()
@@ -927,11 +1038,12 @@ func TestWithdrawals(t *testing.T) {
 	blockParams := engine.PayloadAttributes{
 		Timestamp:   parent.Time + 5,
 		Withdrawals: make([]*types.Withdrawal, 0),
+		BeaconRoot:  &testBeaconRoot,
 	}
 	fcState := engine.ForkchoiceStateV1{
 		HeadBlockHash: parent.Hash(),
 	}
-	resp, err := api.ForkchoiceUpdatedV2(fcState, &blockParams)
+	resp, err := api.ForkchoiceUpdatedV3(fcState, &blockParams)
 	if err != nil {
 		t.Fatalf("error preparing payload, err=%v", err)
 	}
@@ -946,8 +1058,9 @@ func TestWithdrawals(t *testing.T) {
 		FeeRecipient: blockParams.SuggestedFeeRecipient,
 		Random:       blockParams.Random,
 		Withdrawals:  blockParams.Withdrawals,
+		BeaconRoot:   blockParams.BeaconRoot,
 	}).Id()
-	execData, err := api.GetPayloadV2(payloadID)
+	execData, err := api.GetPayloadV3(payloadID)
 	if err != nil {
 		t.Fatalf("error getting payload, err=%v", err)
 	}
@@ -956,7 +1069,7 @@ func TestWithdrawals(t *testing.T) {
 	}
 
 	// 10: verify locally built block
-	if status, err := api.NewPayloadV2(*execData.ExecutionPayload); err != nil {
+	if status, err := api.NewPayloadV3(*execData.ExecutionPayload, []common.Hash{}, &testBeaconRoot); err != nil {
 		t.Fatalf("error validating payload: %v", err)
 	} else if status.Status != engine.VALID {
 		t.Fatalf("invalid payload")
@@ -979,9 +1092,10 @@ func TestWithdrawals(t *testing.T) {
 				Amount:  33,
 			},
 		},
+		BeaconRoot: &testBeaconRoot,
 	}
 	fcState.HeadBlockHash = execData.ExecutionPayload.BlockHash
-	_, err = api.ForkchoiceUpdatedV2(fcState, &blockParams)
+	_, err = api.ForkchoiceUpdatedV3(fcState, &blockParams)
 	if err != nil {
 		t.Fatalf("error preparing payload, err=%v", err)
 	}
@@ -993,12 +1107,13 @@ func TestWithdrawals(t *testing.T) {
 		FeeRecipient: blockParams.SuggestedFeeRecipient,
 		Random:       blockParams.Random,
 		Withdrawals:  blockParams.Withdrawals,
+		BeaconRoot:   blockParams.BeaconRoot,
 	}).Id()
-	execData, err = api.GetPayloadV2(payloadID)
+	execData, err = api.GetPayloadV3(payloadID)
 	if err != nil {
 		t.Fatalf("error getting payload, err=%v", err)
 	}
-	if status, err := api.NewPayloadV2(*execData.ExecutionPayload); err != nil {
+	if status, err := api.NewPayloadV3(*execData.ExecutionPayload, []common.Hash{}, &testBeaconRoot); err != nil {
 		t.Fatalf("error validating payload: %v", err)
 	} else if status.Status != engine.VALID {
 		t.Fatalf("invalid payload")
@@ -1006,7 +1121,7 @@ func TestWithdrawals(t *testing.T) {
 
 	// 11: set block as head.
 	fcState.HeadBlockHash = execData.ExecutionPayload.BlockHash
-	_, err = api.ForkchoiceUpdatedV2(fcState, nil)
+	_, err = api.ForkchoiceUpdatedV3(fcState, nil)
 	if err != nil {
 		t.Fatalf("error preparing payload, err=%v", err)
 	}
@@ -1043,6 +1158,7 @@ func TestNilWithdrawals(t *testing.T) {
 			blockParams: engine.PayloadAttributes{
 				Timestamp:   parent.Time + 5,
 				Withdrawals: nil,
+				BeaconRoot:  &testBeaconRoot,
 			},
 			wantErr: true,
 		},
@@ -1050,6 +1166,7 @@ func TestNilWithdrawals(t *testing.T) {
 			blockParams: engine.PayloadAttributes{
 				Timestamp:   parent.Time + 5,
 				Withdrawals: make([]*types.Withdrawal, 0),
+				BeaconRoot:  &testBeaconRoot,
 			},
 			wantErr: false,
 		},
@@ -1063,6 +1180,7 @@ func TestNilWithdrawals(t *testing.T) {
 						Amount:  32,
 					},
 				},
+				BeaconRoot: &testBeaconRoot,
 			},
 			wantErr: false,
 		},
@@ -1073,7 +1191,7 @@ func TestNilWithdrawals(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err := api.ForkchoiceUpdatedV2(fcState, &test.blockParams)
+		_, err := api.ForkchoiceUpdatedV3(fcState, &test.blockParams)
 		if test.wantErr {
 			if err == nil {
 				t.Fatal("wanted error on fcuv2 with invalid withdrawals")
@@ -1090,12 +1208,13 @@ func TestNilWithdrawals(t *testing.T) {
 			Timestamp:    test.blockParams.Timestamp,
 			FeeRecipient: test.blockParams.SuggestedFeeRecipient,
 			Random:       test.blockParams.Random,
+			BeaconRoot:   test.blockParams.BeaconRoot,
 		}).Id()
-		execData, err := api.GetPayloadV2(payloadID)
+		execData, err := api.GetPayloadV3(payloadID)
 		if err != nil {
 			t.Fatalf("error getting payload, err=%v", err)
 		}
-		if status, err := api.NewPayloadV2(*execData.ExecutionPayload); err != nil {
+		if status, err := api.NewPayloadV3(*execData.ExecutionPayload, []common.Hash{}, &testBeaconRoot); err != nil {
 			t.Fatalf("error validating payload: %v", err.(*engine.EngineAPIError).ErrorData())
 		} else if status.Status != engine.VALID {
 			t.Fatalf("invalid payload")
@@ -18,6 +18,7 @@ package catalyst
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"errors"
 	"math/big"
 	"sync"
@@ -27,6 +28,7 @@ import (
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/core/txpool"
 	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto/blobcommit"
 	"github.com/theQRL/go-zond/log"
 	"github.com/theQRL/go-zond/node"
 	"github.com/theQRL/go-zond/params"
@@ -156,12 +158,19 @@ func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp u
 
 	var random [32]byte
 	rand.Read(random[:])
-	fcResponse, err := c.engineAPI.ForkchoiceUpdatedV2(c.curForkchoiceState, &engine.PayloadAttributes{
+	attributes := &engine.PayloadAttributes{
 		Timestamp:             timestamp,
 		SuggestedFeeRecipient: feeRecipient,
 		Withdrawals:           withdrawals,
 		Random:                random,
-	})
+	}
+	// There is no beacon chain behind the simulator, use the empty beacon root
+	forkchoiceUpdated := c.engineAPI.ForkchoiceUpdatedV2
+	if c.zond.BlockChain().Config().IsCancun(nil, timestamp) {
+		attributes.BeaconRoot = new(common.Hash)
+		forkchoiceUpdated = c.engineAPI.ForkchoiceUpdatedV3
+	}
+	fcResponse, err := forkchoiceUpdated(c.curForkchoiceState, attributes)
 	if err != nil {
 		return err
 	}
@@ -186,7 +195,24 @@ func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp u
 	}
 
 	// Mark the payload as canon
-	if _, err = c.engineAPI.NewPayloadV2(*payload); err != nil {
+	if c.zond.BlockChain().Config().IsCancun(new(big.Int).SetUint64(payload.Number), payload.Timestamp) {
+		// Independently calculate the blob hashes from the bundled commitments
+		blobHashes := make([]common.Hash, 0)
+		if envelope.BlobsBundle != nil {
+			hasher := sha256.New()
+			for _, commit := range envelope.BlobsBundle.Commitments {
+				var commitment blobcommit.Commitment
+				if len(commit) != len(commitment) {
+					return errors.New("invalid commitment length")
+				}
+				copy(commitment[:], commit)
+				blobHashes = append(blobHashes, blobcommit.CalcBlobHashV1(hasher, &commitment))
+			}
+		}
+		if _, err = c.engineAPI.NewPayloadV3(*payload, blobHashes, attributes.BeaconRoot); err != nil {
+			return err
+		}
+	} else if _, err = c.engineAPI.NewPayloadV2(*payload); err != nil {
 		return err
 	}
 	c.setCurrentState(payload.BlockHash, finalizedHash)
@@ -298,26 +298,26 @@ func TestFilters(t *testing.T) {
 	}{
 		{
 			f:    sys.NewBlockFilter(chain[2].Hash(), []common.Address{contract}, nil),
-			want: `[{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696332","0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x3","transactionHash":"0x98d3df047a661b1186be5d1f7ae2a521fbca8ca718cd231bf65e06d09d15e98e","transactionIndex":"0x0","blockHash":"0xae2282865aec2aba624fbd1e968a22205c7d70e8feeca606035ac49865c83742","logIndex":"0x0","removed":false}]`,
+			want: `[{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696332","0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x3","transactionHash":"0x98d3df047a661b1186be5d1f7ae2a521fbca8ca718cd231bf65e06d09d15e98e","transactionIndex":"0x0","blockHash":"0xbe7e2abd2f5614af6b091b5698b68ac7a2f914fdecabb584595b558e7ec070df","logIndex":"0x0","removed":false}]`,
 		},
 		{
 			f:    sys.NewRangeFilter(0, int64(rpc.LatestBlockNumber), []common.Address{contract}, [][]common.Hash{{hash1, hash2, hash3, hash4}}),
-			want: `[{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x2","transactionHash":"0x5c2bb52a0eb61322131687e85eb3498b430274a69428d7bf4bdedfea8529ce9d","transactionIndex":"0x0","blockHash":"0x2272dec00c13cdd8a26b64423643d2d556bb95d2297f9d0c56ba3a682c9e1b3b","logIndex":"0x0","removed":false},{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696332","0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x3","transactionHash":"0x98d3df047a661b1186be5d1f7ae2a521fbca8ca718cd231bf65e06d09d15e98e","transactionIndex":"0x0","blockHash":"0xae2282865aec2aba624fbd1e968a22205c7d70e8feeca606035ac49865c83742","logIndex":"0x0","removed":false},{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696334"],"data":"0x","blockNumber":"0x3e8","transactionHash":"0x298c5fda8e7c96446e298046378764104b07256380b5bec31e6afe6b5a600c9a","transactionIndex":"0x0","blockHash":"0xf59c869d2d6f3e9bfeaad15df443238beca6394588e7ca5b17cf158a25966844","logIndex":"0x0","removed":false}]`,
+			want: `[{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x2","transactionHash":"0x5c2bb52a0eb61322131687e85eb3498b430274a69428d7bf4bdedfea8529ce9d","transactionIndex":"0x0","blockHash":"0x207292d635afb5a1cb33923d3831d3a3fe1f926d9acc9bfd5fcbef1938d6762a","logIndex":"0x0","removed":false},{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696332","0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x3","transactionHash":"0x98d3df047a661b1186be5d1f7ae2a521fbca8ca718cd231bf65e06d09d15e98e","transactionIndex":"0x0","blockHash":"0xbe7e2abd2f5614af6b091b5698b68ac7a2f914fdecabb584595b558e7ec070df","logIndex":"0x0","removed":false},{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696334"],"data":"0x","blockNumber":"0x3e8","transactionHash":"0x298c5fda8e7c96446e298046378764104b07256380b5bec31e6afe6b5a600c9a","transactionIndex":"0x0","blockHash":"0x574dd7671c3669e30ad875fcf8fa798788b560c95e152b12d68b09259ac23e54","logIndex":"0x0","removed":false}]`,
 		},
 		{
 			f: sys.NewRangeFilter(900, 999, []common.Address{contract}, [][]common.Hash{{hash3}}),
 		},
 		{
 			f:    sys.NewRangeFilter(990, int64(rpc.LatestBlockNumber), []common.Address{contract2}, [][]common.Hash{{hash3}}),
-			want: `[{"address":"Zff00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696333"],"data":"0x","blockNumber":"0x3e7","transactionHash":"0xabd8d45a3d8a4905b0fd1a2cd7a584ed163273f1420e4a6a9a7c0003b04b0bd3","transactionIndex":"0x0","blockHash":"0xd4eacb8f32ea38ec1207219b163bb42a4bd6eabe83d91a37b49870db639b8333","logIndex":"0x0","removed":false}]`,
+			want: `[{"address":"Zff00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696333"],"data":"0x","blockNumber":"0x3e7","transactionHash":"0xabd8d45a3d8a4905b0fd1a2cd7a584ed163273f1420e4a6a9a7c0003b04b0bd3","transactionIndex":"0x0","blockHash":"0xf21a888a937149e9bc3d80dd75809c3910005ce5599b1b3d5f701c6108eb3a91","logIndex":"0x0","removed":false}]`,
 		},
 		{
 			f:    sys.NewRangeFilter(1, 10, []common.Address{contract}, [][]common.Hash{{hash2}, {hash1}}),
-			want: `[{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696332","0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x3","transactionHash":"0x98d3df047a661b1186be5d1f7ae2a521fbca8ca718cd231bf65e06d09d15e98e","transactionIndex":"0x0","blockHash":"0xae2282865aec2aba624fbd1e968a22205c7d70e8feeca606035ac49865c83742","logIndex":"0x0","removed":false}]`,
+			want: `[{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696332","0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x3","transactionHash":"0x98d3df047a661b1186be5d1f7ae2a521fbca8ca718cd231bf65e06d09d15e98e","transactionIndex":"0x0","blockHash":"0xbe7e2abd2f5614af6b091b5698b68ac7a2f914fdecabb584595b558e7ec070df","logIndex":"0x0","removed":false}]`,
 		},
 		{
 			f:    sys.NewRangeFilter(1, 10, nil, [][]common.Hash{{hash1, hash2}}),
-			want: `[{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x2","transactionHash":"0x5c2bb52a0eb61322131687e85eb3498b430274a69428d7bf4bdedfea8529ce9d","transactionIndex":"0x0","blockHash":"0x2272dec00c13cdd8a26b64423643d2d556bb95d2297f9d0c56ba3a682c9e1b3b","logIndex":"0x0","removed":false},{"address":"Zff00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x2","transactionHash":"0x7f365dd50796d1941a506c40d1cd98fa01253eafb93104e991b061362236f4f5","transactionIndex":"0x1","blockHash":"0x2272dec00c13cdd8a26b64423643d2d556bb95d2297f9d0c56ba3a682c9e1b3b","logIndex":"0x1","removed":false},{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696332","0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x3","transactionHash":"0x98d3df047a661b1186be5d1f7ae2a521fbca8ca718cd231bf65e06d09d15e98e","transactionIndex":"0x0","blockHash":"0xae2282865aec2aba624fbd1e968a22205c7d70e8feeca606035ac49865c83742","logIndex":"0x0","removed":false}]`,
+			want: `[{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x2","transactionHash":"0x5c2bb52a0eb61322131687e85eb3498b430274a69428d7bf4bdedfea8529ce9d","transactionIndex":"0x0","blockHash":"0x207292d635afb5a1cb33923d3831d3a3fe1f926d9acc9bfd5fcbef1938d6762a","logIndex":"0x0","removed":false},{"address":"Zff00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x2","transactionHash":"0x7f365dd50796d1941a506c40d1cd98fa01253eafb93104e991b061362236f4f5","transactionIndex":"0x1","blockHash":"0x207292d635afb5a1cb33923d3831d3a3fe1f926d9acc9bfd5fcbef1938d6762a","logIndex":"0x1","removed":false},{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696332","0x0000000000000000000000000000000000000000000000000000746f70696331"],"data":"0x","blockNumber":"0x3","transactionHash":"0x98d3df047a661b1186be5d1f7ae2a521fbca8ca718cd231bf65e06d09d15e98e","transactionIndex":"0x0","blockHash":"0xbe7e2abd2f5614af6b091b5698b68ac7a2f914fdecabb584595b558e7ec070df","logIndex":"0x0","removed":false}]`,
 		},
 		{
 			f: sys.NewRangeFilter(0, int64(rpc.LatestBlockNumber), nil, [][]common.Hash{{common.BytesToHash([]byte("fail"))}}),
@@ -330,15 +330,15 @@ func TestFilters(t *testing.T) {
 		},
 		{
 			f:    sys.NewRangeFilter(int64(rpc.LatestBlockNumber), int64(rpc.LatestBlockNumber), nil, nil),
-			want: `[{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696334"],"data":"0x","blockNumber":"0x3e8","transactionHash":"0x298c5fda8e7c96446e298046378764104b07256380b5bec31e6afe6b5a600c9a","transactionIndex":"0x0","blockHash":"0xf59c869d2d6f3e9bfeaad15df443238beca6394588e7ca5b17cf158a25966844","logIndex":"0x0","removed":false}]`,
+			want: `[{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696334"],"data":"0x","blockNumber":"0x3e8","transactionHash":"0x298c5fda8e7c96446e298046378764104b07256380b5bec31e6afe6b5a600c9a","transactionIndex":"0x0","blockHash":"0x574dd7671c3669e30ad875fcf8fa798788b560c95e152b12d68b09259ac23e54","logIndex":"0x0","removed":false}]`,
 		},
 		{
 			f:    sys.NewRangeFilter(int64(rpc.FinalizedBlockNumber), int64(rpc.LatestBlockNumber), nil, nil),
-			want: `[{"address":"Zff00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696333"],"data":"0x","blockNumber":"0x3e7","transactionHash":"0xabd8d45a3d8a4905b0fd1a2cd7a584ed163273f1420e4a6a9a7c0003b04b0bd3","transactionIndex":"0x0","blockHash":"0xd4eacb8f32ea38ec1207219b163bb42a4bd6eabe83d91a37b49870db639b8333","logIndex":"0x0","removed":false},{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696334"],"data":"0x","blockNumber":"0x3e8","transactionHash":"0x298c5fda8e7c96446e298046378764104b07256380b5bec31e6afe6b5a600c9a","transactionIndex":"0x0","blockHash":"0xf59c869d2d6f3e9bfeaad15df443238beca6394588e7ca5b17cf158a25966844","logIndex":"0x0","removed":false}]`,
+			want: `[{"address":"Zff00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696333"],"data":"0x","blockNumber":"0x3e7","transactionHash":"0xabd8d45a3d8a4905b0fd1a2cd7a584ed163273f1420e4a6a9a7c0003b04b0bd3","transactionIndex":"0x0","blockHash":"0xf21a888a937149e9bc3d80dd75809c3910005ce5599b1b3d5f701c6108eb3a91","logIndex":"0x0","removed":false},{"address":"Zfe00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696334"],"data":"0x","blockNumber":"0x3e8","transactionHash":"0x298c5fda8e7c96446e298046378764104b07256380b5bec31e6afe6b5a600c9a","transactionIndex":"0x0","blockHash":"0x574dd7671c3669e30ad875fcf8fa798788b560c95e152b12d68b09259ac23e54","logIndex":"0x0","removed":false}]`,
 		},
 		{
 			f:    sys.NewRangeFilter(int64(rpc.FinalizedBlockNumber), int64(rpc.FinalizedBlockNumber), nil, nil),
-			want: `[{"address":"Zff00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696333"],"data":"0x","blockNumber":"0x3e7","transactionHash":"0xabd8d45a3d8a4905b0fd1a2cd7a584ed163273f1420e4a6a9a7c0003b04b0bd3","transactionIndex":"0x0","blockHash":"0xd4eacb8f32ea38ec1207219b163bb42a4bd6eabe83d91a37b49870db639b8333","logIndex":"0x0","removed":false}]`,
+			want: `[{"address":"Zff00000000000000000000000000000000000000","topics":["0x0000000000000000000000000000000000000000000000000000746f70696333"],"data":"0x","blockNumber":"0x3e7","transactionHash":"0xabd8d45a3d8a4905b0fd1a2cd7a584ed163273f1420e4a6a9a7c0003b04b0bd3","transactionIndex":"0x0","blockHash":"0xf21a888a937149e9bc3d80dd75809c3910005ce5599b1b3d5f701c6108eb3a91","logIndex":"0x0","removed":false}]`,
 		},
 		{
 			f: sys.NewRangeFilter(int64(rpc.LatestBlockNumber), int64(rpc.FinalizedBlockNumber), nil, nil),
@@ -0,0 +1,162 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package zond
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/internal/era"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/zonddb"
+)
+
+// historyCheckInterval is the time interval between the checks for chain
+// history to expire.
+const historyCheckInterval = time.Minute
+
+// historyExpirer exports the bodies and receipts of the blocks older than the
+// history horizon into era files and prunes them from the ancient store. The
+// history is expired in whole epochs, once frozen.
+type historyExpirer struct {
+	chain   *core.BlockChain
+	db      zonddb.Database
+	dir     string // Directory the era files are exported into
+	network string // Network name used in the era filenames
+	horizon uint64 // Number of recent blocks whose history is retained
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newHistoryExpirer creates a history expirer for the given chain.
+func newHistoryExpirer(chain *core.BlockChain, db zonddb.Database, dir string, horizon uint64) *historyExpirer {
+	return &historyExpirer{
+		chain:   chain,
+		db:      db,
+		dir:     dir,
+		network: era.NetworkName(chain.Config().ChainID),
+		horizon: horizon,
+		quit:    make(chan struct{}),
+	}
+}
+
+// start launches the background expiration loop.
+func (h *historyExpirer) start() {
+	h.wg.Add(1)
+	go h.loop()
+}
+
+// stop terminates the expiration loop and waits for it to finish.
+func (h *historyExpirer) stop() {
+	close(h.quit)
+	h.wg.Wait()
+}
+
+// loop periodically expires the chain history fallen behind the horizon.
+func (h *historyExpirer) loop() {
+	defer h.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if err := h.expire(); err != nil {
+				log.Error("Failed to expire chain history", "err", err)
+			}
+			timer.Reset(historyCheckInterval)
+		case <-h.quit:
+			return
+		}
+	}
+}
+
+// expire exports and prunes all the complete epochs of frozen blocks which are
+// older than the horizon.
+func (h *historyExpirer) expire() error {
+	head := h.chain.CurrentBlock().Number.Uint64()
+	if head < h.horizon {
+		return nil
+	}
+	frozen, err := h.db.Ancients()
+	if err != nil {
+		return err
+	}
+	tail, err := h.db.Tail()
+	if err != nil {
+		return err
+	}
+	cutoff := min(head-h.horizon, frozen) / era.MaxEraBatchSize * era.MaxEraBatchSize
+	for tail < cutoff {
+		select {
+		case <-h.quit:
+			return nil
+		default:
+		}
+		var (
+			start = time.Now()
+			epoch = tail / era.MaxEraBatchSize
+			next  = (epoch + 1) * era.MaxEraBatchSize
+		)
+		if err := h.export(epoch); err != nil {
+			return err
+		}
+		if _, err := h.db.TruncateTail(next); err != nil {
+			return err
+		}
+		log.Info("Expired chain history", "epoch", epoch, "tail", next, "elapsed", common.PrettyDuration(time.Since(start)))
+		tail = next
+	}
+	return nil
+}
+
+// export writes the era file of the given epoch, unless a complete one is
+// already present. Any partial era file of the epoch is replaced.
+func (h *historyExpirer) export(epoch uint64) error {
+	files, err := filepath.Glob(filepath.Join(h.dir, fmt.Sprintf("%s-%05d-*.era", h.network, epoch)))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		e, err := era.Open(file)
+		if err == nil {
+			count := e.Count()
+			e.Close()
+			if count == era.MaxEraBatchSize {
+				return nil
+			}
+		}
+	}
+	path, err := era.Export(h.chain, h.dir, h.network, epoch, (epoch+1)*era.MaxEraBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file != path {
+			os.Remove(file)
+		}
+	}
+	log.Info("Exported chain history", "epoch", epoch, "path", path)
+	return nil
+}
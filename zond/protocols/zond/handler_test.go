@@ -84,9 +84,8 @@ func newTestBackendWithGenerator(blocks int, generator func(int, *core.BlockGen)
 		chain.TrieDB().Commit(block.Root(), false)
 	}
 	txconfig := legacypool.DefaultConfig
-	txconfig.Journal = "" // Don't litter the disk with test journals
 
-	pool := legacypool.New(txconfig, chain)
+	pool := legacypool.New(txconfig, chain, nil)
 	txpool, _ := txpool.New(new(big.Int).SetUint64(txconfig.PriceLimit), chain, []txpool.SubPool{pool})
 
 	return &testBackend{
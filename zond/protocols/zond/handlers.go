@@ -414,6 +414,10 @@ func handleTransactions(backend Backend, msg Decoder, peer *Peer) error {
 		if tx == nil {
 			return fmt.Errorf("%w: transaction %d is nil", errDecode, i)
 		}
+		// Blob transactions are only ever announced, never broadcast directly
+		if tx.Type() == types.BlobTxType {
+			return fmt.Errorf("%w: transaction %d is a broadcast blob transaction", errDecode, i)
+		}
 		peer.markTransaction(tx.Hash())
 	}
 	return backend.Handle(peer, &txs)
@@ -180,10 +180,12 @@ func (zond *Zond) pathState(block *types.Block) (*state.StateDB, func(), error)
 	if err == nil {
 		return statedb, noopReleaser, nil
 	}
-	// TODO historic state is not supported in path-based scheme.
-	// Fully archive node in pbss will be implemented by relying
-	// on state history, but needs more work on top.
-	return nil, nil, errors.New("historical state not available in path scheme yet")
+	// Otherwise rebuild the historical state from the state histories if
+	// they are indexed and not yet pruned.
+	if statedb, err = zond.blockchain.HistoricState(block.Root()); err == nil {
+		return statedb, noopReleaser, nil
+	}
+	return nil, nil, fmt.Errorf("historical state not available in path scheme: %w", err)
 }
 
 // stateAtBlock retrieves the state database associated with a certain block.
@@ -235,8 +237,19 @@ func (zond *Zond) stateAtTransaction(ctx context.Context, block *types.Block, tx
 	if txIndex == 0 && len(block.Transactions()) == 0 {
 		return nil, vm.BlockContext{}, statedb, release, nil
 	}
+	// Insert parent beacon block root and parent block hash in the state
+	// before any transactions.
+	context := core.NewZVMBlockContext(block.Header(), zond.blockchain, nil)
+	vmenv := vm.NewZVM(context, vm.TxContext{}, statedb, zond.blockchain.Config(), vm.Config{})
+	core.InstallSystemContracts(zond.blockchain.Config(), parent.Header(), block.Header(), statedb)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
+	if zond.blockchain.Config().IsPrague(block.Number(), block.Time()) {
+		core.ProcessParentBlockHash(block.ParentHash(), vmenv, statedb)
+	}
 	// Recompute transactions up to the target index.
-	signer := types.MakeSigner(zond.blockchain.Config())
+	signer := types.MakeSigner(zond.blockchain.Config(), block.Number(), block.Time())
 	for idx, tx := range block.Transactions() {
 		// Assemble the transaction call message and return if the requested offset
 		msg, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
@@ -181,6 +181,7 @@ type txTraceResult struct {
 // being traced.
 type blockTraceTask struct {
 	statedb *state.StateDB   // Intermediate state prepped for tracing
+	parent  *types.Header    // Parent header of the block to trace
 	block   *types.Block     // Block to trace the transactions from
 	release StateReleaseFunc // The function to release the held resource for this task
 	results []*txTraceResult // Trace results produced by the task
@@ -261,9 +262,20 @@ func (api *API) traceChain(start, end *types.Block, config *TraceConfig, closed
 			// Fetch and execute the block trace taskCh
 			for task := range taskCh {
 				var (
-					signer   = types.MakeSigner(api.backend.ChainConfig())
+					signer   = types.MakeSigner(api.backend.ChainConfig(), task.block.Number(), task.block.Time())
 					blockCtx = core.NewZVMBlockContext(task.block.Header(), api.chainContext(ctx), nil)
 				)
+				// Insert block's parent beacon block root in the state
+				core.InstallSystemContracts(api.backend.ChainConfig(), task.parent, task.block.Header(), task.statedb)
+				if beaconRoot := task.block.BeaconRoot(); beaconRoot != nil {
+					vmenv := vm.NewZVM(blockCtx, vm.TxContext{}, task.statedb, api.backend.ChainConfig(), vm.Config{})
+					core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, task.statedb)
+				}
+				// Insert block's parent hash in the history storage contract
+				if api.backend.ChainConfig().IsPrague(task.block.Number(), task.block.Time()) {
+					vmenv := vm.NewZVM(blockCtx, vm.TxContext{}, task.statedb, api.backend.ChainConfig(), vm.Config{})
+					core.ProcessParentBlockHash(task.block.ParentHash(), vmenv, task.statedb)
+				}
 				// Trace all the transactions contained within
 				for i, tx := range task.block.Transactions() {
 					msg, _ := core.TransactionToMessage(tx, signer, task.block.BaseFee())
@@ -382,7 +394,7 @@ func (api *API) traceChain(start, end *types.Block, config *TraceConfig, closed
 			// Send the block over to the concurrent tracers (if not in the fast-forward phase)
 			txs := next.Transactions()
 			select {
-			case taskCh <- &blockTraceTask{statedb: statedb.Copy(), block: next, release: release, results: make([]*txTraceResult, len(txs))}:
+			case taskCh <- &blockTraceTask{statedb: statedb.Copy(), parent: block.Header(), block: next, release: release, results: make([]*txTraceResult, len(txs))}:
 			case <-closed:
 				tracker.releaseState(number, release)
 				return
@@ -517,11 +529,20 @@ func (api *API) IntermediateRoots(ctx context.Context, hash common.Hash, config
 
 	var (
 		roots              []common.Hash
-		signer             = types.MakeSigner(api.backend.ChainConfig())
+		signer             = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		chainConfig        = api.backend.ChainConfig()
 		vmctx              = core.NewZVMBlockContext(block.Header(), api.chainContext(ctx), nil)
 		deleteEmptyObjects = true
 	)
+	core.InstallSystemContracts(chainConfig, parent.Header(), block.Header(), statedb)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		vmenv := vm.NewZVM(vmctx, vm.TxContext{}, statedb, chainConfig, vm.Config{})
+		core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
+	if chainConfig.IsPrague(block.Number(), block.Time()) {
+		vmenv := vm.NewZVM(vmctx, vm.TxContext{}, statedb, chainConfig, vm.Config{})
+		core.ProcessParentBlockHash(block.ParentHash(), vmenv, statedb)
+	}
 	for i, tx := range block.Transactions() {
 		if err := ctx.Err(); err != nil {
 			return nil, err
@@ -582,6 +603,16 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 	}
 	defer release()
 
+	blockCtx := core.NewZVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+	core.InstallSystemContracts(api.backend.ChainConfig(), parent.Header(), block.Header(), statedb)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		vmenv := vm.NewZVM(blockCtx, vm.TxContext{}, statedb, api.backend.ChainConfig(), vm.Config{})
+		core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
+	if api.backend.ChainConfig().IsPrague(block.Number(), block.Time()) {
+		vmenv := vm.NewZVM(blockCtx, vm.TxContext{}, statedb, api.backend.ChainConfig(), vm.Config{})
+		core.ProcessParentBlockHash(block.ParentHash(), vmenv, statedb)
+	}
 	// JS tracers have high overhead. In this case run a parallel
 	// process that generates states in one thread and traces txes
 	// in separate worker threads.
@@ -594,8 +625,7 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 	var (
 		txs       = block.Transactions()
 		blockHash = block.Hash()
-		blockCtx  = core.NewZVMBlockContext(block.Header(), api.chainContext(ctx), nil)
-		signer    = types.MakeSigner(api.backend.ChainConfig())
+		signer    = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		results   = make([]*txTraceResult, len(txs))
 	)
 	for i, tx := range txs {
@@ -628,7 +658,7 @@ func (api *API) traceBlockParallel(ctx context.Context, block *types.Block, stat
 		txs       = block.Transactions()
 		blockHash = block.Hash()
 		blockCtx  = core.NewZVMBlockContext(block.Header(), api.chainContext(ctx), nil)
-		signer    = types.MakeSigner(api.backend.ChainConfig())
+		signer    = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		results   = make([]*txTraceResult, len(txs))
 		pend      sync.WaitGroup
 	)
@@ -737,7 +767,7 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 	// Execute transaction, either tracing all or just the requested one
 	var (
 		dumps       []string
-		signer      = types.MakeSigner(api.backend.ChainConfig())
+		signer      = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		chainConfig = api.backend.ChainConfig()
 		vmctx       = core.NewZVMBlockContext(block.Header(), api.chainContext(ctx), nil)
 		canon       = true
@@ -751,6 +781,15 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 		// Note: This copies the config, to not screw up the main config
 		chainConfig, canon = overrideConfig(chainConfig, config.Overrides)
 	}
+	core.InstallSystemContracts(chainConfig, parent.Header(), block.Header(), statedb)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		vmenv := vm.NewZVM(vmctx, vm.TxContext{}, statedb, chainConfig, vm.Config{})
+		core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
+	if chainConfig.IsPrague(block.Number(), block.Time()) {
+		vmenv := vm.NewZVM(vmctx, vm.TxContext{}, statedb, chainConfig, vm.Config{})
+		core.ProcessParentBlockHash(block.ParentHash(), vmenv, statedb)
+	}
 	for i, tx := range block.Transactions() {
 		// Prepare the transaction for un-traced execution
 		var (
@@ -861,25 +900,7 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 // top of the provided block and returns them as a JSON object.
 func (api *API) TraceCall(ctx context.Context, args zondapi.TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceCallConfig) (interface{}, error) {
 	// Try to retrieve the specified block
-	var (
-		err   error
-		block *types.Block
-	)
-	if hash, ok := blockNrOrHash.Hash(); ok {
-		block, err = api.blockByHash(ctx, hash)
-	} else if number, ok := blockNrOrHash.Number(); ok {
-		if number == rpc.PendingBlockNumber {
-			// We don't have access to the miner here. For tracing 'future' transactions,
-			// it can be done with block- and state-overrides instead, which offers
-			// more flexibility and stability than trying to trace on 'pending', since
-			// the contents of 'pending' is unstable and probably not a true representation
-			// of what the next actual block is likely to contain.
-			return nil, errors.New("tracing on top of pending is not supported")
-		}
-		block, err = api.blockByNumber(ctx, number)
-	} else {
-		return nil, errors.New("invalid arguments; neither block nor hash specified")
-	}
+	block, err := api.callBlock(ctx, blockNrOrHash)
 	if err != nil {
 		return nil, err
 	}
@@ -915,6 +936,87 @@ func (api *API) TraceCall(ctx context.Context, args zondapi.TransactionArgs, blo
 	return api.traceTx(ctx, msg, new(Context), vmctx, statedb, traceConfig)
 }
 
+// CallBundle is a list of calls executed one after the other, in a block with
+// optionally overridden fields.
+type CallBundle struct {
+	Transactions   []zondapi.TransactionArgs `json:"transactions"`
+	BlockOverrides *zondapi.BlockOverrides   `json:"blockOverride"`
+}
+
+// TraceCallMany lets you trace a list of call bundles on top of the provided
+// block. The calls are executed sequentially on a shared state, so each one
+// sees the effects of the ones before it, including the ones of the previous
+// bundles. The block overrides of a bundle are applied on top of the ones of
+// the config. The tracer output of each call is returned, grouped by bundle.
+func (api *API) TraceCallMany(ctx context.Context, bundles []CallBundle, blockNrOrHash rpc.BlockNumberOrHash, config *TraceCallConfig) ([][]interface{}, error) {
+	block, err := api.callBlock(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	// try to recompute the state
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, release, err := api.backend.StateAtBlock(ctx, block, reexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var traceConfig *TraceConfig
+	if config != nil {
+		if err := config.StateOverrides.Apply(statedb); err != nil {
+			return nil, err
+		}
+		traceConfig = &config.TraceConfig
+	}
+	results := make([][]interface{}, len(bundles))
+	for i, bundle := range bundles {
+		vmctx := core.NewZVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+		if config != nil {
+			config.BlockOverrides.Apply(&vmctx)
+		}
+		bundle.BlockOverrides.Apply(&vmctx)
+
+		results[i] = make([]interface{}, len(bundle.Transactions))
+		for j, args := range bundle.Transactions {
+			msg, err := args.ToMessage(api.backend.RPCGasCap(), vmctx.BaseFee)
+			if err != nil {
+				return nil, fmt.Errorf("bundle %d, call %d: %w", i, j, err)
+			}
+			txctx := &Context{BlockNumber: vmctx.BlockNumber, TxIndex: j}
+			if results[i][j], err = api.traceTx(ctx, msg, txctx, vmctx, statedb, traceConfig); err != nil {
+				return nil, fmt.Errorf("bundle %d, call %d: %w", i, j, err)
+			}
+			// Finalize the state so the modifications are visible to the
+			// subsequent calls.
+			statedb.Finalise(true)
+		}
+	}
+	return results, nil
+}
+
+// callBlock retrieves the block the calls are traced on top of. Tracing on top
+// of the pending block is not supported.
+func (api *API) callBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		return api.blockByHash(ctx, hash)
+	}
+	if number, ok := blockNrOrHash.Number(); ok {
+		if number == rpc.PendingBlockNumber {
+			// We don't have access to the miner here. For tracing 'future' transactions,
+			// it can be done with block- and state-overrides instead, which offers
+			// more flexibility and stability than trying to trace on 'pending', since
+			// the contents of 'pending' is unstable and probably not a true representation
+			// of what the next actual block is likely to contain.
+			return nil, errors.New("tracing on top of pending is not supported")
+		}
+		return api.blockByNumber(ctx, number)
+	}
+	return nil, errors.New("invalid arguments; neither block nor hash specified")
+}
+
 // traceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
@@ -970,6 +1072,9 @@ func APIs(backend Backend) []rpc.API {
 		{
 			Namespace: "debug",
 			Service:   NewAPI(backend),
+		}, {
+			Namespace: "trace",
+			Service:   NewTraceAPI(backend),
 		},
 	}
 }
@@ -168,7 +168,7 @@ func (b *testBackend) StateAtTransaction(ctx context.Context, block *types.Block
 		return nil, vm.BlockContext{}, statedb, release, nil
 	}
 	// Recompute transactions up to the target index.
-	signer := types.MakeSigner(b.chainConfig)
+	signer := types.MakeSigner(b.chainConfig, block.Number(), block.Time())
 	for idx, tx := range block.Transactions() {
 		msg, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
 		txContext := core.NewZVMTxContext(msg)
@@ -328,6 +328,86 @@ func TestTraceCall(t *testing.T) {
 	}
 }
 
+func TestTraceCallMany(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		counter  = common.BytesToAddress([]byte{0xc0, 0x01})
+		number   = common.BytesToAddress([]byte{0xc0, 0x02})
+	)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			// Increments the slot 0 and returns the new value
+			counter: {Code: common.FromHex("6000546001018060005560005260206000f3")},
+			// Returns the block number
+			number: {Code: common.FromHex("4360005260206000f3")},
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {})
+	defer backend.teardown()
+	api := NewAPI(backend)
+
+	var (
+		call     = zondapi.TransactionArgs{From: &accounts[0].addr, To: &counter}
+		override = &zondapi.BlockOverrides{Number: (*hexutil.Big)(big.NewInt(100))}
+		bundles  = []CallBundle{
+			{Transactions: []zondapi.TransactionArgs{call, call}},
+			{Transactions: []zondapi.TransactionArgs{call, {From: &accounts[0].addr, To: &number}}, BlockOverrides: override},
+			{Transactions: []zondapi.TransactionArgs{{From: &accounts[0].addr, To: &number}}},
+		}
+	)
+	results, err := api.TraceCallMany(context.Background(), bundles, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil)
+	if err != nil {
+		t.Fatalf("failed to trace calls: %v", err)
+	}
+	// The counter is shared among the bundles, while the block overrides only
+	// apply to their own bundle.
+	want := [][]uint64{{1, 2}, {3, 100}, {1}}
+	if len(results) != len(want) {
+		t.Fatalf("bundle count mismatch: have %d, want %d", len(results), len(want))
+	}
+	for i, bundle := range results {
+		if len(bundle) != len(want[i]) {
+			t.Fatalf("bundle %d: call count mismatch: have %d, want %d", i, len(bundle), len(want[i]))
+		}
+		for j, result := range bundle {
+			var res logger.ExecutionResult
+			if err := json.Unmarshal(result.(json.RawMessage), &res); err != nil {
+				t.Fatalf("bundle %d, call %d: failed to decode result: %v", i, j, err)
+			}
+			if have := new(big.Int).SetBytes(common.FromHex(res.ReturnValue)).Uint64(); res.Failed || have != want[i][j] {
+				t.Errorf("bundle %d, call %d: result mismatch: have %d (failed %v), want %d", i, j, have, res.Failed, want[i][j])
+			}
+		}
+	}
+	// The state overrides are applied once, before all the bundles.
+	config := &TraceCallConfig{
+		StateOverrides: &zondapi.StateOverride{
+			counter: zondapi.OverrideAccount{StateDiff: newStates([]common.Hash{{}}, []common.Hash{common.BigToHash(big.NewInt(10))})},
+		},
+	}
+	results, err = api.TraceCallMany(context.Background(), bundles[:1], rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), config)
+	if err != nil {
+		t.Fatalf("failed to trace calls: %v", err)
+	}
+	for j, result := range results[0] {
+		var res logger.ExecutionResult
+		if err := json.Unmarshal(result.(json.RawMessage), &res); err != nil {
+			t.Fatalf("call %d: failed to decode result: %v", j, err)
+		}
+		if have, want := new(big.Int).SetBytes(common.FromHex(res.ReturnValue)).Uint64(), uint64(11+j); have != want {
+			t.Errorf("call %d: result mismatch with state override: have %d, want %d", j, have, want)
+		}
+	}
+	// Tracing on top of pending is rejected
+	if _, err := api.TraceCallMany(context.Background(), bundles, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber), nil); err == nil {
+		t.Error("expected error for pending block")
+	}
+}
+
 func TestTraceTransaction(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,381 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/rpc"
+)
+
+const (
+	// flatCallTracer is the name of the native tracer producing the call traces
+	// of the trace namespace.
+	flatCallTracer = "flatCallTracer"
+
+	// maxFilterRange is the maximum number of blocks a trace filter can span.
+	maxFilterRange = 1000
+
+	// maxFilterResults is the maximum number of call traces returned by a
+	// trace filter.
+	maxFilterResults = 10000
+)
+
+// TraceAPI is the collection of tracing APIs exposed over the trace namespace,
+// reporting the call frames of the transactions in the flat format of parity.
+// The traces are served from the trace index if enabled, and re-executed
+// otherwise.
+type TraceAPI struct {
+	api *API
+}
+
+// NewTraceAPI creates a new API definition for the trace methods.
+func NewTraceAPI(backend Backend) *TraceAPI {
+	return &TraceAPI{api: NewAPI(backend)}
+}
+
+// TraceFilterArgs represents the arguments of the trace_filter method.
+type TraceFilterArgs struct {
+	FromBlock   *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock     *rpc.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// TraceReplayResult is the result of replaying a single transaction.
+type TraceReplayResult struct {
+	Output          hexutil.Bytes     `json:"output"`
+	Trace           []json.RawMessage `json:"trace"`
+	TransactionHash common.Hash       `json:"transactionHash"`
+}
+
+// traceAddresses is the subset of a flat call frame identifying the accounts
+// it touches.
+type traceAddresses struct {
+	Action struct {
+		From *common.Address `json:"from"`
+		To   *common.Address `json:"to"`
+	} `json:"action"`
+	Result *struct {
+		Address *common.Address `json:"address"`
+		Output  hexutil.Bytes   `json:"output"`
+	} `json:"result"`
+}
+
+// from returns the account initiating the call frame, nil if there's none.
+func (t *traceAddresses) from() *common.Address {
+	return t.Action.From
+}
+
+// to returns the account called or created by the call frame, nil if there's
+// none.
+func (t *traceAddresses) to() *common.Address {
+	if t.Action.To != nil {
+		return t.Action.To
+	}
+	if t.Result != nil {
+		return t.Result.Address
+	}
+	return nil
+}
+
+// Block returns the call traces of all the transactions in the given block.
+func (api *TraceAPI) Block(ctx context.Context, number rpc.BlockNumber) ([]json.RawMessage, error) {
+	block, err := api.api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	traces, err := api.blockTraces(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	var frames []json.RawMessage
+	for _, trace := range traces {
+		frames = append(frames, trace...)
+	}
+	return frames, nil
+}
+
+// Transaction returns the call traces of the given transaction.
+func (api *TraceAPI) Transaction(ctx context.Context, hash common.Hash) ([]json.RawMessage, error) {
+	tx, blockHash, blockNumber, index, err := api.api.backend.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, errTxNotFound
+	}
+	if traces := api.indexedTraces(blockHash, blockNumber); traces != nil && int(index) < len(traces) {
+		return splitFrames(traces[index])
+	}
+	tracer := flatCallTracer
+	result, err := api.api.TraceTransaction(ctx, hash, &TraceConfig{Tracer: &tracer})
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := result.(json.RawMessage)
+	if !ok {
+		return nil, errors.New("unexpected trace result")
+	}
+	return splitFrames(raw)
+}
+
+// ReplayBlockTransactions replays all the transactions in the given block and
+// returns the requested traces of each. Only the call traces are supported.
+func (api *TraceAPI) ReplayBlockTransactions(ctx context.Context, number rpc.BlockNumber, traceTypes []string) ([]*TraceReplayResult, error) {
+	var withTrace bool
+	for _, typ := range traceTypes {
+		if typ != "trace" {
+			return nil, fmt.Errorf("unsupported trace type %q", typ)
+		}
+		withTrace = true
+	}
+	block, err := api.api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	traces, err := api.blockTraces(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*TraceReplayResult, len(traces))
+	for i, trace := range traces {
+		results[i] = &TraceReplayResult{TransactionHash: block.Transactions()[i].Hash()}
+		if len(trace) > 0 {
+			var top traceAddresses
+			if err := json.Unmarshal(trace[0], &top); err != nil {
+				return nil, err
+			}
+			if top.Result != nil {
+				results[i].Output = top.Result.Output
+			}
+		}
+		if withTrace {
+			results[i].Trace = trace
+		}
+	}
+	return results, nil
+}
+
+// Filter returns the call traces within the given block range matching the
+// given accounts. A trace matches if its sender is one of the from addresses
+// and its recipient is one of the to addresses, an empty list matching any.
+// The blocks covered by the trace index are only looked at if they contain a
+// trace of the filtered accounts, the others are re-executed. The range can't
+// span more than maxFilterRange blocks, and at most maxFilterResults traces are
+// returned.
+func (api *TraceAPI) Filter(ctx context.Context, args TraceFilterArgs) ([]json.RawMessage, error) {
+	from, err := api.resolveNumber(ctx, args.FromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := api.resolveNumber(ctx, args.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, errors.New("invalid block range")
+	}
+	if to-from >= maxFilterRange {
+		return nil, fmt.Errorf("block range too large: %d blocks, maximum %d", to-from+1, maxFilterRange)
+	}
+	// The genesis block has no transactions to trace
+	from = max(from, 1)
+
+	var (
+		matches []json.RawMessage
+		skip    uint64
+		limit   = uint64(maxFilterResults)
+	)
+	if args.After != nil {
+		skip = *args.After
+	}
+	if args.Count != nil {
+		if *args.Count == 0 {
+			return matches, nil
+		}
+		limit = min(limit, *args.Count)
+	}
+	for _, number := range api.filterCandidates(args, from, to) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block, err := api.api.blockByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		traces, err := api.blockTraces(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+		for _, trace := range traces {
+			for _, frame := range trace {
+				var addrs traceAddresses
+				if err := json.Unmarshal(frame, &addrs); err != nil {
+					return nil, err
+				}
+				if !matchAddress(args.FromAddress, addrs.from()) || !matchAddress(args.ToAddress, addrs.to()) {
+					continue
+				}
+				if skip > 0 {
+					skip--
+					continue
+				}
+				matches = append(matches, frame)
+				if uint64(len(matches)) >= limit {
+					return matches, nil
+				}
+			}
+		}
+	}
+	return matches, nil
+}
+
+// filterCandidates returns the numbers of the blocks within the given range
+// which may contain a trace matching the filter, in ascending order. Without
+// any address to filter by, or outside of the range covered by the trace index
+// all the blocks are candidates.
+func (api *TraceAPI) filterCandidates(args TraceFilterArgs, from, to uint64) []uint64 {
+	var (
+		db         = api.api.backend.ChainDb()
+		tail       = rawdb.ReadTraceIndexTail(db)
+		head       = rawdb.ReadTraceIndexHead(db)
+		candidates []uint64
+	)
+	if tail == nil || head == nil || *tail > to || *head < from || (len(args.FromAddress) == 0 && len(args.ToAddress) == 0) {
+		for n := from; n <= to; n++ {
+			candidates = append(candidates, n)
+		}
+		return candidates
+	}
+	// Re-execute the blocks out of the range covered by the trace index and
+	// look up the indexed ones by the filtered addresses. If both the from and
+	// to addresses are specified, either index is enough to find the matches.
+	start, end := max(from, *tail), min(to, *head)
+	for n := from; n < start; n++ {
+		candidates = append(candidates, n)
+	}
+	addresses := args.ToAddress
+	if len(args.FromAddress) > 0 {
+		addresses = args.FromAddress
+	}
+	for _, addr := range addresses {
+		candidates = append(candidates, rawdb.ReadTraceAddressIndex(db, addr, start, end)...)
+	}
+	for n := end + 1; n <= to; n++ {
+		candidates = append(candidates, n)
+	}
+	slices.Sort(candidates)
+	return slices.Compact(candidates)
+}
+
+// resolveNumber resolves the given block number into an absolute one, the
+// latest block if it's not specified.
+func (api *TraceAPI) resolveNumber(ctx context.Context, number *rpc.BlockNumber) (uint64, error) {
+	if number != nil && *number >= 0 {
+		return uint64(*number), nil
+	}
+	n := rpc.LatestBlockNumber
+	if number != nil {
+		n = *number
+	}
+	header, err := api.api.backend.HeaderByNumber(ctx, n)
+	if err != nil {
+		return 0, err
+	}
+	if header == nil {
+		return 0, fmt.Errorf("block #%d not found", n)
+	}
+	return header.Number.Uint64(), nil
+}
+
+// blockTraces returns the call frames of each transaction in the given block,
+// read from the trace index if available and re-executed otherwise.
+func (api *TraceAPI) blockTraces(ctx context.Context, block *types.Block) ([][]json.RawMessage, error) {
+	traces := api.indexedTraces(block.Hash(), block.NumberU64())
+	if traces == nil || len(traces) != len(block.Transactions()) {
+		var err error
+		if traces, err = traceBlockFlat(ctx, api.api, block); err != nil {
+			return nil, err
+		}
+	}
+	frames := make([][]json.RawMessage, len(traces))
+	for i, trace := range traces {
+		var err error
+		if frames[i], err = splitFrames(trace); err != nil {
+			return nil, err
+		}
+	}
+	return frames, nil
+}
+
+// indexedTraces returns the json encoded call traces of each transaction in
+// the given block from the trace index, nil if the block is not indexed.
+func (api *TraceAPI) indexedTraces(hash common.Hash, number uint64) [][]byte {
+	return rawdb.ReadBlockTraces(api.api.backend.ChainDb(), hash, number)
+}
+
+// traceBlockFlat executes all the transactions in the given block with the
+// flat call tracer, returning the json encoded call traces of each.
+func traceBlockFlat(ctx context.Context, api *API, block *types.Block) ([][]byte, error) {
+	tracer := flatCallTracer
+	results, err := api.traceBlock(ctx, block, &TraceConfig{Tracer: &tracer})
+	if err != nil {
+		return nil, err
+	}
+	traces := make([][]byte, len(results))
+	for i, result := range results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("tracing transaction %#x failed: %s", result.TxHash, result.Error)
+		}
+		raw, ok := result.Result.(json.RawMessage)
+		if !ok {
+			return nil, errors.New("unexpected trace result")
+		}
+		traces[i] = raw
+	}
+	return traces, nil
+}
+
+// splitFrames decodes the json encoded call traces of a transaction into the
+// individual call frames.
+func splitFrames(trace []byte) ([]json.RawMessage, error) {
+	var frames []json.RawMessage
+	if err := json.Unmarshal(trace, &frames); err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
+
+// matchAddress reports whether the given address is contained in the filter
+// list, an empty list matching any address.
+func matchAddress(filter []common.Address, addr *common.Address) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	return addr != nil && slices.Contains(filter, *addr)
+}
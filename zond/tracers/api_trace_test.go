@@ -0,0 +1,216 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/event"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/rpc"
+)
+
+// indexerTestBackend extends the test backend with the chain events needed by
+// the trace indexer.
+type indexerTestBackend struct {
+	*testBackend
+}
+
+func (b indexerTestBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
+	return b.chain.SubscribeChainEvent(ch)
+}
+
+// tracePair is the sender and recipient of a flat call frame.
+type tracePair struct {
+	From common.Address
+	To   common.Address
+}
+
+func tracePairs(t *testing.T, frames []json.RawMessage) []tracePair {
+	t.Helper()
+
+	pairs := make([]tracePair, 0, len(frames))
+	for _, frame := range frames {
+		var addrs traceAddresses
+		if err := json.Unmarshal(frame, &addrs); err != nil {
+			t.Fatalf("failed to decode frame: %v", err)
+		}
+		pairs = append(pairs, tracePair{From: *addrs.from(), To: *addrs.to()})
+	}
+	return pairs
+}
+
+func TestTraceAPI(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(3)
+		callee   = common.BytesToAddress([]byte{0xde, 0xad})
+		contract = common.BytesToAddress([]byte{0xca, 0x11})
+	)
+	// The contract calls the callee with all the gas left and no value
+	code := append(common.FromHex("6000600060006000600073"), callee.Bytes()...)
+	code = append(code, common.FromHex("5af100")...)
+
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+			contract:         {Balance: common.Big0, Code: code},
+		},
+	}
+	var (
+		signer = types.ShanghaiSigner{ChainId: big.NewInt(1)}
+		hashes []common.Hash
+	)
+	backend := newTestBackend(t, 3, genesis, func(i int, b *core.BlockGen) {
+		var (
+			from = accounts[0]
+			to   = contract
+			gas  = uint64(100000)
+		)
+		if i == 1 {
+			from, to, gas = accounts[1], accounts[2].addr, params.TxGas
+		}
+		tx, _ := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     b.TxNonce(from.addr),
+			To:        &to,
+			Value:     big.NewInt(1000),
+			Gas:       gas,
+			GasFeeCap: b.BaseFee(),
+		}), signer, from.key)
+		b.AddTx(tx)
+		hashes = append(hashes, tx.Hash())
+	})
+	defer backend.teardown()
+
+	var (
+		api      = NewTraceAPI(backend)
+		outer    = tracePair{From: accounts[0].addr, To: contract}
+		inner    = tracePair{From: contract, To: callee}
+		transfer = tracePair{From: accounts[1].addr, To: accounts[2].addr}
+	)
+	check := func() {
+		t.Helper()
+
+		frames, err := api.Block(context.Background(), rpc.BlockNumber(1))
+		if err != nil {
+			t.Fatalf("failed to trace block: %v", err)
+		}
+		if have, want := tracePairs(t, frames), []tracePair{outer, inner}; !reflect.DeepEqual(have, want) {
+			t.Errorf("block traces mismatch: have %v, want %v", have, want)
+		}
+		frames, err = api.Transaction(context.Background(), hashes[2])
+		if err != nil {
+			t.Fatalf("failed to trace transaction: %v", err)
+		}
+		if have, want := tracePairs(t, frames), []tracePair{outer, inner}; !reflect.DeepEqual(have, want) {
+			t.Errorf("transaction traces mismatch: have %v, want %v", have, want)
+		}
+		replays, err := api.ReplayBlockTransactions(context.Background(), rpc.BlockNumber(2), []string{"trace"})
+		if err != nil {
+			t.Fatalf("failed to replay block: %v", err)
+		}
+		if len(replays) != 1 || replays[0].TransactionHash != hashes[1] {
+			t.Fatalf("replay results mismatch: %v", replays)
+		}
+		if have, want := tracePairs(t, replays[0].Trace), []tracePair{transfer}; !reflect.DeepEqual(have, want) {
+			t.Errorf("replay traces mismatch: have %v, want %v", have, want)
+		}
+		if _, err := api.ReplayBlockTransactions(context.Background(), rpc.BlockNumber(2), []string{"vmTrace"}); err == nil {
+			t.Error("expected error for unsupported trace type")
+		}
+
+		var (
+			zero  = uint64(0)
+			one   = uint64(1)
+			first = rpc.BlockNumber(1)
+			far   = rpc.BlockNumber(maxFilterRange + 1)
+		)
+		for i, tt := range []struct {
+			args TraceFilterArgs
+			want []tracePair
+		}{
+			{TraceFilterArgs{FromBlock: &first}, []tracePair{outer, inner, transfer, outer, inner}},
+			{TraceFilterArgs{ToAddress: []common.Address{callee}}, []tracePair{inner}},
+			{TraceFilterArgs{FromBlock: &first, ToAddress: []common.Address{callee}}, []tracePair{inner, inner}},
+			{TraceFilterArgs{FromBlock: &first, FromAddress: []common.Address{accounts[1].addr}}, []tracePair{transfer}},
+			{TraceFilterArgs{FromBlock: &first, FromAddress: []common.Address{accounts[0].addr}, ToAddress: []common.Address{callee}}, []tracePair{}},
+			{TraceFilterArgs{FromBlock: &first, FromAddress: []common.Address{contract, accounts[1].addr}}, []tracePair{inner, transfer, inner}},
+			{TraceFilterArgs{FromBlock: &first, After: &one, Count: &one}, []tracePair{inner}},
+			{TraceFilterArgs{FromBlock: &first, Count: &zero}, []tracePair{}},
+		} {
+			frames, err := api.Filter(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("test %d: failed to filter traces: %v", i, err)
+			}
+			if have := tracePairs(t, frames); !reflect.DeepEqual(have, tt.want) {
+				t.Errorf("test %d: filtered traces mismatch: have %v, want %v", i, have, tt.want)
+			}
+		}
+		if _, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: &first, ToBlock: &far}); err == nil {
+			t.Error("expected error for too large block range")
+		}
+	}
+	// Serve the traces by re-executing the blocks
+	check()
+
+	// Index all the blocks and serve the traces from the index
+	rawdb.WriteTraceIndexTail(backend.chaindb, 1)
+	indexer := NewIndexer(indexerTestBackend{backend})
+	if err := indexer.index(backend.chain.GetBlockByNumber(3)); err != nil {
+		t.Fatalf("failed to index traces: %v", err)
+	}
+	for n := uint64(1); n <= 3; n++ {
+		if rawdb.ReadBlockTraces(backend.chaindb, backend.chain.GetCanonicalHash(n), n) == nil {
+			t.Fatalf("block %d not indexed", n)
+		}
+	}
+	if head := rawdb.ReadTraceIndexHead(backend.chaindb); head == nil || *head != 3 {
+		t.Fatalf("trace index head mismatch: %v", head)
+	}
+	// Re-indexing a block drops the traces of any other block at its height
+	var (
+		block  = backend.chain.GetBlockByNumber(2)
+		stale  = common.Hash{0x01}
+		traces = rawdb.ReadBlockTraces(backend.chaindb, block.Hash(), 2)
+	)
+	rawdb.WriteBlockTraces(backend.chaindb, stale, 2, traces)
+	if err := indexer.write(block, traces); err != nil {
+		t.Fatalf("failed to re-index block: %v", err)
+	}
+	if rawdb.ReadBlockTraces(backend.chaindb, stale, 2) != nil {
+		t.Fatal("stale block traces not dropped")
+	}
+	if rawdb.ReadBlockTraces(backend.chaindb, block.Hash(), 2) == nil {
+		t.Fatal("block traces dropped on re-index")
+	}
+	args := TraceFilterArgs{ToAddress: []common.Address{callee}}
+	if have, want := api.filterCandidates(args, 1, 3), []uint64{1, 3}; !reflect.DeepEqual(have, want) {
+		t.Errorf("filter candidates mismatch: have %v, want %v", have, want)
+	}
+	check()
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/event"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/rpc"
+)
+
+// indexChunkSize is the number of blocks after which the progress of indexing a
+// long range of blocks is recorded.
+const indexChunkSize = 1024
+
+// IndexerBackend defines the methods needed by the trace indexer to follow
+// the chain.
+type IndexerBackend interface {
+	Backend
+	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
+}
+
+// Indexer traces the transactions of the newly imported canonical blocks with
+// the flat call tracer, storing the traces together with an index of the blocks
+// by the accounts the traces are from or to. The blocks are indexed starting
+// from the chain head at the time the indexing is first enabled.
+type Indexer struct {
+	backend IndexerBackend
+	api     *API
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewIndexer creates a trace indexer on top of the given backend.
+func NewIndexer(backend IndexerBackend) *Indexer {
+	return &Indexer{
+		backend: backend,
+		api:     NewAPI(backend),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Start implements node.Lifecycle, launching the indexing loop.
+func (i *Indexer) Start() error {
+	i.wg.Add(1)
+	go i.loop()
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the indexing loop.
+func (i *Indexer) Stop() error {
+	close(i.quit)
+	i.wg.Wait()
+	return nil
+}
+
+// loop indexes the blocks as they are imported into the canonical chain.
+func (i *Indexer) loop() {
+	defer i.wg.Done()
+
+	events := make(chan core.ChainEvent, 16)
+	sub := i.backend.SubscribeChainEvent(events)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-events:
+			if err := i.index(ev.Block); err != nil {
+				log.Warn("Failed to index block traces", "number", ev.Block.Number(), "hash", ev.Hash, "err", err)
+			}
+		case <-sub.Err():
+			return
+		case <-i.quit:
+			return
+		}
+	}
+}
+
+// index traces the given canonical block along with any of its ancestors down
+// to the index tail which aren't indexed yet, e.g. the ones missed while the
+// node was down or the new ones of a reorg. The ancestors are looked up by their
+// headers and traced one by one, recording the progress every chunk of blocks.
+func (i *Indexer) index(head *types.Block) error {
+	var (
+		ctx  = context.Background()
+		db   = i.backend.ChainDb()
+		tail = head.NumberU64()
+		from = head.NumberU64() + 1
+	)
+	if n := rawdb.ReadTraceIndexTail(db); n != nil {
+		tail = *n
+	} else {
+		rawdb.WriteTraceIndexTail(db, tail)
+	}
+	for header := head.Header(); header != nil && header.Number.Uint64() >= max(tail, 1); {
+		if rawdb.ReadBlockTraces(db, header.Hash(), header.Number.Uint64()) != nil {
+			break
+		}
+		from = header.Number.Uint64()
+
+		var err error
+		if header, err = i.backend.HeaderByHash(ctx, header.ParentHash); err != nil {
+			return err
+		}
+	}
+	for number := from; number <= head.NumberU64(); number++ {
+		select {
+		case <-i.quit:
+			return nil
+		default:
+		}
+		block := head
+		if number < head.NumberU64() {
+			var err error
+			if block, err = i.backend.BlockByNumber(ctx, rpc.BlockNumber(number)); err != nil {
+				return err
+			}
+			if block == nil {
+				return fmt.Errorf("block #%d not found", number)
+			}
+		}
+		if rawdb.ReadBlockTraces(db, block.Hash(), number) == nil {
+			traces, err := traceBlockFlat(ctx, i.api, block)
+			if err != nil {
+				// The state of the missed blocks may no longer be available, give
+				// up on the ones not indexed so far by moving the tail above them.
+				log.Debug("Failed to trace block for indexing", "number", block.Number(), "hash", block.Hash(), "err", err)
+				tail = number + 1
+				rawdb.WriteTraceIndexTail(db, tail)
+				continue
+			}
+			if err := i.write(block, traces); err != nil {
+				return err
+			}
+		}
+		if (number+1-from)%indexChunkSize == 0 {
+			rawdb.WriteTraceIndexHead(db, number)
+		}
+	}
+	rawdb.WriteTraceIndexHead(db, head.NumberU64())
+	return nil
+}
+
+// write stores the call traces of the given block and indexes the block by
+// the accounts of the traces.
+func (i *Indexer) write(block *types.Block, traces [][]byte) error {
+	var (
+		db    = i.backend.ChainDb()
+		batch = db.NewBatch()
+		seen  = make(map[common.Address]struct{})
+	)
+	for _, trace := range traces {
+		frames, err := splitFrames(trace)
+		if err != nil {
+			return err
+		}
+		for _, frame := range frames {
+			var addrs traceAddresses
+			if err := json.Unmarshal(frame, &addrs); err != nil {
+				return err
+			}
+			for _, addr := range []*common.Address{addrs.from(), addrs.to()} {
+				if addr != nil {
+					seen[*addr] = struct{}{}
+				}
+			}
+		}
+	}
+	for addr := range seen {
+		rawdb.WriteTraceAddressIndex(batch, addr, block.NumberU64())
+	}
+	// Drop the traces of any block replaced by a reorg at the same height
+	rawdb.DeleteBlockTraces(db, batch, block.NumberU64())
+	rawdb.WriteBlockTraces(batch, block.Hash(), block.NumberU64(), traces)
+	return batch.Write()
+}
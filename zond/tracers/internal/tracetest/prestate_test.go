@@ -53,15 +53,17 @@ type testcase struct {
 }
 
 func TestPrestateTracer(t *testing.T) {
-	testPrestateDiffTracer("prestateTracer", "prestate_tracer", t)
+	testTracerFixtures("prestateTracer", "prestate_tracer", t)
 }
 
 // TODO(now.youtrack.cloud/issue/TGZ-13)
 func TestPrestateWithDiffModeTracer(t *testing.T) {
-	testPrestateDiffTracer("prestateTracer", "prestate_tracer_with_diff_mode", t)
+	testTracerFixtures("prestateTracer", "prestate_tracer_with_diff_mode", t)
 }
 
-func testPrestateDiffTracer(tracerName string, dirPath string, t *testing.T) {
+// testTracerFixtures runs the given tracer over the transaction of each test
+// case in the given directory, comparing the json encoded results.
+func testTracerFixtures(tracerName string, dirPath string, t *testing.T) {
 	files, err := os.ReadDir(filepath.Join("testdata", dirPath))
 	if err != nil {
 		t.Fatalf("failed to retrieve tracer test suite: %v", err)
@@ -89,7 +91,7 @@ func testPrestateDiffTracer(tracerName string, dirPath string, t *testing.T) {
 			}
 			// Configure a blockchain with the given prestate
 			var (
-				signer    = types.MakeSigner(test.Genesis.Config)
+				signer    = types.MakeSigner(test.Genesis.Config, new(big.Int).SetUint64(uint64(test.Context.Number)), uint64(test.Context.Time))
 				origin, _ = signer.Sender(tx)
 				txContext = vm.TxContext{
 					Origin:   origin,
@@ -121,16 +123,25 @@ func testPrestateDiffTracer(tracerName string, dirPath string, t *testing.T) {
 			if _, err = st.TransitionDb(); err != nil {
 				t.Fatalf("failed to execute transaction: %v", err)
 			}
-			// Retrieve the trace result and compare against the expected
+			// Retrieve the trace result and compare against the expected, with
+			// the fields of both in the same order
 			res, err := tracer.GetResult()
 			if err != nil {
 				t.Fatalf("failed to retrieve trace result: %v", err)
 			}
+			var result interface{}
+			if err := json.Unmarshal(res, &result); err != nil {
+				t.Fatalf("failed to parse trace result: %v", err)
+			}
+			have, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("failed to marshal trace result: %v", err)
+			}
 			want, err := json.Marshal(test.Result)
 			if err != nil {
 				t.Fatalf("failed to marshal test: %v", err)
 			}
-			if string(want) != string(res) {
+			if string(want) != string(have) {
 				t.Fatalf("trace mismatch\n have: %v\n want: %v\n", string(res), string(want))
 			}
 		})
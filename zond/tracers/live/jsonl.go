@@ -0,0 +1,266 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package live contains the live tracers bundled by default, which trace the
+// whole block processing of the chain when selected with --vmtrace.
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/zond/tracers"
+)
+
+func init() {
+	tracers.LiveDirectory.Register("jsonl", newJSONLTracer)
+}
+
+// jsonlTracerConfig is the configuration of the JSONL live tracer.
+type jsonlTracerConfig struct {
+	Path string `json:"path"` // File the traces are appended to
+}
+
+// jsonlTracer is a live tracer writing the block processing events into a file
+// as JSON objects, one per line. The opcode level steps are not written.
+type jsonlTracer struct {
+	file  *os.File
+	w     *bufio.Writer
+	enc   *json.Encoder
+	depth int         // Depth of the current call frame
+	tx    common.Hash // Hash of the transaction being executed
+	err   error       // First write error encountered
+}
+
+// newJSONLTracer creates a JSONL live tracer appending to the configured file.
+func newJSONLTracer(cfg json.RawMessage) (core.BlockchainLogger, error) {
+	var config jsonlTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	if config.Path == "" {
+		return nil, errors.New("jsonl tracer requires a path")
+	}
+	file, err := os.OpenFile(config.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	w := bufio.NewWriter(file)
+	return &jsonlTracer{file: file, w: w, enc: json.NewEncoder(w)}, nil
+}
+
+// write encodes the event as a line of the trace file.
+func (t *jsonlTracer) write(event interface{}) {
+	if t.err != nil {
+		return
+	}
+	if err := t.enc.Encode(event); err != nil {
+		log.Error("Failed to write live trace, tracing stopped", "path", t.file.Name(), "err", err)
+		t.err = err
+	}
+}
+
+// errString returns the message of the error, empty if there is none.
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func (t *jsonlTracer) OnBlockStart(block *types.Block) {
+	t.write(struct {
+		Type       string         `json:"type"`
+		Number     hexutil.Uint64 `json:"number"`
+		Hash       common.Hash    `json:"hash"`
+		ParentHash common.Hash    `json:"parentHash"`
+	}{"blockStart", hexutil.Uint64(block.NumberU64()), block.Hash(), block.ParentHash()})
+}
+
+func (t *jsonlTracer) OnBlockEnd(err error) {
+	t.write(struct {
+		Type  string `json:"type"`
+		Error string `json:"error,omitempty"`
+	}{"blockEnd", errString(err)})
+
+	// Flush the traces of each block, so that they can be followed while the
+	// chain is running.
+	t.flush()
+}
+
+// flush writes the buffered traces into the file.
+func (t *jsonlTracer) flush() {
+	if t.err != nil {
+		return
+	}
+	if err := t.w.Flush(); err != nil {
+		log.Error("Failed to write live trace, tracing stopped", "path", t.file.Name(), "err", err)
+		t.err = err
+	}
+}
+
+// Close flushes the pending traces and closes the trace file. It's called when
+// the node is stopped.
+func (t *jsonlTracer) Close() error {
+	t.flush()
+	if err := t.file.Close(); err != nil {
+		return err
+	}
+	return t.err
+}
+
+func (t *jsonlTracer) OnTxStart(tx *types.Transaction, from common.Address) {
+	t.tx = tx.Hash()
+	t.write(struct {
+		Type  string          `json:"type"`
+		Hash  common.Hash     `json:"hash"`
+		From  common.Address  `json:"from"`
+		To    *common.Address `json:"to"`
+		Nonce hexutil.Uint64  `json:"nonce"`
+	}{"txStart", t.tx, from, tx.To(), hexutil.Uint64(tx.Nonce())})
+}
+
+func (t *jsonlTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	event := struct {
+		Type    string          `json:"type"`
+		Hash    common.Hash     `json:"hash"`
+		Status  *hexutil.Uint64 `json:"status,omitempty"`
+		GasUsed *hexutil.Uint64 `json:"gasUsed,omitempty"`
+		Error   string          `json:"error,omitempty"`
+	}{Type: "txEnd", Hash: t.tx, Error: errString(err)}
+	if receipt != nil {
+		event.Status = (*hexutil.Uint64)(&receipt.Status)
+		event.GasUsed = (*hexutil.Uint64)(&receipt.GasUsed)
+	}
+	t.write(event)
+	t.tx = common.Hash{}
+}
+
+func (t *jsonlTracer) CaptureTxStart(gasLimit uint64) {}
+
+func (t *jsonlTracer) CaptureTxEnd(restGas uint64) {}
+
+func (t *jsonlTracer) CaptureStart(env *vm.ZVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	op := vm.CALL
+	if create {
+		op = vm.CREATE
+	}
+	t.depth = 0
+	t.enter(op, from, to, input, gas, value)
+}
+
+func (t *jsonlTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.exit(output, gasUsed, err)
+}
+
+func (t *jsonlTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.enter(typ, from, to, input, gas, value)
+}
+
+func (t *jsonlTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.exit(output, gasUsed, err)
+}
+
+func (t *jsonlTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+
+func (t *jsonlTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+// enter writes the event of entering a call frame.
+func (t *jsonlTracer) enter(op vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.write(struct {
+		Type  string         `json:"type"`
+		Depth int            `json:"depth"`
+		Op    string         `json:"op"`
+		From  common.Address `json:"from"`
+		To    common.Address `json:"to"`
+		Input hexutil.Bytes  `json:"input"`
+		Gas   hexutil.Uint64 `json:"gas"`
+		Value *hexutil.Big   `json:"value,omitempty"`
+	}{"enter", t.depth, op.String(), from, to, input, hexutil.Uint64(gas), (*hexutil.Big)(value)})
+	t.depth++
+}
+
+// exit writes the event of exiting a call frame.
+func (t *jsonlTracer) exit(output []byte, gasUsed uint64, err error) {
+	t.depth--
+	t.write(struct {
+		Type    string         `json:"type"`
+		Depth   int            `json:"depth"`
+		Output  hexutil.Bytes  `json:"output"`
+		GasUsed hexutil.Uint64 `json:"gasUsed"`
+		Error   string         `json:"error,omitempty"`
+	}{"exit", t.depth, output, hexutil.Uint64(gasUsed), errString(err)})
+}
+
+func (t *jsonlTracer) OnBalanceChange(addr common.Address, prev, new *big.Int) {
+	t.write(struct {
+		Type    string         `json:"type"`
+		Address common.Address `json:"address"`
+		Prev    *hexutil.Big   `json:"prev"`
+		New     *hexutil.Big   `json:"new"`
+	}{"balance", addr, (*hexutil.Big)(prev), (*hexutil.Big)(new)})
+}
+
+func (t *jsonlTracer) OnNonceChange(addr common.Address, prev, new uint64) {
+	t.write(struct {
+		Type    string         `json:"type"`
+		Address common.Address `json:"address"`
+		Prev    hexutil.Uint64 `json:"prev"`
+		New     hexutil.Uint64 `json:"new"`
+	}{"nonce", addr, hexutil.Uint64(prev), hexutil.Uint64(new)})
+}
+
+func (t *jsonlTracer) OnCodeChange(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+	t.write(struct {
+		Type     string         `json:"type"`
+		Address  common.Address `json:"address"`
+		PrevHash common.Hash    `json:"prevHash"`
+		Hash     common.Hash    `json:"hash"`
+		Code     hexutil.Bytes  `json:"code"`
+	}{"code", addr, prevCodeHash, codeHash, code})
+}
+
+func (t *jsonlTracer) OnStorageChange(addr common.Address, slot common.Hash, prev, new common.Hash) {
+	t.write(struct {
+		Type    string         `json:"type"`
+		Address common.Address `json:"address"`
+		Slot    common.Hash    `json:"slot"`
+		Prev    common.Hash    `json:"prev"`
+		New     common.Hash    `json:"new"`
+	}{"storage", addr, slot, prev, new})
+}
+
+func (t *jsonlTracer) OnLog(l *types.Log) {
+	t.write(struct {
+		Type    string         `json:"type"`
+		Address common.Address `json:"address"`
+		Topics  []common.Hash  `json:"topics"`
+		Data    hexutil.Bytes  `json:"data"`
+	}{"log", l.Address, l.Topics, l.Data})
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/zond/tracers"
+)
+
+// Tests that the JSONL live tracer writes one JSON object per event.
+func TestJSONLTracer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	if _, err := tracers.LiveDirectory.New("jsonl", nil); err == nil {
+		t.Fatal("tracer without path created")
+	}
+	config, _ := json.Marshal(jsonlTracerConfig{Path: path})
+	tracer, err := tracers.LiveDirectory.New("jsonl", config)
+	if err != nil {
+		t.Fatalf("failed to create tracer: %v", err)
+	}
+	var (
+		from = common.Address{0x01}
+		to   = common.Address{0x02}
+		tx   = types.NewTx(&types.DynamicFeeTx{Nonce: 1, To: &to, Value: big.NewInt(1)})
+	)
+	tracer.OnBlockStart(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}))
+	tracer.OnTxStart(tx, from)
+	tracer.CaptureStart(nil, from, to, false, nil, 21000, big.NewInt(1))
+	tracer.CaptureEnter(vm.STATICCALL, to, from, []byte{0x01}, 1000, nil)
+	tracer.CaptureExit(nil, 100, errors.New("oops"))
+	tracer.OnBalanceChange(to, big.NewInt(0), big.NewInt(1))
+	tracer.OnStorageChange(to, common.Hash{0x01}, common.Hash{}, common.Hash{0x02})
+	tracer.CaptureEnd(nil, 21000, nil)
+	tracer.OnTxEnd(&types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: 21000}, nil)
+	tracer.OnBlockEnd(nil)
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open trace: %v", err)
+	}
+	defer file.Close()
+
+	var (
+		events  []map[string]interface{}
+		scanner = bufio.NewScanner(file)
+	)
+	for scanner.Scan() {
+		event := make(map[string]interface{})
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid trace line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+	want := []string{"blockStart", "txStart", "enter", "enter", "exit", "balance", "storage", "exit", "txEnd", "blockEnd"}
+	if len(events) != len(want) {
+		t.Fatalf("event count mismatch: have %d, want %d", len(events), len(want))
+	}
+	for i, typ := range want {
+		if events[i]["type"] != typ {
+			t.Fatalf("event %d type mismatch: have %v, want %s", i, events[i]["type"], typ)
+		}
+	}
+	if events[3]["op"] != "STATICCALL" || events[3]["depth"] != float64(1) {
+		t.Fatalf("unexpected nested call frame: %v", events[3])
+	}
+	if events[4]["error"] != "oops" || events[7]["depth"] != float64(0) {
+		t.Fatalf("unexpected call frame exits: %v, %v", events[4], events[7])
+	}
+	if events[8]["hash"] != tx.Hash().Hex() || events[8]["status"] != "0x1" {
+		t.Fatalf("unexpected transaction end: %v", events[8])
+	}
+}
+
+// Tests that closing the JSONL live tracer flushes the traces of an unfinished
+// block into the file.
+func TestJSONLTracerClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	config, _ := json.Marshal(jsonlTracerConfig{Path: path})
+	tracer, err := tracers.LiveDirectory.New("jsonl", config)
+	if err != nil {
+		t.Fatalf("failed to create tracer: %v", err)
+	}
+	tracer.OnBlockStart(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}))
+
+	if blob, _ := os.ReadFile(path); len(blob) != 0 {
+		t.Fatalf("unfinished block flushed: %q", blob)
+	}
+	if err := tracer.(io.Closer).Close(); err != nil {
+		t.Fatalf("failed to close tracer: %v", err)
+	}
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read trace: %v", err)
+	}
+	var event map[string]interface{}
+	if err := json.Unmarshal(blob, &event); err != nil || event["type"] != "blockStart" {
+		t.Fatalf("unexpected trace %q: %v", blob, err)
+	}
+}
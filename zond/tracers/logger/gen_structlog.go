@@ -5,11 +5,11 @@ package logger
 import (
 	"encoding/json"
 
+	"github.com/holiman/uint256"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/common/math"
 	"github.com/theQRL/go-zond/core/vm"
-	"github.com/holiman/uint256"
 )
 
 var _ = (*structLogMarshaling)(nil)
@@ -17,20 +17,21 @@ var _ = (*structLogMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (s StructLog) MarshalJSON() ([]byte, error) {
 	type StructLog struct {
-		Pc            uint64                      `json:"pc"`
-		Op            vm.OpCode                   `json:"op"`
-		Gas           math.HexOrDecimal64         `json:"gas"`
-		GasCost       math.HexOrDecimal64         `json:"gasCost"`
-		Memory        hexutil.Bytes               `json:"memory,omitempty"`
-		MemorySize    int                         `json:"memSize"`
-		Stack         []uint256.Int               `json:"stack"`
-		ReturnData    hexutil.Bytes               `json:"returnData,omitempty"`
-		Storage       map[common.Hash]common.Hash `json:"-"`
-		Depth         int                         `json:"depth"`
-		RefundCounter uint64                      `json:"refund"`
-		Err           error                       `json:"-"`
-		OpName        string                      `json:"opName"`
-		ErrorString   string                      `json:"error,omitempty"`
+		Pc               uint64                      `json:"pc"`
+		Op               vm.OpCode                   `json:"op"`
+		Gas              math.HexOrDecimal64         `json:"gas"`
+		GasCost          math.HexOrDecimal64         `json:"gasCost"`
+		Memory           hexutil.Bytes               `json:"memory,omitempty"`
+		MemorySize       int                         `json:"memSize"`
+		Stack            []uint256.Int               `json:"stack"`
+		ReturnData       hexutil.Bytes               `json:"returnData,omitempty"`
+		Storage          map[common.Hash]common.Hash `json:"-"`
+		TransientStorage map[common.Hash]common.Hash `json:"transientStorage,omitempty"`
+		Depth            int                         `json:"depth"`
+		RefundCounter    uint64                      `json:"refund"`
+		Err              error                       `json:"-"`
+		OpName           string                      `json:"opName"`
+		ErrorString      string                      `json:"error,omitempty"`
 	}
 	var enc StructLog
 	enc.Pc = s.Pc
@@ -42,6 +43,7 @@ func (s StructLog) MarshalJSON() ([]byte, error) {
 	enc.Stack = s.Stack
 	enc.ReturnData = s.ReturnData
 	enc.Storage = s.Storage
+	enc.TransientStorage = s.TransientStorage
 	enc.Depth = s.Depth
 	enc.RefundCounter = s.RefundCounter
 	enc.Err = s.Err
@@ -53,18 +55,19 @@ func (s StructLog) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (s *StructLog) UnmarshalJSON(input []byte) error {
 	type StructLog struct {
-		Pc            *uint64                     `json:"pc"`
-		Op            *vm.OpCode                  `json:"op"`
-		Gas           *math.HexOrDecimal64        `json:"gas"`
-		GasCost       *math.HexOrDecimal64        `json:"gasCost"`
-		Memory        *hexutil.Bytes              `json:"memory,omitempty"`
-		MemorySize    *int                        `json:"memSize"`
-		Stack         []uint256.Int               `json:"stack"`
-		ReturnData    *hexutil.Bytes              `json:"returnData,omitempty"`
-		Storage       map[common.Hash]common.Hash `json:"-"`
-		Depth         *int                        `json:"depth"`
-		RefundCounter *uint64                     `json:"refund"`
-		Err           error                       `json:"-"`
+		Pc               *uint64                     `json:"pc"`
+		Op               *vm.OpCode                  `json:"op"`
+		Gas              *math.HexOrDecimal64        `json:"gas"`
+		GasCost          *math.HexOrDecimal64        `json:"gasCost"`
+		Memory           *hexutil.Bytes              `json:"memory,omitempty"`
+		MemorySize       *int                        `json:"memSize"`
+		Stack            []uint256.Int               `json:"stack"`
+		ReturnData       *hexutil.Bytes              `json:"returnData,omitempty"`
+		Storage          map[common.Hash]common.Hash `json:"-"`
+		TransientStorage map[common.Hash]common.Hash `json:"transientStorage,omitempty"`
+		Depth            *int                        `json:"depth"`
+		RefundCounter    *uint64                     `json:"refund"`
+		Err              error                       `json:"-"`
 	}
 	var dec StructLog
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -97,6 +100,9 @@ func (s *StructLog) UnmarshalJSON(input []byte) error {
 	if dec.Storage != nil {
 		s.Storage = dec.Storage
 	}
+	if dec.TransientStorage != nil {
+		s.TransientStorage = dec.TransientStorage
+	}
 	if dec.Depth != nil {
 		s.Depth = *dec.Depth
 	}
@@ -63,18 +63,19 @@ type Config struct {
 // StructLog is emitted to the ZVM each cycle and lists information about the current internal state
 // prior to the execution of the statement.
 type StructLog struct {
-	Pc            uint64                      `json:"pc"`
-	Op            vm.OpCode                   `json:"op"`
-	Gas           uint64                      `json:"gas"`
-	GasCost       uint64                      `json:"gasCost"`
-	Memory        []byte                      `json:"memory,omitempty"`
-	MemorySize    int                         `json:"memSize"`
-	Stack         []uint256.Int               `json:"stack"`
-	ReturnData    []byte                      `json:"returnData,omitempty"`
-	Storage       map[common.Hash]common.Hash `json:"-"`
-	Depth         int                         `json:"depth"`
-	RefundCounter uint64                      `json:"refund"`
-	Err           error                       `json:"-"`
+	Pc               uint64                      `json:"pc"`
+	Op               vm.OpCode                   `json:"op"`
+	Gas              uint64                      `json:"gas"`
+	GasCost          uint64                      `json:"gasCost"`
+	Memory           []byte                      `json:"memory,omitempty"`
+	MemorySize       int                         `json:"memSize"`
+	Stack            []uint256.Int               `json:"stack"`
+	ReturnData       []byte                      `json:"returnData,omitempty"`
+	Storage          map[common.Hash]common.Hash `json:"-"`
+	TransientStorage map[common.Hash]common.Hash `json:"transientStorage,omitempty"`
+	Depth            int                         `json:"depth"`
+	RefundCounter    uint64                      `json:"refund"`
+	Err              error                       `json:"-"`
 }
 
 // overrides for gencodec
@@ -104,17 +105,19 @@ func (s *StructLog) ErrorString() string {
 //
 // StructLogger can capture state based on the given Log configuration and also keeps
 // a track record of modified storage which is used in reporting snapshots of the
-// contract their storage.
+// contract their storage. Transient storage accessed via TLOAD/TSTORE is tracked
+// separately, since it is discarded at the end of every transaction.
 type StructLogger struct {
 	cfg Config
 	env *vm.ZVM
 
-	storage  map[common.Address]Storage
-	logs     []StructLog
-	output   []byte
-	err      error
-	gasLimit uint64
-	usedGas  uint64
+	storage          map[common.Address]Storage
+	transientStorage map[common.Address]Storage
+	logs             []StructLog
+	output           []byte
+	err              error
+	gasLimit         uint64
+	usedGas          uint64
 
 	interrupt atomic.Bool // Atomic flag to signal execution interruption
 	reason    error       // Textual reason for the interruption
@@ -123,7 +126,8 @@ type StructLogger struct {
 // NewStructLogger returns a new logger
 func NewStructLogger(cfg *Config) *StructLogger {
 	logger := &StructLogger{
-		storage: make(map[common.Address]Storage),
+		storage:          make(map[common.Address]Storage),
+		transientStorage: make(map[common.Address]Storage),
 	}
 	if cfg != nil {
 		logger.cfg = *cfg
@@ -134,6 +138,7 @@ func NewStructLogger(cfg *Config) *StructLogger {
 // Reset clears the data held by the logger.
 func (l *StructLogger) Reset() {
 	l.storage = make(map[common.Address]Storage)
+	l.transientStorage = make(map[common.Address]Storage)
 	l.output = make([]byte, 0)
 	l.logs = l.logs[:0]
 	l.err = nil
@@ -146,7 +151,8 @@ func (l *StructLogger) CaptureStart(env *vm.ZVM, from common.Address, to common.
 
 // CaptureState logs a new structured log message and pushes it out to the environment
 //
-// CaptureState also tracks SLOAD/SSTORE ops to track storage change.
+// CaptureState also tracks SLOAD/SSTORE ops to track storage change and
+// TLOAD/TSTORE ops to track transient storage change.
 func (l *StructLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
 	// If tracing was interrupted, set the error and stop
 	if l.interrupt.Load() {
@@ -202,13 +208,37 @@ func (l *StructLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, s
 			storage = l.storage[contract.Address()].Copy()
 		}
 	}
+	// Copy a snapshot of the current transient storage to a new container
+	var transientStorage Storage
+	if !l.cfg.DisableStorage && (op == vm.TLOAD || op == vm.TSTORE) {
+		if l.transientStorage[contract.Address()] == nil {
+			l.transientStorage[contract.Address()] = make(Storage)
+		}
+		// capture TLOAD opcodes and record the read entry in the local transient storage
+		if op == vm.TLOAD && stackLen >= 1 {
+			var (
+				address = common.Hash(stackData[stackLen-1].Bytes32())
+				value   = l.env.StateDB.GetTransientState(contract.Address(), address)
+			)
+			l.transientStorage[contract.Address()][address] = value
+			transientStorage = l.transientStorage[contract.Address()].Copy()
+		} else if op == vm.TSTORE && stackLen >= 2 {
+			// capture TSTORE opcodes and record the written entry in the local transient storage.
+			var (
+				value   = common.Hash(stackData[stackLen-2].Bytes32())
+				address = common.Hash(stackData[stackLen-1].Bytes32())
+			)
+			l.transientStorage[contract.Address()][address] = value
+			transientStorage = l.transientStorage[contract.Address()].Copy()
+		}
+	}
 	var rdata []byte
 	if l.cfg.EnableReturnData {
 		rdata = make([]byte, len(rData))
 		copy(rdata, rData)
 	}
 	// create a new snapshot of the ZVM.
-	log := StructLog{pc, op, gas, cost, mem, memory.Len(), stck, rdata, storage, depth, l.env.StateDB.GetRefund(), err}
+	log := StructLog{pc, op, gas, cost, mem, memory.Len(), stck, rdata, storage, transientStorage, depth, l.env.StateDB.GetRefund(), err}
 	l.logs = append(l.logs, log)
 }
 
@@ -303,6 +333,12 @@ func WriteTrace(writer io.Writer, logs []StructLog) {
 				fmt.Fprintf(writer, "%x: %x\n", h, item)
 			}
 		}
+		if len(log.TransientStorage) > 0 {
+			fmt.Fprintln(writer, "TransientStorage:")
+			for h, item := range log.TransientStorage {
+				fmt.Fprintf(writer, "%x: %x\n", h, item)
+			}
+		}
 		if len(log.ReturnData) > 0 {
 			fmt.Fprintln(writer, "ReturnData:")
 			fmt.Fprint(writer, hex.Dump(log.ReturnData))
@@ -411,17 +447,18 @@ type ExecutionResult struct {
 // StructLogRes stores a structured log emitted by the ZVM while replaying a
 // transaction in debug mode
 type StructLogRes struct {
-	Pc            uint64             `json:"pc"`
-	Op            string             `json:"op"`
-	Gas           uint64             `json:"gas"`
-	GasCost       uint64             `json:"gasCost"`
-	Depth         int                `json:"depth"`
-	Error         string             `json:"error,omitempty"`
-	Stack         *[]string          `json:"stack,omitempty"`
-	ReturnData    string             `json:"returnData,omitempty"`
-	Memory        *[]string          `json:"memory,omitempty"`
-	Storage       *map[string]string `json:"storage,omitempty"`
-	RefundCounter uint64             `json:"refund,omitempty"`
+	Pc               uint64             `json:"pc"`
+	Op               string             `json:"op"`
+	Gas              uint64             `json:"gas"`
+	GasCost          uint64             `json:"gasCost"`
+	Depth            int                `json:"depth"`
+	Error            string             `json:"error,omitempty"`
+	Stack            *[]string          `json:"stack,omitempty"`
+	ReturnData       string             `json:"returnData,omitempty"`
+	Memory           *[]string          `json:"memory,omitempty"`
+	Storage          *map[string]string `json:"storage,omitempty"`
+	TransientStorage *map[string]string `json:"transientStorage,omitempty"`
+	RefundCounter    uint64             `json:"refund,omitempty"`
 }
 
 // formatLogs formats ZVM returned structured logs for json output
@@ -461,6 +498,13 @@ func formatLogs(logs []StructLog) []StructLogRes {
 			}
 			formatted[index].Storage = &storage
 		}
+		if trace.TransientStorage != nil {
+			storage := make(map[string]string)
+			for i, storageValue := range trace.TransientStorage {
+				storage[fmt.Sprintf("%x", i)] = fmt.Sprintf("%x", storageValue)
+			}
+			formatted[index].TransientStorage = &storage
+		}
 	}
 	return formatted
 }
@@ -27,9 +27,10 @@ import (
 )
 
 type JSONLogger struct {
-	encoder *json.Encoder
-	cfg     *Config
-	env     *vm.ZVM
+	encoder          *json.Encoder
+	cfg              *Config
+	env              *vm.ZVM
+	transientStorage map[common.Address]Storage
 }
 
 // NewJSONLogger creates a new ZVM tracer that prints execution steps as JSON objects
@@ -44,6 +45,7 @@ func NewJSONLogger(cfg *Config, writer io.Writer) *JSONLogger {
 
 func (l *JSONLogger) CaptureStart(env *vm.ZVM, from, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
 	l.env = env
+	l.transientStorage = make(map[common.Address]Storage)
 }
 
 func (l *JSONLogger) CaptureFault(pc uint64, op vm.OpCode, gas uint64, cost uint64, scope *vm.ScopeContext, depth int, err error) {
@@ -75,6 +77,26 @@ func (l *JSONLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, sco
 	if l.cfg.EnableReturnData {
 		log.ReturnData = rData
 	}
+	// Track the transient storage accessed via TLOAD/TSTORE, it can't be
+	// inspected from the state after the transaction.
+	if !l.cfg.DisableStorage && (op == vm.TLOAD || op == vm.TSTORE) {
+		var (
+			stackData = stack.Data()
+			stackLen  = len(stackData)
+			address   = scope.Contract.Address()
+		)
+		if l.transientStorage[address] == nil {
+			l.transientStorage[address] = make(Storage)
+		}
+		if op == vm.TLOAD && stackLen >= 1 {
+			slot := common.Hash(stackData[stackLen-1].Bytes32())
+			l.transientStorage[address][slot] = l.env.StateDB.GetTransientState(address, slot)
+		} else if op == vm.TSTORE && stackLen >= 2 {
+			slot := common.Hash(stackData[stackLen-1].Bytes32())
+			l.transientStorage[address][slot] = common.Hash(stackData[stackLen-2].Bytes32())
+		}
+		log.TransientStorage = l.transientStorage[address].Copy()
+	}
 	l.encoder.Encode(log)
 }
 
@@ -17,9 +17,11 @@
 package logger
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/theQRL/go-zond/common"
@@ -79,6 +81,74 @@ func TestStoreCapture(t *testing.T) {
 	}
 }
 
+func TestTransientStoreCapture(t *testing.T) {
+	var (
+		logger     = NewStructLogger(nil)
+		statedb, _ = state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		env        = vm.NewZVM(vm.BlockContext{}, vm.TxContext{}, statedb, params.TestChainConfig, vm.Config{Tracer: logger})
+		contract   = vm.NewContract(&dummyContractRef{}, &dummyContractRef{}, new(big.Int), 100000)
+	)
+	contract.Code = []byte{byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.TSTORE), byte(vm.PUSH1), 0x0, byte(vm.TLOAD)}
+	var index common.Hash
+	logger.CaptureStart(env, common.Address{}, contract.Address(), false, nil, 0, nil)
+	_, err := env.Interpreter().Run(contract, []byte{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logger.transientStorage[contract.Address()]) != 1 {
+		t.Fatalf("expected exactly 1 changed transient value on address %x, got %d", contract.Address(),
+			len(logger.transientStorage[contract.Address()]))
+	}
+	exp := common.BigToHash(big.NewInt(1))
+	if logger.transientStorage[contract.Address()][index] != exp {
+		t.Errorf("expected %x, got %x", exp, logger.transientStorage[contract.Address()][index])
+	}
+	if len(logger.storage[contract.Address()]) != 0 {
+		t.Errorf("expected no persistent storage changes, got %d", len(logger.storage[contract.Address()]))
+	}
+	for _, log := range logger.StructLogs() {
+		if log.Op == vm.TLOAD && log.TransientStorage[index] != exp {
+			t.Errorf("expected TLOAD log with transient value %x, got %x", exp, log.TransientStorage[index])
+		}
+	}
+}
+
+func TestJSONLoggerTransientStorage(t *testing.T) {
+	var (
+		out        bytes.Buffer
+		logger     = NewJSONLogger(nil, &out)
+		statedb, _ = state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		env        = vm.NewZVM(vm.BlockContext{}, vm.TxContext{}, statedb, params.TestChainConfig, vm.Config{Tracer: logger})
+		contract   = vm.NewContract(&dummyContractRef{}, &dummyContractRef{}, new(big.Int), 100000)
+	)
+	contract.Code = []byte{byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.TSTORE), byte(vm.PUSH1), 0x0, byte(vm.TLOAD)}
+	logger.CaptureStart(env, common.Address{}, contract.Address(), false, nil, 0, nil)
+	if _, err := env.Interpreter().Run(contract, []byte{}, false); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		found bool
+		want  = map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(1))}
+		dec   = json.NewDecoder(&out)
+	)
+	for dec.More() {
+		var log StructLog
+		if err := dec.Decode(&log); err != nil {
+			t.Fatal(err)
+		}
+		if log.Op != vm.TLOAD {
+			continue
+		}
+		found = true
+		if !reflect.DeepEqual(log.TransientStorage, want) {
+			t.Errorf("unexpected transient storage: have %v, want %v", log.TransientStorage, want)
+		}
+	}
+	if !found {
+		t.Fatal("TLOAD step is not logged")
+	}
+}
+
 // Tests that blank fields don't appear in logs when JSON marshalled, to reduce
 // logs bloat and confusion. See https://github.com/theQRL/go-zond/issues/24487
 func TestStructLogMarshalingOmitEmpty(t *testing.T) {
@@ -0,0 +1,302 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/zond/tracers"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("gasProfileTracer", newGasProfileTracer, false)
+}
+
+// The function names of the profile nodes not called through a selector.
+const (
+	constructorFunction = "constructor"
+	fallbackFunction    = "fallback"
+	precompileFunction  = "precompile"
+)
+
+// opcodeProfile is the gas spent by an opcode.
+type opcodeProfile struct {
+	Gas   uint64 `json:"gas"`
+	Count uint64 `json:"count"`
+}
+
+// pcProfile is the gas spent by the opcode at a program counter.
+type pcProfile struct {
+	Op    string `json:"op"`
+	Gas   uint64 `json:"gas"`
+	Count uint64 `json:"count"`
+}
+
+// profileNode is the gas spent by a function of a contract, aggregated over
+// all the calls reaching it through the same call path. The gas spent by the
+// functions it calls is only included in the inclusive gas, the self gas is
+// further broken down by opcode and program counter.
+type profileNode struct {
+	Address  common.Address            `json:"address"`
+	Function string                    `json:"function"`
+	Calls    uint64                    `json:"calls"`
+	Gas      uint64                    `json:"gas"`
+	SelfGas  uint64                    `json:"selfGas"`
+	Opcodes  map[string]*opcodeProfile `json:"opcodes,omitempty"`
+	PCs      map[uint64]*pcProfile     `json:"pcs,omitempty"`
+	Children []*profileNode            `json:"children,omitempty"`
+
+	children map[profileKey]*profileNode // Children indexed by contract and function
+}
+
+// profileKey identifies the children of a profile node.
+type profileKey struct {
+	address  common.Address
+	function string
+}
+
+// newProfileNode creates an empty profile node of the given function.
+func newProfileNode(address common.Address, function string) *profileNode {
+	return &profileNode{
+		Address:  address,
+		Function: function,
+		Opcodes:  make(map[string]*opcodeProfile),
+		PCs:      make(map[uint64]*pcProfile),
+		children: make(map[profileKey]*profileNode),
+	}
+}
+
+// child returns the child node of the given function, creating it if needed.
+func (n *profileNode) child(address common.Address, function string) *profileNode {
+	key := profileKey{address, function}
+	if child, ok := n.children[key]; ok {
+		return child
+	}
+	child := newProfileNode(address, function)
+	n.children[key] = child
+	n.Children = append(n.Children, child)
+	return child
+}
+
+// spend attributes the given gas to the opcode at the given program counter.
+func (n *profileNode) spend(pc uint64, op vm.OpCode, gas uint64) {
+	n.SelfGas += gas
+
+	opcode, ok := n.Opcodes[op.String()]
+	if !ok {
+		opcode = new(opcodeProfile)
+		n.Opcodes[op.String()] = opcode
+	}
+	opcode.Gas += gas
+	opcode.Count++
+
+	step, ok := n.PCs[pc]
+	if !ok {
+		step = &pcProfile{Op: op.String()}
+		n.PCs[pc] = step
+	}
+	step.Gas += gas
+	step.Count++
+}
+
+// refund takes back the given gas from the last step at the given program
+// counter, without changing the step count.
+func (n *profileNode) refund(pc uint64, op vm.OpCode, gas uint64) {
+	n.SelfGas -= gas
+	n.Opcodes[op.String()].Gas -= gas
+	n.PCs[pc].Gas -= gas
+}
+
+// finalise computes the inclusive gas of the node and its descendants.
+func (n *profileNode) finalise() uint64 {
+	n.Gas = n.SelfGas
+	for _, child := range n.Children {
+		n.Gas += child.finalise()
+	}
+	return n.Gas
+}
+
+// profileFrame is a call being executed.
+type profileFrame struct {
+	node      *profileNode
+	accounted uint64 // Gas attributed to the steps of the call and its children
+
+	// The last step of the call, to take back the gas forwarded by the call
+	// opcodes from their cost.
+	lastPC   uint64
+	lastOp   vm.OpCode
+	lastCost uint64
+}
+
+// gasProfileTracer attributes the gas spent by a transaction to the contracts
+// and functions executed, identified by their 4-byte selector, and within each
+// function to the opcodes and program counters. The result is a call tree,
+// aggregated by contract and function along each call path.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "gasProfileTracer"})
+//	{
+//	  address: "Z...", function: "0xa9059cbb", calls: 1, gas: 29455, selfGas: 29455,
+//	  opcodes: {SSTORE: {gas: 22100, count: 2}, ...},
+//	  pcs: {"123": {op: "SSTORE", gas: 20000, count: 1}, ...}
+//	}
+type gasProfileTracer struct {
+	noopTracer
+	root              *profileNode
+	callstack         []*profileFrame
+	activePrecompiles []common.Address // Updated on CaptureStart based on given rules
+	interrupt         atomic.Bool      // Atomic flag to signal execution interruption
+	reason            error            // Textual reason for the interruption
+}
+
+// newGasProfileTracer returns a native go tracer which profiles the gas spent
+// by a tx, and implements vm.ZVMLogger.
+func newGasProfileTracer(ctx *tracers.Context, _ json.RawMessage) (tracers.Tracer, error) {
+	return &gasProfileTracer{}, nil
+}
+
+// isPrecompiled returns whether the addr is a precompile.
+func (t *gasProfileTracer) isPrecompiled(addr common.Address) bool {
+	for _, p := range t.activePrecompiles {
+		if p == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// function names the function executed by a call, by its 4-byte selector.
+func (t *gasProfileTracer) function(typ vm.OpCode, to common.Address, input []byte) string {
+	switch {
+	case typ == vm.CREATE || typ == vm.CREATE2:
+		return constructorFunction
+	case t.isPrecompiled(to):
+		return precompileFunction
+	case len(input) >= 4:
+		return bytesToHex(input[:4])
+	default:
+		return fallbackFunction
+	}
+}
+
+// CaptureStart implements the ZVMLogger interface to initialize the tracing operation.
+func (t *gasProfileTracer) CaptureStart(env *vm.ZVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	// Update list of precompiles based on current block
+	rules := env.ChainConfig().Rules(env.Context.BlockNumber, env.Context.Time)
+	t.activePrecompiles = vm.ActivePrecompiles(rules)
+
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+	t.root = newProfileNode(to, t.function(typ, to, input))
+	t.root.Calls = 1
+	t.callstack = []*profileFrame{{node: t.root}}
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *gasProfileTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	if len(t.callstack) != 1 {
+		return
+	}
+	t.exit(gasUsed)
+}
+
+// CaptureState implements the ZVMLogger interface to trace a single step of VM execution.
+func (t *gasProfileTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	// skip if the previous op caused an error
+	if err != nil {
+		return
+	}
+	// Skip if tracing was interrupted
+	if t.interrupt.Load() {
+		return
+	}
+	frame := t.callstack[len(t.callstack)-1]
+	frame.node.spend(pc, op, cost)
+	frame.accounted += cost
+	frame.lastPC, frame.lastOp, frame.lastCost = pc, op, cost
+}
+
+// CaptureEnter is called when ZVM enters a new scope (via call or create).
+func (t *gasProfileTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	parent := t.callstack[len(t.callstack)-1]
+
+	// The cost of the call opcodes includes the gas forwarded to the callee,
+	// which is accounted for by the callee instead. The stipend of the value
+	// transfers is granted on top, so it is credited back to the call too to
+	// keep the inclusive gas in line with the gas actually spent.
+	if typ == vm.CALL || typ == vm.DELEGATECALL || typ == vm.STATICCALL {
+		forwarded := gas
+		if forwarded = min(forwarded, parent.lastCost); forwarded > 0 {
+			parent.node.refund(parent.lastPC, parent.lastOp, forwarded)
+			parent.accounted -= forwarded
+			parent.lastCost -= forwarded
+		}
+	}
+	node := parent.node.child(to, t.function(typ, to, input))
+	node.Calls++
+	t.callstack = append(t.callstack, &profileFrame{node: node})
+}
+
+// CaptureExit is called when ZVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *gasProfileTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if len(t.callstack) <= 1 {
+		return
+	}
+	t.exit(gasUsed)
+	t.callstack[len(t.callstack)-1].accounted += gasUsed
+}
+
+// exit pops the current call, attributing the gas not spent by its steps, e.g.
+// by precompiles, code deposit or failures, to the function itself.
+func (t *gasProfileTracer) exit(gasUsed uint64) {
+	frame := t.callstack[len(t.callstack)-1]
+	t.callstack = t.callstack[:len(t.callstack)-1]
+
+	if gasUsed > frame.accounted {
+		frame.node.SelfGas += gasUsed - frame.accounted
+	}
+}
+
+// GetResult returns the json-encoded gas profile tree, and any error arising
+// from the encoding or forceful termination (via `Stop`).
+func (t *gasProfileTracer) GetResult() (json.RawMessage, error) {
+	if t.root == nil {
+		return nil, errors.New("no call profiled")
+	}
+	t.root.finalise()
+
+	res, err := json.Marshal(t.root)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *gasProfileTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
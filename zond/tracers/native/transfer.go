@@ -0,0 +1,289 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/theQRL/go-zond/accounts/abi"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/zond/tracers"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("tokenTransferTracer", newTokenTransferTracer, false)
+}
+
+var (
+	// transferTopic is the topic of the ZRC-20 and ZRC-721 Transfer events.
+	transferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+	// transferSingleTopic is the topic of the ZRC-1155 TransferSingle event.
+	transferSingleTopic = common.HexToHash("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62")
+
+	// transferBatchTopic is the topic of the ZRC-1155 TransferBatch event.
+	transferBatchTopic = common.HexToHash("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb")
+
+	// transferBatchArgs are the non-indexed arguments of the ZRC-1155
+	// TransferBatch event.
+	transferBatchArgs = func() abi.Arguments {
+		typ, _ := abi.NewType("uint256[]", "", nil)
+		return abi.Arguments{{Type: typ}, {Type: typ}}
+	}()
+)
+
+// The kinds of asset movements reported by the token transfer tracer.
+const (
+	nativeTransfer  = "native"
+	zrc20Transfer   = "zrc20"
+	zrc721Transfer  = "zrc721"
+	zrc1155Transfer = "zrc1155"
+)
+
+// tokenTransfer is a single asset movement, along with the path of the call
+// which caused it. The call path holds the index of each nested call within
+// its parent, being empty for the top call of the transaction.
+type tokenTransfer struct {
+	Type     string          `json:"type"`
+	Token    *common.Address `json:"token,omitempty"`
+	Operator *common.Address `json:"operator,omitempty"`
+	From     common.Address  `json:"from"`
+	To       common.Address  `json:"to"`
+	TokenID  *hexutil.Big    `json:"tokenId,omitempty"`
+	Value    *hexutil.Big    `json:"value,omitempty"`
+	CallPath []int           `json:"callPath"`
+}
+
+// transferFrame holds the asset movements of a call which are only reported if
+// neither the call nor any of its parents fail.
+type transferFrame struct {
+	path      []int
+	calls     int
+	transfers []tokenTransfer
+}
+
+// tokenTransferTracer reports the asset movements of a transaction in the order
+// they happen: the native value transferred by the calls and the tokens moved
+// by the ZRC-20, ZRC-721 and ZRC-1155 transfer events. The movements of the
+// failed calls are left out.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "tokenTransferTracer"})
+//	[
+//	  {type: "native", from: "Z...", to: "Z...", value: "0xde0b6b3a7640000", callPath: []},
+//	  {type: "zrc20", token: "Z...", from: "Z...", to: "Z...", value: "0x64", callPath: [0]}
+//	]
+type tokenTransferTracer struct {
+	noopTracer
+	callstack []transferFrame
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newTokenTransferTracer returns a native go tracer which collects the asset
+// movements of a tx, and implements vm.ZVMLogger.
+func newTokenTransferTracer(ctx *tracers.Context, _ json.RawMessage) (tracers.Tracer, error) {
+	return &tokenTransferTracer{callstack: make([]transferFrame, 1)}, nil
+}
+
+// CaptureStart implements the ZVMLogger interface to initialize the tracing operation.
+func (t *tokenTransferTracer) CaptureStart(env *vm.ZVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.callstack[0] = transferFrame{path: []int{}}
+	t.addNative(from, to, value)
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *tokenTransferTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	if err != nil {
+		t.callstack[0].transfers = nil
+	}
+}
+
+// CaptureState implements the ZVMLogger interface to trace a single step of VM execution.
+func (t *tokenTransferTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	// skip if the previous op caused an error
+	if err != nil {
+		return
+	}
+	// Skip if tracing was interrupted
+	if t.interrupt.Load() {
+		return
+	}
+	// All the transfer events have three or four topics
+	if op != vm.LOG3 && op != vm.LOG4 {
+		return
+	}
+	var (
+		size      = int(op - vm.LOG0)
+		stackData = scope.Stack.Data()
+		mStart    = stackData[len(stackData)-1]
+		mSize     = stackData[len(stackData)-2]
+		topics    = make([]common.Hash, size)
+	)
+	for i := 0; i < size; i++ {
+		topics[i] = common.Hash(stackData[len(stackData)-2-(i+1)].Bytes32())
+	}
+	data, err := tracers.GetMemoryCopyPadded(scope.Memory, int64(mStart.Uint64()), int64(mSize.Uint64()))
+	if err != nil {
+		// mSize was unrealistically large
+		log.Warn("failed to copy log data", "err", err, "tracer", "tokenTransferTracer", "offset", mStart, "size", mSize)
+		return
+	}
+	t.addLog(scope.Contract.Address(), topics, data)
+}
+
+// CaptureEnter is called when ZVM enters a new scope (via call or create).
+func (t *tokenTransferTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	parent := &t.callstack[len(t.callstack)-1]
+	path := make([]int, len(parent.path), len(parent.path)+1)
+	copy(path, parent.path)
+	path = append(path, parent.calls)
+	parent.calls++
+	t.callstack = append(t.callstack, transferFrame{path: path})
+
+	// Delegate calls report the value of the parent call, no value is moved
+	if typ == vm.CALL || typ == vm.CREATE || typ == vm.CREATE2 {
+		t.addNative(from, to, value)
+	}
+}
+
+// CaptureExit is called when ZVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *tokenTransferTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	size := len(t.callstack)
+	if size <= 1 {
+		return
+	}
+	// pop call, handing over its transfers to the parent if succeeded
+	call := t.callstack[size-1]
+	t.callstack = t.callstack[:size-1]
+	if err == nil {
+		t.callstack[size-2].transfers = append(t.callstack[size-2].transfers, call.transfers...)
+	}
+}
+
+// GetResult returns the json-encoded list of asset movements, and any error
+// arising from the encoding or forceful termination (via `Stop`).
+func (t *tokenTransferTracer) GetResult() (json.RawMessage, error) {
+	if len(t.callstack) != 1 {
+		return nil, errors.New("incorrect number of top-level calls")
+	}
+	transfers := t.callstack[0].transfers
+	if transfers == nil {
+		transfers = []tokenTransfer{}
+	}
+	res, err := json.Marshal(transfers)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *tokenTransferTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
+
+// add records an asset movement caused by the current call.
+func (t *tokenTransferTracer) add(transfer tokenTransfer) {
+	frame := &t.callstack[len(t.callstack)-1]
+	transfer.CallPath = frame.path
+	frame.transfers = append(frame.transfers, transfer)
+}
+
+// addNative records a native value transfer, if any value is moved.
+func (t *tokenTransferTracer) addNative(from common.Address, to common.Address, value *big.Int) {
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+	t.add(tokenTransfer{
+		Type:  nativeTransfer,
+		From:  from,
+		To:    to,
+		Value: (*hexutil.Big)(new(big.Int).Set(value)),
+	})
+}
+
+// addLog decodes the token transfers of an event emitted by the given token
+// contract. Events not matching any of the transfer events are ignored.
+func (t *tokenTransferTracer) addLog(token common.Address, topics []common.Hash, data []byte) {
+	switch {
+	case topics[0] == transferTopic && len(topics) == 3 && len(data) == 32:
+		// ZRC-20 Transfer(address indexed from, address indexed to, uint256 value)
+		t.add(tokenTransfer{
+			Type:  zrc20Transfer,
+			Token: &token,
+			From:  common.BytesToAddress(topics[1].Bytes()),
+			To:    common.BytesToAddress(topics[2].Bytes()),
+			Value: (*hexutil.Big)(new(big.Int).SetBytes(data)),
+		})
+
+	case topics[0] == transferTopic && len(topics) == 4 && len(data) == 0:
+		// ZRC-721 Transfer(address indexed from, address indexed to, uint256 indexed tokenId)
+		t.add(tokenTransfer{
+			Type:    zrc721Transfer,
+			Token:   &token,
+			From:    common.BytesToAddress(topics[1].Bytes()),
+			To:      common.BytesToAddress(topics[2].Bytes()),
+			TokenID: (*hexutil.Big)(topics[3].Big()),
+		})
+
+	case topics[0] == transferSingleTopic && len(topics) == 4 && len(data) == 64:
+		// ZRC-1155 TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value)
+		operator := common.BytesToAddress(topics[1].Bytes())
+		t.add(tokenTransfer{
+			Type:     zrc1155Transfer,
+			Token:    &token,
+			Operator: &operator,
+			From:     common.BytesToAddress(topics[2].Bytes()),
+			To:       common.BytesToAddress(topics[3].Bytes()),
+			TokenID:  (*hexutil.Big)(new(big.Int).SetBytes(data[:32])),
+			Value:    (*hexutil.Big)(new(big.Int).SetBytes(data[32:])),
+		})
+
+	case topics[0] == transferBatchTopic && len(topics) == 4:
+		// ZRC-1155 TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values)
+		unpacked, err := transferBatchArgs.Unpack(data)
+		if err != nil {
+			return
+		}
+		ids, values := unpacked[0].([]*big.Int), unpacked[1].([]*big.Int)
+		if len(ids) != len(values) {
+			return
+		}
+		operator := common.BytesToAddress(topics[1].Bytes())
+		for i := range ids {
+			t.add(tokenTransfer{
+				Type:     zrc1155Transfer,
+				Token:    &token,
+				Operator: &operator,
+				From:     common.BytesToAddress(topics[2].Bytes()),
+				To:       common.BytesToAddress(topics[3].Bytes()),
+				TokenID:  (*hexutil.Big)(ids[i]),
+				Value:    (*hexutil.Big)(values[i]),
+			})
+		}
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/theQRL/go-zond/consensus/beacon"
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/txpool/blobpool"
 	"github.com/theQRL/go-zond/core/txpool/legacypool"
 	"github.com/theQRL/go-zond/miner"
 	"github.com/theQRL/go-zond/params"
@@ -57,6 +58,7 @@ var Defaults = Config{
 	FilterLogCacheSize: 32,
 	Miner:              miner.DefaultConfig,
 	TxPool:             legacypool.DefaultConfig,
+	BlobPool:           blobpool.DefaultConfig,
 	RPCGasCap:          50000000,
 	RPCZVMTimeout:      5 * time.Second,
 	GPO:                FullNodeGPO,
@@ -86,6 +88,10 @@ type Config struct {
 
 	TransactionHistory uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
 	StateHistory       uint64 `toml:",omitempty"` // The maximum number of blocks from head whose state histories are reserved.
+	StateDiffs         bool   `toml:",omitempty"` // Whether to persist the account and storage changes of each block, reserved as the state histories.
+	TraceIndex         bool   `toml:",omitempty"` // Whether to index the call traces of the new blocks by address for the trace namespace.
+	HistoryHorizon     uint64 `toml:",omitempty"` // The number of blocks from head whose bodies and receipts are retained, 0 means all.
+	HistoryEraDir      string `toml:",omitempty"` // Directory the expired bodies and receipts are exported into as era files.
 
 	// State scheme represents the scheme used to store zond states and trie
 	// nodes on top. It can be 'hash', 'path', or none which means use the scheme
@@ -116,7 +122,8 @@ type Config struct {
 	Miner miner.Config
 
 	// Transaction pool options
-	TxPool legacypool.Config
+	TxPool   legacypool.Config
+	BlobPool blobpool.Config
 
 	// Gas Price Oracle options
 	GPO gasprice.Config
@@ -124,6 +131,10 @@ type Config struct {
 	// Enables tracking of SHA3 preimages in the VM
 	EnablePreimageRecording bool
 
+	// Enables live tracing of the block processing with the named tracer
+	VMTrace           string
+	VMTraceJsonConfig string
+
 	// Miscellaneous options
 	DocRoot string `toml:"-"`
 
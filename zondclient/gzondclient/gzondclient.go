@@ -166,6 +166,19 @@ func (ec *Client) CallContractWithBlockOverrides(ctx context.Context, msg zond.C
 	return hex, err
 }
 
+// SimulateV1 executes series of calls on top of the state at the given block,
+// packed into consecutive simulated blocks whose header fields and state may
+// be overridden. The calls of each block are executed sequentially, every one
+// of them seeing the state changes of the ones before.
+//
+// blockNumber selects the block height the simulation starts from. It can be
+// nil, in which case the latest known block is used.
+func (ec *Client) SimulateV1(ctx context.Context, opts SimulateOptions, blockNumber *big.Int) ([]*SimulateBlockResult, error) {
+	var result []*SimulateBlockResult
+	err := ec.c.CallContext(ctx, &result, "zond_simulateV1", opts, toBlockNumArg(blockNumber))
+	return result, err
+}
+
 // GCStats retrieves the current garbage collection stats from a gzond node.
 func (ec *Client) GCStats(ctx context.Context) (*debug.GCStats, error) {
 	var result debug.GCStats
@@ -335,3 +348,71 @@ func (o BlockOverrides) MarshalJSON() ([]byte, error) {
 	}
 	return json.Marshal(output)
 }
+
+// SimulateOptions are the options of a SimulateV1 request.
+type SimulateOptions struct {
+	// BlockStateCalls are the blocks to simulate, in order.
+	BlockStateCalls []SimulateBlock `json:"blockStateCalls"`
+	// Validation enables the transaction validity checks of regular block
+	// processing, e.g. nonces, balances, base fee and signatures.
+	Validation bool `json:"validation"`
+	// ReturnFullTransactions includes the full transactions instead of their
+	// hashes in the simulated blocks.
+	ReturnFullTransactions bool `json:"returnFullTransactions"`
+}
+
+// SimulateBlock is a simulated block along with the calls to execute in it.
+type SimulateBlock struct {
+	// BlockOverrides specifies the header fields of the block to override.
+	BlockOverrides *BlockOverrides
+	// StateOverrides specifies the state to override prior to executing the
+	// calls of the block.
+	StateOverrides *map[common.Address]OverrideAccount
+	// Calls are the calls to execute in the block.
+	Calls []zond.CallMsg
+}
+
+func (b SimulateBlock) MarshalJSON() ([]byte, error) {
+	type block struct {
+		BlockOverrides *BlockOverrides                     `json:"blockOverrides,omitempty"`
+		StateOverrides *map[common.Address]OverrideAccount `json:"stateOverrides,omitempty"`
+		Calls          []interface{}                       `json:"calls"`
+	}
+	output := block{
+		BlockOverrides: b.BlockOverrides,
+		StateOverrides: b.StateOverrides,
+		Calls:          make([]interface{}, len(b.Calls)),
+	}
+	for i, call := range b.Calls {
+		output.Calls[i] = toCallArg(call)
+	}
+	return json.Marshal(output)
+}
+
+// SimulateBlockResult is the result of a simulated block.
+type SimulateBlockResult struct {
+	Number        hexutil.Uint64       `json:"number"`
+	Hash          common.Hash          `json:"hash"`
+	Timestamp     hexutil.Uint64       `json:"timestamp"`
+	GasLimit      hexutil.Uint64       `json:"gasLimit"`
+	GasUsed       hexutil.Uint64       `json:"gasUsed"`
+	FeeRecipient  common.Address       `json:"miner"`
+	BaseFeePerGas *hexutil.Big         `json:"baseFeePerGas"`
+	Calls         []SimulateCallResult `json:"calls"`
+}
+
+// SimulateCallResult is the result of a simulated call.
+type SimulateCallResult struct {
+	ReturnValue hexutil.Bytes  `json:"returnData"`
+	Logs        []*types.Log   `json:"logs"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Status      hexutil.Uint64 `json:"status"`
+	Error       *CallError     `json:"error,omitempty"`
+}
+
+// CallError is the error of a failed simulated call.
+type CallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
@@ -158,6 +158,10 @@ func TestGzondClient(t *testing.T) {
 			"TestCallContractWithBlockOverrides",
 			func(t *testing.T) { testCallContractWithBlockOverrides(t, client) },
 		},
+		{
+			"TestSimulateV1",
+			func(t *testing.T) { testSimulateV1(t, client) },
+		},
 		// The testaccesslist is a bit time-sensitive: the newTestBackend imports
 		// one block. The `testAccessList` fails if the miner has not yet created a
 		// new pending-block after the import event.
@@ -591,3 +595,47 @@ func testCallContractWithBlockOverrides(t *testing.T, client *rpc.Client) {
 		t.Fatalf("unexpected result: %x", res)
 	}
 }
+
+func testSimulateV1(t *testing.T, client *rpc.Client) {
+	zc := New(client)
+	var (
+		sender    = common.Address{0x11}
+		recipient = common.Address{0x22}
+	)
+	// Fund an account in the first block and spend the funds in the second one
+	opts := SimulateOptions{
+		BlockStateCalls: []SimulateBlock{
+			{
+				BlockOverrides: &BlockOverrides{Time: 10000},
+				StateOverrides: &map[common.Address]OverrideAccount{
+					sender: {Balance: big.NewInt(1000)},
+				},
+				Calls: []zond.CallMsg{{From: sender, To: &recipient, Value: big.NewInt(1000)}},
+			},
+			{
+				Calls: []zond.CallMsg{{From: recipient, To: &sender, Value: big.NewInt(600)}},
+			},
+		},
+	}
+	results, err := zc.SimulateV1(context.Background(), opts, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("block count mismatch: have %d, want 2", len(results))
+	}
+	if results[0].Number != 2 || results[0].Timestamp != 10000 {
+		t.Fatalf("block override mismatch: number %d, time %d", results[0].Number, results[0].Timestamp)
+	}
+	if results[1].Number != 3 || results[1].Timestamp <= results[0].Timestamp {
+		t.Fatalf("block mismatch: number %d, time %d", results[1].Number, results[1].Timestamp)
+	}
+	for i, result := range results {
+		if len(result.Calls) != 1 {
+			t.Fatalf("block %d: call count mismatch: have %d, want 1", i, len(result.Calls))
+		}
+		if call := result.Calls[0]; uint64(call.Status) != types.ReceiptStatusSuccessful || call.Error != nil {
+			t.Fatalf("block %d: call failed: status %d, error %v", i, call.Status, call.Error)
+		}
+	}
+}